@@ -48,7 +48,9 @@ func main() {
 		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
 	}
 
-	if err = tf6server.Serve(akamai.ProviderRegistryPath, muxServer.ProviderServer, serveOpts...); err != nil {
+	err = tf6server.Serve(akamai.ProviderRegistryPath, muxServer.ProviderServer, serveOpts...)
+	akamai.Shutdown()
+	if err != nil {
 		log.Fatal(err)
 	}
 }
@@ -2,8 +2,12 @@
 package akamai
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
 	"github.com/akamai/terraform-provider-akamai/v6/version"
 )
 
@@ -16,6 +20,44 @@ const (
 	ProviderName = "terraform-provider-akamai"
 )
 
+// shutdownTimeout bounds the work done when the provider shuts down, as go-plugin kills the provider process
+// if it does not exit within 2 seconds after Terraform is done with it
+const shutdownTimeout = 1500 * time.Millisecond
+
+// Shutdown is called when the provider process is shutting down.
+// It emits the summary of API requests made during the run to the log and to the metrics summary file, if configured,
+// exports the remaining spans and closes the log files. It returns after shutdownTimeout even if this work is not done.
+func Shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		shutdown(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		providerMetrics.log.Errorf("provider shutdown did not finish within %s", shutdownTimeout)
+	}
+}
+
+func shutdown(ctx context.Context) {
+	if err := providerMetrics.emitSummary(); err != nil {
+		providerMetrics.log.Errorf("failed to write metrics summary: %s", err)
+	}
+
+	if err := tracing.Shutdown(ctx); err != nil {
+		providerMetrics.log.Errorf("failed to export spans: %s", err)
+	}
+
+	if err := logger.CloseFiles(); err != nil {
+		providerMetrics.log.Errorf("failed to close log files: %s", err)
+	}
+}
+
 func userAgent(terraformVersion string) string {
 	return fmt.Sprintf("Terraform/%s (+https://www.terraform.io) %s/%s", terraformVersion,
 		ProviderName, version.ProviderVersion)
@@ -3,9 +3,10 @@ package akamai
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
-	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -15,56 +16,197 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/retryablehttp"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
+	"github.com/apex/log"
 	"github.com/google/uuid"
 	"github.com/spf13/cast"
 )
 
+// ErrUnsupportedCacheBackend is returned when the configured cache backend is not supported
+var ErrUnsupportedCacheBackend = errors.New("unsupported cache backend")
+
+const (
+	cacheBackendMemory = "memory"
+	cacheBackendFile   = "file"
+)
+
 type contextConfig struct {
-	edgegridConfig *edgegrid.Config
-	userAgent      string
-	ctx            context.Context
-	requestLimit   int
-	enableCache    bool
-	retryMax       int
-	retryWaitMin   time.Duration
-	retryWaitMax   time.Duration
-	retryDisabled  bool
+	edgegridConfig     *edgegrid.Config
+	profiles           map[string]*edgegrid.Config
+	accountKey         string
+	userAgent          string
+	ctx                context.Context
+	requestLimit       int
+	rateLimits         []rateLimit
+	rateLimitAdaptive  bool
+	cassetteDir        string
+	cassetteMode       string
+	logFormat          string
+	logFile            string
+	metricsSummaryFile string
+	tracingExporter    string
+	tracingEndpoint    string
+	tracingFile        string
+	operationID        string
+	enableCache        bool
+	cacheBackend       string
+	cacheDir           string
+	cacheTTL           time.Duration
+	retryMax           int
+	retryWriteMax      int
+	retryMethods       []string
+	retryWaitMin       time.Duration
+	retryWaitMax       time.Duration
+	retryDisabled      bool
 }
 
 func configureContext(cfg contextConfig) (*meta.OperationMeta, error) {
 	operationID := uuid.NewString()
-	log := logger.FromContext(cfg.ctx, "OperationID", operationID)
+	cfg.operationID = operationID
+	log, err := newLogger(cfg, operationID)
+	if err != nil {
+		return nil, err
+	}
+	providerMetrics.configure(cfg.metricsSummaryFile, log)
+
+	err = tracing.Configure(cfg.ctx, tracing.Config{
+		Exporter: cfg.tracingExporter,
+		Endpoint: cfg.tracingEndpoint,
+		File:     cfg.tracingFile,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	edgegridConfig, profileConfigs := withAccountKeys(cfg)
+	sess, err := newSession(cfg, edgegridConfig, log)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[string]session.Session, len(profileConfigs))
+	for name, profileConfig := range profileConfigs {
+		profiles[name], err = newSession(cfg, profileConfig, log.WithField("CredentialsProfile", name))
+		if err != nil {
+			return nil, err
+		}
+	}
+	cache.Enable(cfg.enableCache)
+	err = cache.ConfigureStore(cacheStoreKey(cfg, edgegridConfig), func() (cache.Store, error) {
+		return newCacheStore(cfg, edgegridConfig)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return meta.New(sess, log.HCLog(), operationID, meta.WithCredentialsProfiles(profiles))
+}
+
+// withAccountKeys returns the configs of the default credentials and of the credentials profiles
+// with the account switch keys applied to all their requests, which are, in the order of precedence:
+//   - for the default credentials: the provider 'account_switch_key', then 'account_key' of the edgerc section or 'config' block
+//   - for a credentials profile: 'account_key' of the profile, then the provider 'account_switch_key'
+//
+// The 'account_switch_key' argument of a resource takes precedence over both, see accountSwitchKeySigner.
+func withAccountKeys(cfg contextConfig) (edgegrid.Config, map[string]edgegrid.Config) {
+	edgegridConfig := *cfg.edgegridConfig
+	if cfg.accountKey != "" {
+		edgegridConfig.AccountKey = cfg.accountKey
+	}
+
+	profiles := make(map[string]edgegrid.Config, len(cfg.profiles))
+	for name, profileConfig := range cfg.profiles {
+		profile := *profileConfig
+		if profile.AccountKey == "" {
+			profile.AccountKey = cfg.accountKey
+		}
+		profiles[name] = profile
+	}
+	return edgegridConfig, profiles
+}
+
+// newLogger returns the logger from the context, unless the provider is configured
+// with its own log format or file
+func newLogger(cfg contextConfig, operationID string) (*logger.Logger, error) {
+	if cfg.logFormat == "" && cfg.logFile == "" {
+		return logger.FromContext(cfg.ctx, "OperationID", operationID), nil
+	}
+	hclog, err := logger.NewHCLog(cfg.logFormat, cfg.logFile)
+	if err != nil {
+		return nil, err
+	}
+	return logger.FromHCLog(hclog.With("OperationID", operationID)), nil
+}
 
+func newSession(cfg contextConfig, edgegridConfig edgegrid.Config, log log.Interface) (session.Session, error) {
 	opts := []session.Option{
-		session.WithSigner(cfg.edgegridConfig),
+		session.WithSigner(newAccountSwitchKeySigner(edgegridConfig)),
 		session.WithUserAgent(cfg.userAgent),
 		session.WithLog(log),
 		session.WithHTTPTracing(cast.ToBool(os.Getenv("AKAMAI_HTTP_TRACE_ENABLED"))),
 		session.WithRequestLimit(cfg.requestLimit),
 	}
-	var sess session.Session
-	var err error
 	if cfg.retryDisabled {
-		sess, err = sessionWithoutRetry(opts)
-	} else {
-		sess, err = sessionWithRetry(cfg, opts)
+		return sessionWithoutRetry(cfg, opts, log)
 	}
-	if err != nil {
-		return nil, err
+	return sessionWithRetry(cfg, opts, log)
+}
+
+// cacheStoreKey returns the key identifying the configuration of the cache store, see cache.ConfigureStore
+func cacheStoreKey(cfg contextConfig, edgegridConfig edgegrid.Config) string {
+	backend, ttl := cfg.cacheBackend, cfg.cacheTTL
+	if backend == "" {
+		backend = cacheBackendMemory
+	}
+	if ttl == 0 {
+		ttl = cache.DefaultTTL
+	}
+	return strings.Join([]string{backend, cfg.cacheDir, edgegridConfig.Host, edgegridConfig.AccountKey, ttl.String()}, "|")
+}
+
+// newCacheStore returns the cache store selected in the provider configuration.
+// The file store is namespaced with the API host and the account switch key of the default credentials,
+// so that the entries are never shared between different accounts. Operations made with credentials profiles
+// or account switch keys of resources do not use the cache, see resolveMetaArguments.
+func newCacheStore(cfg contextConfig, edgegridConfig edgegrid.Config) (cache.Store, error) {
+	if cfg.cacheTTL == 0 {
+		cfg.cacheTTL = cache.DefaultTTL
 	}
-	cache.Enable(cfg.enableCache)
 
-	return meta.New(sess, log.HCLog(), operationID)
+	switch cfg.cacheBackend {
+	case "", cacheBackendMemory:
+		return cache.NewMemoryStore(cfg.cacheTTL)
+	case cacheBackendFile:
+		dir := cfg.cacheDir
+		if dir == "" {
+			userCacheDir, err := os.UserCacheDir()
+			if err != nil {
+				return nil, fmt.Errorf("cannot determine cache directory: %w", err)
+			}
+			dir = filepath.Join(userCacheDir, ProviderName)
+		}
+		return cache.NewFileStore(dir, edgegridConfig.Host+"|"+edgegridConfig.AccountKey, cfg.cacheTTL)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCacheBackend, cfg.cacheBackend)
+	}
 }
 
-func sessionWithoutRetry(opts []session.Option) (session.Session, error) {
+func sessionWithoutRetry(cfg contextConfig, opts []session.Option, log log.Interface) (session.Session, error) {
+	transport, err := newTransport(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, session.WithClient(&http.Client{Transport: transport}))
 	return session.New(opts...)
 }
 
-func sessionWithRetry(cfg contextConfig, opts []session.Option) (session.Session, error) {
+func sessionWithRetry(cfg contextConfig, opts []session.Option, log log.Interface) (session.Session, error) {
 	if cfg.retryMax == 0 {
 		cfg.retryMax = 10
 	}
+	if cfg.retryWriteMax == 0 {
+		cfg.retryWriteMax = 3
+	}
 	if cfg.retryWaitMin == 0 {
 		cfg.retryWaitMin = time.Duration(1) * time.Second
 	}
@@ -72,12 +214,27 @@ func sessionWithRetry(cfg contextConfig, opts []session.Option) (session.Session
 		cfg.retryWaitMax = time.Duration(30) * time.Second
 	}
 
+	policy, err := newRetryPolicy(cfg.retryMethods, cfg.retryMax, cfg.retryWriteMax)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := newTransport(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
 	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = cfg.retryMax
+	retryClient.RetryMax = policy.retryMax()
 	retryClient.RetryWaitMin = cfg.retryWaitMin
 	retryClient.RetryWaitMax = cfg.retryWaitMax
+	retryClient.CheckRetry = policy.CheckRetry
+	retryClient.Backoff = policy.Backoff
+	retryClient.HTTPClient.Transport = transport
 
-	opts = append(opts, session.WithClient(retryClient.StandardClient()))
+	opts = append(opts, session.WithClient(&http.Client{
+		Transport: &retryAttemptsTransport{next: retryClient.StandardClient().Transport},
+	}))
 	sess, err := session.New(opts...)
 	if err != nil {
 		return nil, err
@@ -91,20 +248,5 @@ func sessionWithRetry(cfg contextConfig, opts []session.Option) (session.Session
 		return sess.Sign(req)
 	}
 
-	retryClient.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
-		var urlErr *url.Error
-		if (resp != nil && resp.Request.Method == http.MethodGet) ||
-			(resp == nil && errors.As(err, &urlErr) && strings.ToUpper(urlErr.Op) == http.MethodGet) {
-			if ctx.Err() != nil {
-				return false, ctx.Err()
-			}
-			if resp != nil && resp.StatusCode == http.StatusConflict {
-				return true, nil
-			}
-			return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
-		}
-		return false, nil
-	}
-
 	return sess, nil
 }
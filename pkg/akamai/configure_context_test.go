@@ -0,0 +1,133 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAccountKeys(t *testing.T) {
+	tests := map[string]struct {
+		configKey          string
+		providerKey        string
+		profileKey         string
+		expectedDefaultKey string
+		expectedProfileKey string
+	}{
+		"no keys": {},
+		"key from config": {
+			configKey:          "config-key",
+			expectedDefaultKey: "config-key",
+		},
+		"provider key overrides config key and is used by profiles": {
+			configKey:          "config-key",
+			providerKey:        "provider-key",
+			expectedDefaultKey: "provider-key",
+			expectedProfileKey: "provider-key",
+		},
+		"profile key overrides provider key": {
+			providerKey:        "provider-key",
+			profileKey:         "profile-key",
+			expectedDefaultKey: "provider-key",
+			expectedProfileKey: "profile-key",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			profileConfig := &edgegrid.Config{Host: "profile.com", AccountKey: test.profileKey}
+			cfg := contextConfig{
+				edgegridConfig: &edgegrid.Config{Host: "host.com", AccountKey: test.configKey},
+				profiles:       map[string]*edgegrid.Config{"profile": profileConfig},
+				accountKey:     test.providerKey,
+			}
+
+			edgegridConfig, profiles := withAccountKeys(cfg)
+			assert.Equal(t, test.expectedDefaultKey, edgegridConfig.AccountKey)
+			assert.Equal(t, test.expectedProfileKey, profiles["profile"].AccountKey)
+			assert.Equal(t, "profile.com", profiles["profile"].Host)
+			// the configuration is not modified
+			assert.Equal(t, test.configKey, cfg.edgegridConfig.AccountKey)
+			assert.Equal(t, test.profileKey, profileConfig.AccountKey)
+		})
+	}
+}
+
+func TestNewCacheStore(t *testing.T) {
+	edgegridConfig := edgegrid.Config{Host: "host.com", AccountKey: "account"}
+
+	t.Run("memory store by default", func(t *testing.T) {
+		store, err := newCacheStore(contextConfig{}, edgegridConfig)
+		require.NoError(t, err)
+		assert.NotNil(t, store)
+	})
+
+	t.Run("file store shares entries for the same account", func(t *testing.T) {
+		dir := t.TempDir()
+		cfg := contextConfig{cacheBackend: cacheBackendFile, cacheDir: dir}
+
+		store, err := newCacheStore(cfg, edgegridConfig)
+		require.NoError(t, err)
+		require.NoError(t, store.Set("bucket", "key", []byte("data")))
+
+		sameAccountStore, err := newCacheStore(cfg, edgegridConfig)
+		require.NoError(t, err)
+		data, err := sameAccountStore.Get("bucket", "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("data"), data)
+
+		otherAccountStore, err := newCacheStore(cfg, edgegrid.Config{Host: "host.com", AccountKey: "other"})
+		require.NoError(t, err)
+		_, err = otherAccountStore.Get("bucket", "key")
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported backend", func(t *testing.T) {
+		_, err := newCacheStore(contextConfig{cacheBackend: "redis"}, edgegridConfig)
+		assert.ErrorIs(t, err, ErrUnsupportedCacheBackend)
+	})
+}
+
+func TestCacheStoreKey(t *testing.T) {
+	edgegridConfig := edgegrid.Config{Host: "host.com", AccountKey: "account"}
+
+	assert.Equal(t, cacheStoreKey(contextConfig{}, edgegridConfig),
+		cacheStoreKey(contextConfig{cacheBackend: cacheBackendMemory, cacheTTL: cache.DefaultTTL}, edgegridConfig))
+	assert.NotEqual(t, cacheStoreKey(contextConfig{}, edgegridConfig),
+		cacheStoreKey(contextConfig{cacheBackend: cacheBackendFile}, edgegridConfig))
+	assert.NotEqual(t, cacheStoreKey(contextConfig{}, edgegridConfig),
+		cacheStoreKey(contextConfig{}, edgegrid.Config{Host: "host.com", AccountKey: "other"}))
+	assert.NotEqual(t, cacheStoreKey(contextConfig{}, edgegridConfig),
+		cacheStoreKey(contextConfig{cacheTTL: time.Minute}, edgegridConfig))
+}
+
+func TestNewLogger(t *testing.T) {
+	t.Run("JSON entries in log file", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "provider.log")
+		log, err := newLogger(contextConfig{ctx: context.Background(), logFormat: logger.FormatJSON, logFile: logFile}, "opID")
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, logger.CloseFiles()) }()
+		log.WithField("client_secret", "abc").Info("configured")
+
+		data, err := os.ReadFile(logFile)
+		require.NoError(t, err)
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(data, &entry))
+		assert.Equal(t, "configured", entry["@message"])
+		assert.Equal(t, "opID", entry["OperationID"])
+		assert.Equal(t, "REDACTED", entry["client_secret"])
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := newLogger(contextConfig{ctx: context.Background(), logFormat: "xml"}, "opID")
+		assert.ErrorIs(t, err, logger.ErrUnsupportedFormat)
+	})
+}
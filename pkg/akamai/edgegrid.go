@@ -10,6 +10,9 @@ import (
 // ErrWrongEdgeGridConfiguration is returned when the configuration could not be read
 var ErrWrongEdgeGridConfiguration = errors.New("error reading Akamai EdgeGrid configuration")
 
+// ErrDuplicatedCredentialsProfile is returned when more than one credentials profile has the same name
+var ErrDuplicatedCredentialsProfile = errors.New("duplicated credentials profile")
+
 // DefaultConfigFilePath is the default path for edgerc config file
 var DefaultConfigFilePath = edgegrid.DefaultConfigFile
 
@@ -22,6 +25,14 @@ type configBearer struct {
 	maxBody      int
 }
 
+// credentialsProfile holds the configuration of a single named credentials profile
+type credentialsProfile struct {
+	name       string
+	edgercPath string
+	section    string
+	config     configBearer
+}
+
 func (c configBearer) toEdgegridConfig() (*edgegrid.Config, error) {
 	if !c.valid() {
 		return nil, ErrWrongEdgeGridConfiguration
@@ -96,3 +107,33 @@ func edgercSectionOrDefault(section string) string {
 	}
 	return section
 }
+
+// newProfilesEdgegridConfigs creates an edgegrid.Config for each of the provided credentials profiles.
+//
+// Every profile is resolved the same way as the default provider credentials (see newEdgegridConfig).
+// If a profile does not specify the edgerc path, the provider's one is used.
+// If a profile does not specify the section, its name is used as the section.
+func newProfilesEdgegridConfigs(edgercPath string, profiles []credentialsProfile) (map[string]*edgegrid.Config, error) {
+	configs := make(map[string]*edgegrid.Config, len(profiles))
+	for _, profile := range profiles {
+		if _, ok := configs[profile.name]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicatedCredentialsProfile, profile.name)
+		}
+
+		path, section := profile.edgercPath, profile.section
+		if path == "" {
+			path = edgercPath
+		}
+		if section == "" {
+			section = profile.name
+		}
+
+		config, err := newEdgegridConfig(path, section, profile.config)
+		if err != nil {
+			return nil, fmt.Errorf("credentials profile %q: %w", profile.name, err)
+		}
+		configs[profile.name] = config
+	}
+
+	return configs, nil
+}
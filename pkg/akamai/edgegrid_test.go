@@ -254,3 +254,46 @@ func TestConfigBearerValid(t *testing.T) {
 		})
 	}
 }
+
+func TestNewProfilesEdgegridConfigs(t *testing.T) {
+	edgercPath := "testdata/edgerc"
+
+	t.Run("uses profile name as the section by default", func(t *testing.T) {
+		configs, err := newProfilesEdgegridConfigs(edgercPath, []credentialsProfile{
+			{name: "other_account"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "other.com", configs["other_account"].Host)
+	})
+
+	t.Run("uses provided section and config", func(t *testing.T) {
+		configs, err := newProfilesEdgegridConfigs(edgercPath, []credentialsProfile{
+			{name: "file", section: "other_account"},
+			{name: "config", config: configBearer{
+				host:         "config.com",
+				accessToken:  "test_access_token",
+				clientToken:  "test_client_token",
+				clientSecret: "test_client_secret",
+			}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "other.com", configs["file"].Host)
+		assert.Equal(t, "config.com", configs["config"].Host)
+	})
+
+	t.Run("returns error for duplicated name", func(t *testing.T) {
+		_, err := newProfilesEdgegridConfigs(edgercPath, []credentialsProfile{
+			{name: "other_account"},
+			{name: "other_account"},
+		})
+		assert.ErrorIs(t, err, ErrDuplicatedCredentialsProfile)
+	})
+
+	t.Run("returns error for not existing section", func(t *testing.T) {
+		_, err := newProfilesEdgegridConfigs(edgercPath, []credentialsProfile{
+			{name: "not_existing"},
+		})
+		assert.ErrorIs(t, err, ErrWrongEdgeGridConfiguration)
+		assert.ErrorContains(t, err, `credentials profile "not_existing"`)
+	})
+}
@@ -0,0 +1,800 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type (
+	// frameworkResourceWithMetaArguments extends the schema of the wrapped framework resource with the provider
+	// meta-arguments. The meta-arguments are removed from the plan, state and configuration passed to the wrapped
+	// resource, which is configured with the meta resolved from them before every operation.
+	// Validation of the configuration and state upgrades are passed to the wrapped resource without the meta-arguments.
+	// CRUD operations and import are traced like the ones of SDK resources, see withTracing.
+	frameworkResourceWithMetaArguments struct {
+		resource     resource.Resource
+		providerData any
+	}
+
+	// frameworkResourceWithMetaArgumentsAndUpgradeState is frameworkResourceWithMetaArguments for resources implementing
+	// resource.ResourceWithUpgradeState. It is a separate type, as Terraform treats the resources with state upgraders
+	// differently from the ones without them
+	frameworkResourceWithMetaArgumentsAndUpgradeState struct {
+		*frameworkResourceWithMetaArguments
+	}
+
+	// frameworkConfigValidatorWithMetaArguments passes the configuration without the meta-arguments to the wrapped validator
+	frameworkConfigValidatorWithMetaArguments struct {
+		validator resource.ConfigValidator
+		resource  *frameworkResourceWithMetaArguments
+	}
+
+	// frameworkDataSourceWithMetaArguments is frameworkResourceWithMetaArguments for framework data sources
+	frameworkDataSourceWithMetaArguments struct {
+		dataSource   datasource.DataSource
+		providerData any
+	}
+
+	// metaArgumentsValues holds the values of the meta-arguments of a resource or data source
+	metaArgumentsValues map[string]tftypes.Value
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &frameworkResourceWithMetaArguments{}
+	_ resource.ResourceWithImportState = &frameworkResourceWithMetaArguments{}
+	_ resource.ResourceWithModifyPlan  = &frameworkResourceWithMetaArguments{}
+
+	_ resource.ResourceWithValidateConfig   = &frameworkResourceWithMetaArguments{}
+	_ resource.ResourceWithConfigValidators = &frameworkResourceWithMetaArguments{}
+	_ resource.ResourceWithUpgradeState     = &frameworkResourceWithMetaArgumentsAndUpgradeState{}
+
+	_ datasource.DataSourceWithConfigure = &frameworkDataSourceWithMetaArguments{}
+)
+
+// withFrameworkResourceMetaArguments wraps the resources returned by the given functions with frameworkResourceWithMetaArguments
+func withFrameworkResourceMetaArguments(resources []func() resource.Resource) []func() resource.Resource {
+	wrapped := make([]func() resource.Resource, 0, len(resources))
+	for _, r := range resources {
+		r := r
+		wrapped = append(wrapped, func() resource.Resource {
+			inner := r()
+			res := &frameworkResourceWithMetaArguments{resource: inner}
+			if _, ok := inner.(resource.ResourceWithUpgradeState); ok {
+				return &frameworkResourceWithMetaArgumentsAndUpgradeState{res}
+			}
+			return res
+		})
+	}
+	return wrapped
+}
+
+// withFrameworkDataSourceMetaArguments wraps the data sources returned by the given functions with frameworkDataSourceWithMetaArguments
+func withFrameworkDataSourceMetaArguments(dataSources []func() datasource.DataSource) []func() datasource.DataSource {
+	wrapped := make([]func() datasource.DataSource, 0, len(dataSources))
+	for _, d := range dataSources {
+		d := d
+		wrapped = append(wrapped, func() datasource.DataSource {
+			return &frameworkDataSourceWithMetaArguments{dataSource: d()}
+		})
+	}
+	return wrapped
+}
+
+// Metadata implements resource.Resource
+func (r *frameworkResourceWithMetaArguments) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.resource.Metadata(ctx, req, resp)
+}
+
+// Schema implements resource.Resource
+func (r *frameworkResourceWithMetaArguments) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	r.resource.Schema(ctx, req, resp)
+	resp.Schema = withMetaArgumentsSchema(resp.Schema)
+}
+
+// withMetaArgumentsSchema returns the resource schema extended with the meta-arguments
+func withMetaArgumentsSchema(s resourceschema.Schema) resourceschema.Schema {
+	attributes := make(map[string]resourceschema.Attribute, len(s.Attributes)+len(metaArguments))
+	for name, attribute := range s.Attributes {
+		attributes[name] = attribute
+	}
+	attributes[credentialsProfileKey] = resourceschema.StringAttribute{
+		Optional:    true,
+		Description: credentialsProfileDescription,
+	}
+	attributes[accountSwitchKeyKey] = resourceschema.StringAttribute{
+		Optional:    true,
+		Description: accountSwitchKeyDescription,
+	}
+	s.Attributes = attributes
+	return s
+}
+
+// Configure implements resource.ResourceWithConfigure. The wrapped resource is configured before every operation
+func (r *frameworkResourceWithMetaArguments) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	r.providerData = req.ProviderData
+}
+
+// Create implements resource.Resource
+func (r *frameworkResourceWithMetaArguments) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startFrameworkSpan(ctx, resourceTypeName(ctx, r.resource), "Create", req.Plan.Raw, r.providerData)
+	defer func() { endFrameworkSpan(span, resp.State.Raw, resp.Diagnostics) }()
+
+	s, values, diags := r.schemaWithValues(ctx, req.Plan.Raw)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, diags = r.configure(ctx, values, req.Plan.Raw)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerReq := resource.CreateRequest{
+		Config:       tfsdk.Config{Schema: s.inner.Schema, Raw: s.strip(req.Config.Raw, &resp.Diagnostics)},
+		Plan:         tfsdk.Plan{Schema: s.inner.Schema, Raw: s.strip(req.Plan.Raw, &resp.Diagnostics)},
+		ProviderMeta: req.ProviderMeta,
+	}
+	innerResp := resource.CreateResponse{
+		State:   tfsdk.State{Schema: s.inner.Schema, Raw: s.strip(resp.State.Raw, &resp.Diagnostics)},
+		Private: resp.Private,
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.resource.Create(ctx, innerReq, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State.Raw = s.extend(innerResp.State.Raw, values, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+// Read implements resource.Resource
+func (r *frameworkResourceWithMetaArguments) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startFrameworkSpan(ctx, resourceTypeName(ctx, r.resource), "Read", req.State.Raw, r.providerData)
+	defer func() { endFrameworkSpan(span, resp.State.Raw, resp.Diagnostics) }()
+
+	s, values, diags := r.schemaWithValues(ctx, req.State.Raw)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, diags = r.configure(ctx, values, req.State.Raw)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerReq := resource.ReadRequest{
+		State:        tfsdk.State{Schema: s.inner.Schema, Raw: s.strip(req.State.Raw, &resp.Diagnostics)},
+		Private:      req.Private,
+		ProviderMeta: req.ProviderMeta,
+	}
+	innerResp := resource.ReadResponse{
+		State:   tfsdk.State{Schema: s.inner.Schema, Raw: s.strip(resp.State.Raw, &resp.Diagnostics)},
+		Private: resp.Private,
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.resource.Read(ctx, innerReq, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State.Raw = s.extend(innerResp.State.Raw, values, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+// Update implements resource.Resource. It is not passed to the wrapped resource when only meta-arguments have changed,
+// as the change of those arguments does not require any API calls
+func (r *frameworkResourceWithMetaArguments) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	s, values, diags := r.schemaWithValues(ctx, req.Plan.Raw)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	plan, state := s.strip(req.Plan.Raw, &resp.Diagnostics), s.strip(req.State.Raw, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Equal(state) {
+		resp.State.Raw = req.Plan.Raw
+		return
+	}
+	ctx, span := startFrameworkSpan(ctx, resourceTypeName(ctx, r.resource), "Update", req.Plan.Raw, r.providerData)
+	defer func() { endFrameworkSpan(span, resp.State.Raw, resp.Diagnostics) }()
+
+	ctx, diags = r.configure(ctx, values, req.Plan.Raw)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerReq := resource.UpdateRequest{
+		Config:       tfsdk.Config{Schema: s.inner.Schema, Raw: s.strip(req.Config.Raw, &resp.Diagnostics)},
+		Plan:         tfsdk.Plan{Schema: s.inner.Schema, Raw: plan},
+		State:        tfsdk.State{Schema: s.inner.Schema, Raw: state},
+		ProviderMeta: req.ProviderMeta,
+		Private:      req.Private,
+	}
+	innerResp := resource.UpdateResponse{
+		State:   tfsdk.State{Schema: s.inner.Schema, Raw: s.strip(resp.State.Raw, &resp.Diagnostics)},
+		Private: resp.Private,
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.resource.Update(ctx, innerReq, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State.Raw = s.extend(innerResp.State.Raw, values, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+// Delete implements resource.Resource
+func (r *frameworkResourceWithMetaArguments) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startFrameworkSpan(ctx, resourceTypeName(ctx, r.resource), "Delete", req.State.Raw, r.providerData)
+	defer func() { endFrameworkSpan(span, resp.State.Raw, resp.Diagnostics) }()
+
+	s, values, diags := r.schemaWithValues(ctx, req.State.Raw)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, diags = r.configure(ctx, values, req.State.Raw)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerReq := resource.DeleteRequest{
+		State:        tfsdk.State{Schema: s.inner.Schema, Raw: s.strip(req.State.Raw, &resp.Diagnostics)},
+		ProviderMeta: req.ProviderMeta,
+		Private:      req.Private,
+	}
+	innerResp := resource.DeleteResponse{
+		State: tfsdk.State{Schema: s.inner.Schema, Raw: s.strip(resp.State.Raw, &resp.Diagnostics)},
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.resource.Delete(ctx, innerReq, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State.Raw = s.extend(innerResp.State.Raw, values, &resp.Diagnostics)
+}
+
+// ImportState implements resource.ResourceWithImportState. The meta-arguments are given as the prefix of the import ID,
+// see splitImportID
+func (r *frameworkResourceWithMetaArguments) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importer, ok := r.resource.(resource.ResourceWithImportState)
+	if !ok {
+		resp.Diagnostics.AddError("Resource Import Not Implemented",
+			"This resource does not support import. Please contact the provider developer for additional information.")
+		return
+	}
+	ctx, span := startFrameworkSpan(ctx, resourceTypeName(ctx, r.resource), "ImportState", tftypes.Value{}, r.providerData)
+	defer func() { endFrameworkSpan(span, resp.State.Raw, resp.Diagnostics) }()
+
+	id, args, err := splitImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Failed", err.Error())
+		return
+	}
+	values := metaArgumentsValues{}
+	for _, name := range metaArguments {
+		values[name] = tftypes.NewValue(tftypes.String, nil)
+		if value, ok := args[name]; ok {
+			values[name] = tftypes.NewValue(tftypes.String, value)
+		}
+	}
+
+	s, diags := r.schemas(ctx)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, diags = r.configure(ctx, values, tftypes.Value{})
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	innerResp := resource.ImportStateResponse{
+		State:   tfsdk.State{Schema: s.inner.Schema, Raw: s.strip(resp.State.Raw, &resp.Diagnostics)},
+		Private: resp.Private,
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	importer.ImportState(ctx, resource.ImportStateRequest{ID: id}, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State.Raw = s.extend(innerResp.State.Raw, values, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+// ModifyPlan implements resource.ResourceWithModifyPlan
+func (r *frameworkResourceWithMetaArguments) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifier, ok := r.resource.(resource.ResourceWithModifyPlan)
+	if !ok {
+		return
+	}
+
+	// the plan is null when the resource is destroyed
+	raw := req.Plan.Raw
+	if raw.IsNull() {
+		raw = req.State.Raw
+	}
+	s, values, diags := r.schemaWithValues(ctx, raw)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	// the provider is not configured yet when its configuration is not known, e.g. during validation
+	if r.providerData != nil && values.known() {
+		ctx, diags = r.configure(ctx, values, raw)
+		if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	innerReq := resource.ModifyPlanRequest{
+		Config:       tfsdk.Config{Schema: s.inner.Schema, Raw: s.strip(req.Config.Raw, &resp.Diagnostics)},
+		State:        tfsdk.State{Schema: s.inner.Schema, Raw: s.strip(req.State.Raw, &resp.Diagnostics)},
+		Plan:         tfsdk.Plan{Schema: s.inner.Schema, Raw: s.strip(req.Plan.Raw, &resp.Diagnostics)},
+		ProviderMeta: req.ProviderMeta,
+		Private:      req.Private,
+	}
+	innerResp := resource.ModifyPlanResponse{
+		Plan:            tfsdk.Plan{Schema: s.inner.Schema, Raw: s.strip(resp.Plan.Raw, &resp.Diagnostics)},
+		RequiresReplace: resp.RequiresReplace,
+		Private:         resp.Private,
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	modifier.ModifyPlan(ctx, innerReq, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Plan.Raw = s.extend(innerResp.Plan.Raw, values, &resp.Diagnostics)
+	resp.RequiresReplace = innerResp.RequiresReplace
+	resp.Private = innerResp.Private
+}
+
+// ValidateConfig implements resource.ResourceWithValidateConfig
+func (r *frameworkResourceWithMetaArguments) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validator, ok := r.resource.(resource.ResourceWithValidateConfig)
+	if !ok {
+		return
+	}
+	innerReq, diags := r.validateConfigRequest(ctx, req)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	validator.ValidateConfig(ctx, innerReq, resp)
+}
+
+// ConfigValidators implements resource.ResourceWithConfigValidators
+func (r *frameworkResourceWithMetaArguments) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	validators, ok := r.resource.(resource.ResourceWithConfigValidators)
+	if !ok {
+		return nil
+	}
+	var wrapped []resource.ConfigValidator
+	for _, validator := range validators.ConfigValidators(ctx) {
+		wrapped = append(wrapped, frameworkConfigValidatorWithMetaArguments{validator: validator, resource: r})
+	}
+	return wrapped
+}
+
+// UpgradeState implements resource.ResourceWithUpgradeState. The prior schemas are extended with the meta-arguments,
+// whose values are kept in the upgraded state
+func (r *frameworkResourceWithMetaArgumentsAndUpgradeState) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	upgraders := r.resource.(resource.ResourceWithUpgradeState).UpgradeState(ctx)
+	wrapped := make(map[int64]resource.StateUpgrader, len(upgraders))
+	for version, upgrader := range upgraders {
+		wrapped[version] = r.stateUpgrader(ctx, upgrader)
+	}
+	return wrapped
+}
+
+// stateUpgrader wraps the state upgrader of the wrapped resource
+func (r *frameworkResourceWithMetaArguments) stateUpgrader(ctx context.Context, upgrader resource.StateUpgrader) resource.StateUpgrader {
+	var prior *metaArgumentsSchemas
+	var priorSchema *resourceschema.Schema
+	if upgrader.PriorSchema != nil {
+		outer := withMetaArgumentsSchema(*upgrader.PriorSchema)
+		s := newResourceMetaArgumentsSchemas(ctx, *upgrader.PriorSchema, outer)
+		prior, priorSchema = &s, &outer
+	}
+
+	upgrade := func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+		s, diags := r.schemas(ctx)
+		if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+			return
+		}
+		values, rawState, err := stripRawState(req.RawState)
+		if err != nil {
+			resp.Diagnostics.AddError("Reading Meta-Arguments Failed", err.Error())
+			return
+		}
+
+		innerReq := resource.UpgradeStateRequest{RawState: rawState}
+		if req.State != nil && prior != nil {
+			innerReq.State = &tfsdk.State{Schema: prior.inner.Schema, Raw: prior.strip(req.State.Raw, &resp.Diagnostics)}
+		}
+		innerResp := resource.UpgradeStateResponse{
+			State: tfsdk.State{Schema: s.inner.Schema, Raw: s.strip(resp.State.Raw, &resp.Diagnostics)},
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		upgrader.StateUpgrader(ctx, innerReq, &innerResp)
+
+		if resp.Diagnostics.Append(innerResp.Diagnostics...); resp.Diagnostics.HasError() {
+			return
+		}
+		raw := innerResp.State.Raw
+		if innerResp.DynamicValue != nil {
+			if raw, err = innerResp.DynamicValue.Unmarshal(s.innerType); err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+				return
+			}
+		}
+		resp.State.Raw = s.extend(raw, values, &resp.Diagnostics)
+	}
+
+	return resource.StateUpgrader{PriorSchema: priorSchema, StateUpgrader: upgrade}
+}
+
+// validateConfigRequest returns the request to validate the configuration without the meta-arguments
+func (r *frameworkResourceWithMetaArguments) validateConfigRequest(ctx context.Context, req resource.ValidateConfigRequest) (resource.ValidateConfigRequest, diag.Diagnostics) {
+	s, diags := r.schemas(ctx)
+	if diags.HasError() {
+		return req, diags
+	}
+	raw := s.strip(req.Config.Raw, &diags)
+	return resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s.inner.Schema, Raw: raw}}, diags
+}
+
+// Description implements resource.ConfigValidator
+func (v frameworkConfigValidatorWithMetaArguments) Description(ctx context.Context) string {
+	return v.validator.Description(ctx)
+}
+
+// MarkdownDescription implements resource.ConfigValidator
+func (v frameworkConfigValidatorWithMetaArguments) MarkdownDescription(ctx context.Context) string {
+	return v.validator.MarkdownDescription(ctx)
+}
+
+// ValidateResource implements resource.ConfigValidator
+func (v frameworkConfigValidatorWithMetaArguments) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	innerReq, diags := v.resource.validateConfigRequest(ctx, req)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	v.validator.ValidateResource(ctx, innerReq, resp)
+}
+
+// schemas returns the schema of the wrapped resource and the schema extended with the meta-arguments
+func (r *frameworkResourceWithMetaArguments) schemas(ctx context.Context) (metaArgumentsSchemas, diag.Diagnostics) {
+	var inner, outer resource.SchemaResponse
+	r.resource.Schema(ctx, resource.SchemaRequest{}, &inner)
+	r.Schema(ctx, resource.SchemaRequest{}, &outer)
+	diags := append(inner.Diagnostics, outer.Diagnostics...)
+	return newResourceMetaArgumentsSchemas(ctx, inner.Schema, outer.Schema), diags
+}
+
+// schemaWithValues returns the schemas of the resource and the values of the meta-arguments of the given object
+func (r *frameworkResourceWithMetaArguments) schemaWithValues(ctx context.Context, raw tftypes.Value) (metaArgumentsSchemas, metaArgumentsValues, diag.Diagnostics) {
+	s, diags := r.schemas(ctx)
+	if diags.HasError() {
+		return s, nil, diags
+	}
+	values, err := getMetaArgumentsValues(raw)
+	if err != nil {
+		diags.AddError("Reading Meta-Arguments Failed", err.Error())
+	}
+	return s, values, diags
+}
+
+// configure configures the wrapped resource with the meta resolved from the meta-arguments
+// and returns the context carrying the account switch key
+func (r *frameworkResourceWithMetaArguments) configure(ctx context.Context, values metaArgumentsValues, raw tftypes.Value) (context.Context, diag.Diagnostics) {
+	configurable, ok := r.resource.(resource.ResourceWithConfigure)
+	if !ok || r.providerData == nil {
+		return ctx, nil
+	}
+	name := resourceTypeName(ctx, r.resource)
+	ctx, m, diags := resolveFrameworkMetaArguments(ctx, name, values, raw, r.providerData)
+	if diags.HasError() {
+		return ctx, diags
+	}
+	var resp resource.ConfigureResponse
+	configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: m}, &resp)
+	return ctx, append(diags, resp.Diagnostics...)
+}
+
+// Metadata implements datasource.DataSource
+func (d *frameworkDataSourceWithMetaArguments) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	d.dataSource.Metadata(ctx, req, resp)
+}
+
+// Schema implements datasource.DataSource
+func (d *frameworkDataSourceWithMetaArguments) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	d.dataSource.Schema(ctx, req, resp)
+	attributes := make(map[string]datasourceschema.Attribute, len(resp.Schema.Attributes)+len(metaArguments))
+	for name, attribute := range resp.Schema.Attributes {
+		attributes[name] = attribute
+	}
+	attributes[credentialsProfileKey] = datasourceschema.StringAttribute{
+		Optional:    true,
+		Description: credentialsProfileDescription,
+	}
+	attributes[accountSwitchKeyKey] = datasourceschema.StringAttribute{
+		Optional:    true,
+		Description: accountSwitchKeyDescription,
+	}
+	resp.Schema.Attributes = attributes
+}
+
+// Configure implements datasource.DataSourceWithConfigure. The wrapped data source is configured before it is read
+func (d *frameworkDataSourceWithMetaArguments) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	d.providerData = req.ProviderData
+}
+
+// Read implements datasource.DataSource
+func (d *frameworkDataSourceWithMetaArguments) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startFrameworkSpan(ctx, dataSourceTypeName(ctx, d.dataSource), "Read", req.Config.Raw, d.providerData)
+	defer func() { endFrameworkSpan(span, resp.State.Raw, resp.Diagnostics) }()
+
+	var inner, outer datasource.SchemaResponse
+	d.dataSource.Schema(ctx, datasource.SchemaRequest{}, &inner)
+	d.Schema(ctx, datasource.SchemaRequest{}, &outer)
+	if resp.Diagnostics.Append(append(inner.Diagnostics, outer.Diagnostics...)...); resp.Diagnostics.HasError() {
+		return
+	}
+	s := newDataSourceMetaArgumentsSchemas(ctx, inner.Schema, outer.Schema)
+
+	values, err := getMetaArgumentsValues(req.Config.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Reading Meta-Arguments Failed", err.Error())
+		return
+	}
+	if configurable, ok := d.dataSource.(datasource.DataSourceWithConfigure); ok && d.providerData != nil {
+		var m any
+		var diags diag.Diagnostics
+		ctx, m, diags = resolveFrameworkMetaArguments(ctx, dataSourceTypeName(ctx, d.dataSource), values, req.Config.Raw, d.providerData)
+		if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+			return
+		}
+		var configureResp datasource.ConfigureResponse
+		configurable.Configure(ctx, datasource.ConfigureRequest{ProviderData: m}, &configureResp)
+		if resp.Diagnostics.Append(configureResp.Diagnostics...); resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	innerReq := datasource.ReadRequest{
+		Config:       tfsdk.Config{Schema: s.inner.Schema, Raw: s.strip(req.Config.Raw, &resp.Diagnostics)},
+		ProviderMeta: req.ProviderMeta,
+	}
+	innerResp := datasource.ReadResponse{
+		State: tfsdk.State{Schema: s.inner.Schema, Raw: s.strip(resp.State.Raw, &resp.Diagnostics)},
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.dataSource.Read(ctx, innerReq, &innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State.Raw = s.extend(innerResp.State.Raw, values, &resp.Diagnostics)
+}
+
+// metaArgumentsSchemas holds the schema of the wrapped resource or data source, as the schema of an empty state,
+// and the types of the objects of both the wrapped schema and the one extended with the meta-arguments
+type metaArgumentsSchemas struct {
+	inner                tfsdk.State
+	innerType, outerType tftypes.Type
+}
+
+func newResourceMetaArgumentsSchemas(ctx context.Context, inner, outer resourceschema.Schema) metaArgumentsSchemas {
+	return metaArgumentsSchemas{
+		inner:     tfsdk.State{Schema: inner},
+		innerType: inner.Type().TerraformType(ctx),
+		outerType: outer.Type().TerraformType(ctx),
+	}
+}
+
+func newDataSourceMetaArgumentsSchemas(ctx context.Context, inner, outer datasourceschema.Schema) metaArgumentsSchemas {
+	return metaArgumentsSchemas{
+		inner:     tfsdk.State{Schema: inner},
+		innerType: inner.Type().TerraformType(ctx),
+		outerType: outer.Type().TerraformType(ctx),
+	}
+}
+
+// strip removes the meta-arguments from the object of the extended schema. The failure of the conversion is added to the diagnostics
+func (s metaArgumentsSchemas) strip(raw tftypes.Value, diags *diag.Diagnostics) tftypes.Value {
+	stripped, err := convertObject(raw, s.innerType, func(attributes map[string]tftypes.Value) {
+		for _, name := range metaArguments {
+			delete(attributes, name)
+		}
+	})
+	if err != nil {
+		diags.AddError("Removing Meta-Arguments Failed", err.Error())
+	}
+	return stripped
+}
+
+// extend adds the values of the meta-arguments to the object of the wrapped schema. The failure of the conversion is added to the diagnostics
+func (s metaArgumentsSchemas) extend(raw tftypes.Value, values metaArgumentsValues, diags *diag.Diagnostics) tftypes.Value {
+	extended, err := convertObject(raw, s.outerType, func(attributes map[string]tftypes.Value) {
+		for _, name := range metaArguments {
+			attributes[name] = values.get(name)
+		}
+	})
+	if err != nil {
+		diags.AddError("Adding Meta-Arguments Failed", err.Error())
+	}
+	return extended
+}
+
+// convertObject returns the object of the given type with the attributes of the given object modified by the function.
+// Null, unknown and not set values are converted to the same value of the given type. If the object cannot be converted,
+// the null value of the given type is returned with the error
+func convertObject(raw tftypes.Value, typ tftypes.Type, modify func(map[string]tftypes.Value)) (tftypes.Value, error) {
+	if raw.Type() == nil || raw.IsNull() {
+		return tftypes.NewValue(typ, nil), nil
+	}
+	if !raw.IsKnown() {
+		return tftypes.NewValue(typ, tftypes.UnknownValue), nil
+	}
+	var values map[string]tftypes.Value
+	if err := raw.As(&values); err != nil {
+		return tftypes.NewValue(typ, nil), fmt.Errorf("converting object with meta-arguments: %w", err)
+	}
+	// the map shares the attributes with the given object, which must not be modified
+	attributes := make(map[string]tftypes.Value, len(values)+len(metaArguments))
+	for name, value := range values {
+		attributes[name] = value
+	}
+	modify(attributes)
+	if err := tftypes.ValidateValue(typ, attributes); err != nil {
+		return tftypes.NewValue(typ, nil), fmt.Errorf("converting object with meta-arguments: %w", err)
+	}
+	return tftypes.NewValue(typ, attributes), nil
+}
+
+// stripRawState returns the values of the meta-arguments of the raw state and the raw state without them
+func stripRawState(raw *tfprotov6.RawState) (metaArgumentsValues, *tfprotov6.RawState, error) {
+	values := metaArgumentsValues{}
+	if raw == nil {
+		return values, nil, nil
+	}
+	stripped := &tfprotov6.RawState{}
+	if raw.JSON != nil {
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(raw.JSON, &attributes); err != nil {
+			return nil, nil, err
+		}
+		for _, name := range metaArguments {
+			value, ok := attributes[name]
+			if !ok {
+				continue
+			}
+			var s *string
+			if err := json.Unmarshal(value, &s); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", name, err)
+			}
+			values[name] = tftypes.NewValue(tftypes.String, nil)
+			if s != nil {
+				values[name] = tftypes.NewValue(tftypes.String, *s)
+			}
+			delete(attributes, name)
+		}
+		var err error
+		if stripped.JSON, err = json.Marshal(attributes); err != nil {
+			return nil, nil, err
+		}
+	}
+	if raw.Flatmap != nil {
+		stripped.Flatmap = make(map[string]string, len(raw.Flatmap))
+		for key, value := range raw.Flatmap {
+			stripped.Flatmap[key] = value
+		}
+		for _, name := range metaArguments {
+			if value, ok := stripped.Flatmap[name]; ok {
+				values[name] = tftypes.NewValue(tftypes.String, value)
+				delete(stripped.Flatmap, name)
+			}
+		}
+	}
+	return values, stripped, nil
+}
+
+// getMetaArgumentsValues returns the values of the meta-arguments of the given object
+func getMetaArgumentsValues(raw tftypes.Value) (metaArgumentsValues, error) {
+	values := metaArgumentsValues{}
+	if raw.Type() == nil || raw.IsNull() || !raw.IsKnown() {
+		return values, nil
+	}
+	var attributes map[string]tftypes.Value
+	if err := raw.As(&attributes); err != nil {
+		return nil, err
+	}
+	for _, name := range metaArguments {
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		}
+	}
+	return values, nil
+}
+
+// get returns the value of the meta-argument, null if it is not set
+func (v metaArgumentsValues) get(name string) tftypes.Value {
+	if value, ok := v[name]; ok {
+		return value
+	}
+	return tftypes.NewValue(tftypes.String, nil)
+}
+
+// string returns the value of the meta-argument as string, empty if it is null or unknown
+func (v metaArgumentsValues) string(name string) string {
+	value := v.get(name)
+	var s string
+	if !value.IsKnown() || value.IsNull() {
+		return s
+	}
+	if err := value.As(&s); err != nil {
+		return ""
+	}
+	return s
+}
+
+// known checks whether the values of all meta-arguments are known
+func (v metaArgumentsValues) known() bool {
+	for _, value := range v {
+		if !value.IsKnown() {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveFrameworkMetaArguments is resolveMetaArguments for framework resources and data sources
+func resolveFrameworkMetaArguments(ctx context.Context, name string, values metaArgumentsValues, raw tftypes.Value, m any) (context.Context, any, diag.Diagnostics) {
+	ctx, profileMeta, err := resolveMeta(ctx, name, rawID(raw), values.string(credentialsProfileKey), values.string(accountSwitchKeyKey), m)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddAttributeError(path.Root(credentialsProfileKey), "Resolving Meta-Arguments Failed", err.Error())
+		return ctx, nil, diags
+	}
+	return ctx, profileMeta, nil
+}
+
+// rawID returns the value of the 'id' attribute of the object, empty if it is not set or not known
+func rawID(raw tftypes.Value) string {
+	var id string
+	if raw.Type() == nil || !raw.IsKnown() || raw.IsNull() {
+		return id
+	}
+	if v, _, err := tftypes.WalkAttributePath(raw, tftypes.NewAttributePath().WithAttributeName("id")); err == nil {
+		if value, ok := v.(tftypes.Value); ok && value.IsKnown() && !value.IsNull() {
+			_ = value.As(&id)
+		}
+	}
+	return id
+}
+
+func resourceTypeName(ctx context.Context, r resource.Resource) string {
+	var resp resource.MetadataResponse
+	r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "akamai"}, &resp)
+	return resp.TypeName
+}
+
+func dataSourceTypeName(ctx context.Context, d datasource.DataSource) string {
+	var resp datasource.MetadataResponse
+	d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "akamai"}, &resp)
+	return resp.TypeName
+}
@@ -0,0 +1,499 @@
+package akamai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// testFrameworkResource records the meta and the account switch key it is used with
+	testFrameworkResource struct {
+		meta       meta.Meta
+		usedMeta   *meta.Meta
+		usedKey    *any
+		importedID *string
+		updated    *bool
+	}
+
+	testFrameworkModel struct {
+		ID   types.String `tfsdk:"id"`
+		Name types.String `tfsdk:"name"`
+	}
+
+	// testFrameworkResourceWithValidation records the configurations it validates and upgrades the state from version 0,
+	// which had title instead of name
+	testFrameworkResourceWithValidation struct {
+		testFrameworkResource
+		validated *[]tftypes.Value
+	}
+
+	// testFrameworkConfigValidator records the configurations it validates
+	testFrameworkConfigValidator struct {
+		validated *[]tftypes.Value
+	}
+
+	// testFrameworkDataSource records the meta and the account switch key it is used with
+	testFrameworkDataSource struct {
+		meta     meta.Meta
+		usedMeta *meta.Meta
+		usedKey  *any
+	}
+)
+
+func (r *testFrameworkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_test"
+}
+
+func (r *testFrameworkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceschema.Schema{
+		Attributes: map[string]resourceschema.Attribute{
+			"id":   resourceschema.StringAttribute{Computed: true},
+			"name": resourceschema.StringAttribute{Optional: true},
+		},
+	}
+}
+
+func (r *testFrameworkResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	r.meta = req.ProviderData.(meta.Meta)
+}
+
+func (r *testFrameworkResource) record(ctx context.Context) {
+	*r.usedMeta = r.meta
+	*r.usedKey = ctx.Value(accountSwitchKeyContextKey{})
+}
+
+func (r *testFrameworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.record(ctx)
+	var model testFrameworkModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	model.ID = types.StringValue("1")
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+func (r *testFrameworkResource) Read(ctx context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+	r.record(ctx)
+}
+
+func (r *testFrameworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.record(ctx)
+	*r.updated = true
+	var model testFrameworkModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+func (r *testFrameworkResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	r.record(ctx)
+}
+
+func (r *testFrameworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	r.record(ctx)
+	*r.importedID = req.ID
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *testFrameworkResourceWithValidation) ValidateConfig(_ context.Context, req resource.ValidateConfigRequest, _ *resource.ValidateConfigResponse) {
+	*r.validated = append(*r.validated, req.Config.Raw)
+}
+
+func (r *testFrameworkResourceWithValidation) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{testFrameworkConfigValidator{validated: r.validated}}
+}
+
+func (r *testFrameworkResourceWithValidation) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &resourceschema.Schema{
+				Attributes: map[string]resourceschema.Attribute{
+					"id":    resourceschema.StringAttribute{Computed: true},
+					"title": resourceschema.StringAttribute{Optional: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var id, title types.String
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("title"), &title)...)
+				resp.Diagnostics.Append(resp.State.Set(ctx, testFrameworkModel{ID: id, Name: title})...)
+			},
+		},
+	}
+}
+
+func (v testFrameworkConfigValidator) Description(_ context.Context) string {
+	return "records the configuration"
+}
+
+func (v testFrameworkConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v testFrameworkConfigValidator) ValidateResource(_ context.Context, req resource.ValidateConfigRequest, _ *resource.ValidateConfigResponse) {
+	*v.validated = append(*v.validated, req.Config.Raw)
+}
+
+func (d *testFrameworkDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_test"
+}
+
+func (d *testFrameworkDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = datasourceschema.Schema{
+		Attributes: map[string]datasourceschema.Attribute{
+			"id":   datasourceschema.StringAttribute{Computed: true},
+			"name": datasourceschema.StringAttribute{Optional: true},
+		},
+	}
+}
+
+func (d *testFrameworkDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	d.meta = req.ProviderData.(meta.Meta)
+}
+
+func (d *testFrameworkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	*d.usedMeta = d.meta
+	*d.usedKey = ctx.Value(accountSwitchKeyContextKey{})
+	var model testFrameworkModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	model.ID = types.StringValue("1")
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+func TestFrameworkResourceWithMetaArguments(t *testing.T) {
+	ctx := context.Background()
+	defaultSess := session.Must(session.New())
+	profileSess := session.Must(session.New())
+	m, err := meta.New(defaultSess, hclog.NewNullLogger(), "opID", meta.WithCredentialsProfiles(map[string]session.Session{
+		"other": profileSess,
+	}))
+	require.NoError(t, err)
+
+	var usedMeta meta.Meta
+	var usedKey any
+	var importedID string
+	var updated bool
+	newResource := func() resource.ResourceWithImportState {
+		r := withFrameworkResourceMetaArguments([]func() resource.Resource{func() resource.Resource {
+			return &testFrameworkResource{usedMeta: &usedMeta, usedKey: &usedKey, importedID: &importedID, updated: &updated}
+		}})[0]()
+		r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: m}, &resource.ConfigureResponse{})
+		return r.(resource.ResourceWithImportState)
+	}
+
+	var schemaResp resource.SchemaResponse
+	newResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+	require.Contains(t, s.Attributes, credentialsProfileKey)
+	require.Contains(t, s.Attributes, accountSwitchKeyKey)
+
+	objectType := s.Type().TerraformType(ctx)
+	object := func(id, name, profile, accountKey any) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, id),
+			"name":                tftypes.NewValue(tftypes.String, name),
+			credentialsProfileKey: tftypes.NewValue(tftypes.String, profile),
+			accountSwitchKeyKey:   tftypes.NewValue(tftypes.String, accountKey),
+		})
+	}
+	null := tftypes.NewValue(objectType, nil)
+
+	tests := map[string]struct {
+		profile            any
+		accountKey         any
+		expectedSess       session.Session
+		expectedAccountKey any
+		expectedError      string
+	}{
+		"default credentials": {
+			expectedSess: defaultSess,
+		},
+		"named profile": {
+			profile:      "other",
+			expectedSess: profileSess,
+		},
+		"account switch key": {
+			accountKey:         "key",
+			expectedSess:       defaultSess,
+			expectedAccountKey: "key",
+		},
+		"unknown profile": {
+			profile:       "unknown",
+			expectedError: `credentials profile not found: "unknown"`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			usedMeta, usedKey = nil, nil
+			plan := object(tftypes.UnknownValue, "a", test.profile, test.accountKey)
+			req := resource.CreateRequest{
+				Config: tfsdk.Config{Schema: s, Raw: object(nil, "a", test.profile, test.accountKey)},
+				Plan:   tfsdk.Plan{Schema: s, Raw: plan},
+			}
+			resp := resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: null}}
+			newResource().Create(ctx, req, &resp)
+			if test.expectedError != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, test.expectedError, resp.Diagnostics[0].Detail())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Same(t, test.expectedSess, usedMeta.Session())
+			assert.Equal(t, test.expectedAccountKey, usedKey)
+			assert.True(t, object("1", "a", test.profile, test.accountKey).Equal(resp.State.Raw), resp.State.Raw.String())
+		})
+	}
+
+	t.Run("import with meta-arguments", func(t *testing.T) {
+		usedMeta, usedKey = nil, nil
+		resp := resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: null}}
+		newResource().ImportState(ctx, resource.ImportStateRequest{ID: "credentials_profile=other,account_switch_key=1-A:1-B;1"}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.Equal(t, "1", importedID)
+		assert.Same(t, profileSess, usedMeta.Session())
+		assert.Equal(t, "1-A:1-B", usedKey)
+		assert.True(t, object("1", nil, "other", "1-A:1-B").Equal(resp.State.Raw), resp.State.Raw.String())
+	})
+
+	t.Run("update is skipped when only meta-arguments changed", func(t *testing.T) {
+		state := object("1", "a", nil, nil)
+		update := func(plan tftypes.Value) resource.UpdateResponse {
+			req := resource.UpdateRequest{
+				Config: tfsdk.Config{Schema: s, Raw: plan},
+				Plan:   tfsdk.Plan{Schema: s, Raw: plan},
+				State:  tfsdk.State{Schema: s, Raw: state},
+			}
+			resp := resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: plan}}
+			newResource().Update(ctx, req, &resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			return resp
+		}
+
+		plan := object("1", "a", "other", nil)
+		resp := update(plan)
+		assert.False(t, updated)
+		assert.True(t, plan.Equal(resp.State.Raw))
+
+		plan = object("1", "b", "other", nil)
+		resp = update(plan)
+		assert.True(t, updated)
+		assert.Same(t, profileSess, usedMeta.Session())
+		assert.True(t, plan.Equal(resp.State.Raw), resp.State.Raw.String())
+	})
+}
+
+func TestFrameworkResourceWithMetaArgumentsOptionalInterfaces(t *testing.T) {
+	ctx := context.Background()
+	var validated []tftypes.Value
+	r := withFrameworkResourceMetaArguments([]func() resource.Resource{func() resource.Resource {
+		return &testFrameworkResourceWithValidation{validated: &validated}
+	}})[0]()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+	objectType := s.Type().TerraformType(ctx)
+	innerType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String, "name": tftypes.String}}
+
+	t.Run("configuration is validated without meta-arguments", func(t *testing.T) {
+		config := tfsdk.Config{Schema: s, Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, nil),
+			"name":                tftypes.NewValue(tftypes.String, "a"),
+			credentialsProfileKey: tftypes.NewValue(tftypes.String, "other"),
+			accountSwitchKeyKey:   tftypes.NewValue(tftypes.String, nil),
+		})}
+		expected := tftypes.NewValue(innerType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, nil),
+			"name": tftypes.NewValue(tftypes.String, "a"),
+		})
+
+		var resp resource.ValidateConfigResponse
+		r.(resource.ResourceWithValidateConfig).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, &resp)
+		validators := r.(resource.ResourceWithConfigValidators).ConfigValidators(ctx)
+		require.Len(t, validators, 1)
+		validators[0].ValidateResource(ctx, resource.ValidateConfigRequest{Config: config}, &resp)
+
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		require.Len(t, validated, 2)
+		for _, value := range validated {
+			assert.True(t, expected.Equal(value), value.String())
+		}
+	})
+
+	t.Run("state is upgraded with meta-arguments", func(t *testing.T) {
+		upgrader, ok := r.(resource.ResourceWithUpgradeState).UpgradeState(ctx)[0]
+		require.True(t, ok)
+		require.Contains(t, upgrader.PriorSchema.Attributes, credentialsProfileKey)
+		require.Contains(t, upgrader.PriorSchema.Attributes, accountSwitchKeyKey)
+
+		rawState := &tfprotov6.RawState{JSON: []byte(`{"id":"1","title":"a","credentials_profile":"other","account_switch_key":null}`)}
+		prior, err := rawState.Unmarshal(upgrader.PriorSchema.Type().TerraformType(ctx))
+		require.NoError(t, err)
+		req := resource.UpgradeStateRequest{RawState: rawState, State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: prior}}
+		resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: s}}
+		upgrader.StateUpgrader(ctx, req, &resp)
+
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		expected := tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, "1"),
+			"name":                tftypes.NewValue(tftypes.String, "a"),
+			credentialsProfileKey: tftypes.NewValue(tftypes.String, "other"),
+			accountSwitchKeyKey:   tftypes.NewValue(tftypes.String, nil),
+		})
+		assert.True(t, expected.Equal(resp.State.Raw), resp.State.Raw.String())
+	})
+
+	t.Run("state upgrade is not implemented when the wrapped resource does not implement it", func(t *testing.T) {
+		r := withFrameworkResourceMetaArguments([]func() resource.Resource{func() resource.Resource {
+			return &testFrameworkResource{}
+		}})[0]()
+		_, ok := r.(resource.ResourceWithUpgradeState)
+		assert.False(t, ok)
+	})
+}
+
+func TestConvertObject(t *testing.T) {
+	typ := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String, "name": tftypes.String}}
+	noop := func(map[string]tftypes.Value) {}
+
+	tests := map[string]struct {
+		raw           tftypes.Value
+		expected      tftypes.Value
+		expectedError string
+	}{
+		"object": {
+			raw: tftypes.NewValue(typ, map[string]tftypes.Value{
+				"id":   tftypes.NewValue(tftypes.String, "1"),
+				"name": tftypes.NewValue(tftypes.String, "a"),
+			}),
+			expected: tftypes.NewValue(typ, map[string]tftypes.Value{
+				"id":   tftypes.NewValue(tftypes.String, "1"),
+				"name": tftypes.NewValue(tftypes.String, "a"),
+			}),
+		},
+		"unknown": {
+			raw:      tftypes.NewValue(typ, tftypes.UnknownValue),
+			expected: tftypes.NewValue(typ, tftypes.UnknownValue),
+		},
+		"not set": {
+			expected: tftypes.NewValue(typ, nil),
+		},
+		"not an object": {
+			raw:           tftypes.NewValue(tftypes.String, "a"),
+			expected:      tftypes.NewValue(typ, nil),
+			expectedError: "converting object with meta-arguments",
+		},
+		"missing attribute": {
+			raw: tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String}}, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "1"),
+			}),
+			expected:      tftypes.NewValue(typ, nil),
+			expectedError: `required attribute "name" not set`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			value, err := convertObject(test.raw, typ, noop)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.True(t, test.expected.Equal(value), value.String())
+		})
+	}
+}
+
+func TestFrameworkDataSourceWithMetaArguments(t *testing.T) {
+	ctx := context.Background()
+	defaultSess := session.Must(session.New())
+	profileSess := session.Must(session.New())
+	m, err := meta.New(defaultSess, hclog.NewNullLogger(), "opID", meta.WithCredentialsProfiles(map[string]session.Session{
+		"other": profileSess,
+	}))
+	require.NoError(t, err)
+
+	var usedMeta meta.Meta
+	var usedKey any
+	d := withFrameworkDataSourceMetaArguments([]func() datasource.DataSource{func() datasource.DataSource {
+		return &testFrameworkDataSource{usedMeta: &usedMeta, usedKey: &usedKey}
+	}})[0]()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: m}, &datasource.ConfigureResponse{})
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+	objectType := s.Type().TerraformType(ctx)
+	object := func(id any) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, id),
+			"name":                tftypes.NewValue(tftypes.String, "a"),
+			credentialsProfileKey: tftypes.NewValue(tftypes.String, "other"),
+			accountSwitchKeyKey:   tftypes.NewValue(tftypes.String, "key"),
+		})
+	}
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: object(nil)}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: object(nil)}}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Same(t, profileSess, usedMeta.Session())
+	assert.Equal(t, "key", usedKey)
+	assert.True(t, object("1").Equal(resp.State.Raw), resp.State.Raw.String())
+}
+
+func TestSplitImportID(t *testing.T) {
+	tests := map[string]struct {
+		importID      string
+		expectedID    string
+		expectedArgs  map[string]string
+		expectedError string
+	}{
+		"no meta-arguments": {
+			importID:   "prp_1,ctr_1,grp_1",
+			expectedID: "prp_1,ctr_1,grp_1",
+		},
+		"meta-arguments": {
+			importID:     "credentials_profile=other,account_switch_key=1-A:1-B;prp_1:www.example.com",
+			expectedID:   "prp_1:www.example.com",
+			expectedArgs: map[string]string{credentialsProfileKey: "other", accountSwitchKeyKey: "1-A:1-B"},
+		},
+		"account switch key only": {
+			importID:     "account_switch_key=1-A:1-B;prp_1",
+			expectedID:   "prp_1",
+			expectedArgs: map[string]string{accountSwitchKeyKey: "1-A:1-B"},
+		},
+		"semicolon in the ID": {
+			importID:   "a;b",
+			expectedID: "a;b",
+		},
+		"unsupported meta-argument": {
+			importID:      "credential_profile=other;prp_1",
+			expectedError: `unsupported meta-argument "credential_profile" in import ID "credential_profile=other;prp_1", supported are: credentials_profile, account_switch_key`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, args, err := splitImportID(test.importID)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedID, id)
+			assert.Equal(t, test.expectedArgs, args)
+		})
+	}
+}
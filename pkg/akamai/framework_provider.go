@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf/validators"
@@ -27,15 +28,32 @@ type Provider struct {
 
 // ProviderModel represents the model of Provider configuration
 type ProviderModel struct {
-	EdgercPath    types.String `tfsdk:"edgerc"`
-	EdgercSection types.String `tfsdk:"config_section"`
-	EdgercConfig  types.Set    `tfsdk:"config"`
-	CacheEnabled  types.Bool   `tfsdk:"cache_enabled"`
-	RequestLimit  types.Int64  `tfsdk:"request_limit"`
-	RetryMax      types.Int64  `tfsdk:"retry_max"`
-	RetryWaitMin  types.Int64  `tfsdk:"retry_wait_min"`
-	RetryWaitMax  types.Int64  `tfsdk:"retry_wait_max"`
-	RetryDisabled types.Bool   `tfsdk:"retry_disabled"`
+	EdgercPath         types.String `tfsdk:"edgerc"`
+	EdgercSection      types.String `tfsdk:"config_section"`
+	EdgercConfig       types.Set    `tfsdk:"config"`
+	Profiles           types.Set    `tfsdk:"credentials_profile"`
+	AccountKey         types.String `tfsdk:"account_switch_key"`
+	CacheEnabled       types.Bool   `tfsdk:"cache_enabled"`
+	CacheBackend       types.String `tfsdk:"cache_backend"`
+	CacheDir           types.String `tfsdk:"cache_dir"`
+	CacheTTL           types.Int64  `tfsdk:"cache_ttl"`
+	RequestLimit       types.Int64  `tfsdk:"request_limit"`
+	RateLimits         types.Set    `tfsdk:"rate_limit"`
+	RateLimitAdaptive  types.Bool   `tfsdk:"rate_limit_adaptive"`
+	CassetteDir        types.String `tfsdk:"http_cassette_dir"`
+	CassetteMode       types.String `tfsdk:"http_cassette_mode"`
+	LogFormat          types.String `tfsdk:"log_format"`
+	LogFile            types.String `tfsdk:"log_file"`
+	MetricsSummaryFile types.String `tfsdk:"metrics_summary_file"`
+	TracingExporter    types.String `tfsdk:"tracing_exporter"`
+	TracingEndpoint    types.String `tfsdk:"tracing_endpoint"`
+	TracingFile        types.String `tfsdk:"tracing_file"`
+	RetryMax           types.Int64  `tfsdk:"retry_max"`
+	RetryWriteMax      types.Int64  `tfsdk:"retry_write_max"`
+	RetryMethods       types.List   `tfsdk:"retry_methods"`
+	RetryWaitMin       types.Int64  `tfsdk:"retry_wait_min"`
+	RetryWaitMax       types.Int64  `tfsdk:"retry_wait_max"`
+	RetryDisabled      types.Bool   `tfsdk:"retry_disabled"`
 }
 
 // ConfigModel represents the model of edgegrid configuration block
@@ -48,6 +66,26 @@ type ConfigModel struct {
 	AccountKey   types.String `tfsdk:"account_key"`
 }
 
+// CredentialsProfileModel represents the model of named credentials profile block
+type CredentialsProfileModel struct {
+	Name          types.String `tfsdk:"name"`
+	EdgercPath    types.String `tfsdk:"edgerc"`
+	EdgercSection types.String `tfsdk:"config_section"`
+	Host          types.String `tfsdk:"host"`
+	AccessToken   types.String `tfsdk:"access_token"`
+	ClientToken   types.String `tfsdk:"client_token"`
+	ClientSecret  types.String `tfsdk:"client_secret"`
+	MaxBody       types.Int64  `tfsdk:"max_body"`
+	AccountKey    types.String `tfsdk:"account_key"`
+}
+
+// RateLimitModel represents the model of API family rate limit block
+type RateLimitModel struct {
+	API               types.String  `tfsdk:"api"`
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	Burst             types.Int64   `tfsdk:"burst"`
+}
+
 // NewFrameworkProvider returns a function returning Provider as provider.Provider
 func NewFrameworkProvider(subproviders ...subprovider.Subprovider) func() provider.Provider {
 	return func() provider.Provider {
@@ -74,17 +112,79 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 				Description: "The section of the edgerc file to use for configuration",
 				Optional:    true,
 			},
+			"account_switch_key": schema.StringAttribute{
+				Description: "The account switch key applied to every API request. It overrides `account_key` of the default credentials, " +
+					"but not the one of a credentials profile, and is overridden by `account_switch_key` of a resource",
+				Optional: true,
+			},
 			"cache_enabled": schema.BoolAttribute{
 				Optional: true,
 			},
+			"cache_backend": schema.StringAttribute{
+				Description: "The storage of the cache: 'memory' (default) or 'file', which persists the cache between runs",
+				Optional:    true,
+			},
+			"cache_dir": schema.StringAttribute{
+				Description: "The directory of the 'file' cache backend, default is the user cache directory",
+				Optional:    true,
+			},
+			"cache_ttl": schema.Int64Attribute{
+				Description: "The time in seconds after which the cache entries expire, default is 600 sec",
+				Optional:    true,
+			},
 			"request_limit": schema.Int64Attribute{
 				Description: "The maximum number of API requests to be made per second (0 for no limit)",
 				Optional:    true,
 			},
+			"rate_limit_adaptive": schema.BoolAttribute{
+				Description: "Should the rate of requests to an API family be reduced when the API throttles the requests, default false",
+				Optional:    true,
+			},
+			"http_cassette_dir": schema.StringAttribute{
+				Description: "The directory of sanitized HTTP interactions recorded or replayed according to `http_cassette_mode`",
+				Optional:    true,
+			},
+			"http_cassette_mode": schema.StringAttribute{
+				Description: "Either 'record', which records the API traffic into `http_cassette_dir`, or 'replay', which serves the recorded responses without network access",
+				Optional:    true,
+			},
+			"log_format": schema.StringAttribute{
+				Description: "The format of the provider logs, either 'text' or 'json', which writes every entry as a single JSON object, default 'text'",
+				Optional:    true,
+			},
+			"log_file": schema.StringAttribute{
+				Description: "The file the provider logs are appended to instead of the Terraform log",
+				Optional:    true,
+			},
+			"metrics_summary_file": schema.StringAttribute{
+				Description: "The file the JSON summary of API requests made per API family and resource type is written to when the provider shuts down. Every provider process writes to its own file with the process ID inserted before the extension, e.g. `summary.1234.json`",
+				Optional:    true,
+			},
+			"tracing_exporter": schema.StringAttribute{
+				Description: "The tracing exporter of provider operations, either 'otlp', which exports the spans to `tracing_endpoint`, or 'file', which writes them to `tracing_file`. Tracing is disabled by default",
+				Optional:    true,
+			},
+			"tracing_endpoint": schema.StringAttribute{
+				Description: "The URL of the OTLP/HTTP endpoint the spans are exported to, defaults to `OTEL_EXPORTER_OTLP_ENDPOINT` environment variable or http://localhost:4318",
+				Optional:    true,
+			},
+			"tracing_file": schema.StringAttribute{
+				Description: "The file the spans are written to as JSON by the 'file' tracing exporter",
+				Optional:    true,
+			},
 			"retry_max": schema.Int64Attribute{
 				Description: "The maximum number retires of API requests, default 10",
 				Optional:    true,
 			},
+			"retry_write_max": schema.Int64Attribute{
+				Description: "The maximum number retries of API requests modifying objects, default 3",
+				Optional:    true,
+			},
+			"retry_methods": schema.ListAttribute{
+				Description: "The HTTP methods of API requests retried after transient failures, default GET, HEAD and OPTIONS. Requests rejected with 429 status are retried regardless of their method",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 			"retry_wait_min": schema.Int64Attribute{
 				Description: "The minimum wait time in seconds between API requests retries, default is 1 sec",
 				Optional:    true,
@@ -127,6 +227,63 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 					},
 				},
 			},
+			"rate_limit": schema.SetNestedBlock{
+				Description: "The client-side limit of requests per second made to an API family",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"api": schema.StringAttribute{
+							Description: "The API family, which is the first segment of the API path, e.g. 'papi', 'appsec', 'config-dns' or 'config-gtm'",
+							Required:    true,
+						},
+						"requests_per_second": schema.Float64Attribute{
+							Description: "The maximum number of requests per second made to the API family",
+							Required:    true,
+						},
+						"burst": schema.Int64Attribute{
+							Description: "The maximum number of requests made at once, defaults to the requests per second rounded up",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"credentials_profile": schema.SetNestedBlock{
+				Description: "Named EdgeGrid credentials which can be selected with the `credentials_profile` argument of resources and data sources",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the credentials profile",
+							Required:    true,
+						},
+						"edgerc": schema.StringAttribute{
+							Description: "The path to the edgerc file, defaults to the provider's edgerc",
+							Optional:    true,
+						},
+						"config_section": schema.StringAttribute{
+							Description: "The section of the edgerc file to use, defaults to the name of the profile",
+							Optional:    true,
+						},
+						"host": schema.StringAttribute{
+							Optional: true,
+						},
+						"access_token": schema.StringAttribute{
+							Optional: true,
+						},
+						"client_token": schema.StringAttribute{
+							Optional: true,
+						},
+						"client_secret": schema.StringAttribute{
+							Optional:  true,
+							Sensitive: true,
+						},
+						"max_body": schema.Int64Attribute{
+							Optional: true,
+						},
+						"account_key": schema.StringAttribute{
+							Optional: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -190,18 +347,102 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
+	var profiles []credentialsProfile
+	if !data.Profiles.IsNull() {
+		var profileModels []CredentialsProfileModel
+		resp.Diagnostics.Append(data.Profiles.ElementsAs(ctx, &profileModels, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, profileModel := range profileModels {
+			profiles = append(profiles, credentialsProfile{
+				name:       profileModel.Name.ValueString(),
+				edgercPath: profileModel.EdgercPath.ValueString(),
+				section:    profileModel.EdgercSection.ValueString(),
+				config: configBearer{
+					accessToken:  profileModel.AccessToken.ValueString(),
+					accountKey:   profileModel.AccountKey.ValueString(),
+					clientSecret: profileModel.ClientSecret.ValueString(),
+					clientToken:  profileModel.ClientToken.ValueString(),
+					host:         profileModel.Host.ValueString(),
+					maxBody:      int(profileModel.MaxBody.ValueInt64()),
+				},
+			})
+		}
+	}
+
+	profileConfigs, err := newProfilesEdgegridConfigs(data.EdgercPath.ValueString(), profiles)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
+		return
+	}
+
+	accountKey := getFrameworkConfigString(data.AccountKey, "AKAMAI_ACCOUNT_SWITCH_KEY")
+
+	cacheBackend := getFrameworkConfigString(data.CacheBackend, "AKAMAI_CACHE_BACKEND")
+	cacheDir := getFrameworkConfigString(data.CacheDir, "AKAMAI_CACHE_DIR")
+
+	cacheTTL, err := getFrameworkConfigInt(data.CacheTTL, "AKAMAI_CACHE_TTL")
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
+		return
+	}
+
 	requestLimit, err := getFrameworkConfigInt(data.RequestLimit, "AKAMAI_REQUEST_LIMIT")
 	if err != nil {
 		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
 		return
 	}
 
+	var rateLimits []rateLimit
+	if !data.RateLimits.IsNull() {
+		var rateLimitModels []RateLimitModel
+		resp.Diagnostics.Append(data.RateLimits.ElementsAs(ctx, &rateLimitModels, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, rateLimitModel := range rateLimitModels {
+			rateLimits = append(rateLimits, rateLimit{
+				api:               rateLimitModel.API.ValueString(),
+				requestsPerSecond: rateLimitModel.RequestsPerSecond.ValueFloat64(),
+				burst:             int(rateLimitModel.Burst.ValueInt64()),
+			})
+		}
+	}
+
+	rateLimitAdaptive, err := getFrameworkConfigBool(data.RateLimitAdaptive, "AKAMAI_RATE_LIMIT_ADAPTIVE")
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
+		return
+	}
+
+	cassetteDir := getFrameworkConfigString(data.CassetteDir, "AKAMAI_HTTP_CASSETTE_DIR")
+	cassetteMode := getFrameworkConfigString(data.CassetteMode, "AKAMAI_HTTP_CASSETTE_MODE")
+	logFormat := getFrameworkConfigString(data.LogFormat, "AKAMAI_LOG_FORMAT")
+	logFile := getFrameworkConfigString(data.LogFile, "AKAMAI_LOG_FILE")
+	metricsSummaryFile := getFrameworkConfigString(data.MetricsSummaryFile, "AKAMAI_METRICS_SUMMARY_FILE")
+	tracingExporter := getFrameworkConfigString(data.TracingExporter, "AKAMAI_TRACING_EXPORTER")
+	tracingEndpoint := getFrameworkConfigString(data.TracingEndpoint, "AKAMAI_TRACING_ENDPOINT")
+	tracingFile := getFrameworkConfigString(data.TracingFile, "AKAMAI_TRACING_FILE")
+
 	retryMax, err := getFrameworkConfigInt(data.RetryMax, "AKAMAI_RETRY_MAX")
 	if err != nil {
 		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
 		return
 	}
 
+	retryWriteMax, err := getFrameworkConfigInt(data.RetryWriteMax, "AKAMAI_RETRY_WRITE_MAX")
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
+		return
+	}
+
+	retryMethods, diags := getFrameworkConfigStringList(ctx, data.RetryMethods, "AKAMAI_RETRY_METHODS")
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
 	retryWaitMin, err := getFrameworkConfigInt(data.RetryWaitMin, "AKAMAI_RETRY_WAIT_MIN")
 	if err != nil {
 		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
@@ -221,15 +462,32 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 	}
 
 	meta, err := configureContext(contextConfig{
-		edgegridConfig: edgegridConfig,
-		userAgent:      userAgent(req.TerraformVersion),
-		ctx:            ctx,
-		requestLimit:   requestLimit,
-		enableCache:    data.CacheEnabled.ValueBool(),
-		retryMax:       retryMax,
-		retryWaitMin:   time.Duration(retryWaitMin) * time.Second,
-		retryWaitMax:   time.Duration(retryWaitMax) * time.Second,
-		retryDisabled:  retryDisabled,
+		edgegridConfig:     edgegridConfig,
+		profiles:           profileConfigs,
+		accountKey:         accountKey,
+		userAgent:          userAgent(req.TerraformVersion),
+		ctx:                ctx,
+		requestLimit:       requestLimit,
+		rateLimits:         rateLimits,
+		rateLimitAdaptive:  rateLimitAdaptive,
+		cassetteDir:        cassetteDir,
+		cassetteMode:       cassetteMode,
+		logFormat:          logFormat,
+		logFile:            logFile,
+		metricsSummaryFile: metricsSummaryFile,
+		tracingExporter:    tracingExporter,
+		tracingEndpoint:    tracingEndpoint,
+		tracingFile:        tracingFile,
+		enableCache:        data.CacheEnabled.ValueBool(),
+		cacheBackend:       cacheBackend,
+		cacheDir:           cacheDir,
+		cacheTTL:           time.Duration(cacheTTL) * time.Second,
+		retryMax:           retryMax,
+		retryWriteMax:      retryWriteMax,
+		retryMethods:       retryMethods,
+		retryWaitMin:       time.Duration(retryWaitMin) * time.Second,
+		retryWaitMax:       time.Duration(retryWaitMax) * time.Second,
+		retryDisabled:      retryDisabled,
 	})
 	if err != nil {
 		resp.Diagnostics.Append(diag.NewErrorDiagnostic("configuring context failed", err.Error()))
@@ -248,7 +506,7 @@ func (p *Provider) Resources(_ context.Context) []func() resource.Resource {
 		resources = append(resources, subprovider.FrameworkResources()...)
 	}
 
-	return resources
+	return withFrameworkResourceMetaArguments(resources)
 }
 
 // DataSources returns slice of functions used to instantiate data source implementations
@@ -259,7 +517,26 @@ func (p *Provider) DataSources(_ context.Context) []func() datasource.DataSource
 		dataSources = append(dataSources, subprovider.FrameworkDataSources()...)
 	}
 
-	return dataSources
+	return withFrameworkDataSourceMetaArguments(dataSources)
+}
+
+func getFrameworkConfigString(tfValue types.String, envKey string) string {
+	if tfValue.IsNull() {
+		return os.Getenv(envKey)
+	}
+	return tfValue.ValueString()
+}
+
+func getFrameworkConfigStringList(ctx context.Context, tfValue types.List, envKey string) ([]string, diag.Diagnostics) {
+	if tfValue.IsNull() {
+		if v := os.Getenv(envKey); v != "" {
+			return strings.Split(v, ","), nil
+		}
+		return nil, nil
+	}
+	var ret []string
+	diags := tfValue.ElementsAs(ctx, &ret, false)
+	return ret, diags
 }
 
 func getFrameworkConfigInt(tfValue types.Int64, envKey string) (int, error) {
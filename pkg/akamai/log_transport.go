@@ -0,0 +1,37 @@
+package akamai
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+	"github.com/apex/log"
+)
+
+// logTransport logs every API request with its API family, status and latency.
+// The entries also carry the log fields from the request context, e.g. the type and ID
+// of the resource the request was made for, so that they can be correlated in structured logs.
+type logTransport struct {
+	next http.RoundTripper
+	log  log.Interface
+}
+
+// RoundTrip executes the request and logs its outcome
+func (t *logTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(r)
+
+	fields := apexFields(logger.FieldsFromContext(r.Context())...)
+	fields["API"] = apiFamily(r.URL.Path)
+	fields["Method"] = r.Method
+	fields["Path"] = r.URL.Path
+	fields["LatencyMs"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		t.log.WithFields(fields).WithError(err).Warn("API request failed")
+		return resp, err
+	}
+	fields["Status"] = resp.StatusCode
+	t.log.WithFields(fields).Debug("API request completed")
+	return resp, nil
+}
@@ -0,0 +1,89 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestLogTransport(t *testing.T) {
+	t.Setenv("TF_LOG", "DEBUG")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	logFile := filepath.Join(t.TempDir(), "provider.log")
+	hclog, err := logger.NewHCLog(logger.FormatJSON, logFile)
+	require.NoError(t, err)
+	log := logger.FromHCLog(hclog)
+
+	readEntries := func(t *testing.T) []map[string]any {
+		data, err := os.ReadFile(logFile)
+		require.NoError(t, err)
+		var entries []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+
+	t.Run("response is logged with context fields", func(t *testing.T) {
+		transport := &logTransport{next: http.DefaultTransport, log: log}
+		ctx := logger.ContextWithFields(context.Background(), "ResourceType", "akamai_property", "ResourceID", "prp_1")
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/papi/v1/properties/prp_1?contractId=ctr_1", nil)
+		require.NoError(t, err)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		entries := readEntries(t)
+		require.Len(t, entries, 1)
+		entry := entries[0]
+		assert.Equal(t, "debug", entry["@level"])
+		assert.Equal(t, "API request completed", entry["@message"])
+		assert.Equal(t, "papi", entry["API"])
+		assert.Equal(t, http.MethodGet, entry["Method"])
+		assert.Equal(t, "/papi/v1/properties/prp_1", entry["Path"])
+		assert.Equal(t, float64(http.StatusNotFound), entry["Status"])
+		assert.Contains(t, entry, "LatencyMs")
+		assert.Equal(t, "akamai_property", entry["ResourceType"])
+		assert.Equal(t, "prp_1", entry["ResourceID"])
+	})
+
+	t.Run("transport error is logged", func(t *testing.T) {
+		transport := &logTransport{next: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		}), log: log}
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/config-dns/v2/zones", nil)
+		require.NoError(t, err)
+		_, err = transport.RoundTrip(req)
+		require.Error(t, err)
+
+		entries := readEntries(t)
+		require.Len(t, entries, 2)
+		entry := entries[1]
+		assert.Equal(t, "warn", entry["@level"])
+		assert.Equal(t, "config-dns", entry["API"])
+		assert.Equal(t, "connection refused", entry["error"])
+		assert.NotContains(t, entry, "Status")
+	})
+}
@@ -0,0 +1,213 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// credentialsProfileKey is the name of the argument added to every resource and data source,
+	// which selects one of the credentials profiles configured in the provider block
+	credentialsProfileKey = "credentials_profile"
+
+	// accountSwitchKeyKey is the name of the argument added to every resource and data source,
+	// which overrides the account switch key for all requests made for that resource
+	accountSwitchKeyKey = "account_switch_key"
+
+	// importMetaArgumentsSeparator separates the meta-arguments prefix from the ID of the imported resource,
+	// e.g. 'credentials_profile=other,account_switch_key=1-ABC:1-DEF;prp_1'
+	importMetaArgumentsSeparator = ";"
+
+	// resourceTypeLogField is the log field with the type of the resource the entry is logged for
+	resourceTypeLogField = "ResourceType"
+	// resourceIDLogField is the log field with the ID of the resource the entry is logged for
+	resourceIDLogField = "ResourceID"
+)
+
+// metaArguments are the arguments added by the provider to every resource and data source
+var metaArguments = []string{credentialsProfileKey, accountSwitchKeyKey}
+
+const (
+	credentialsProfileDescription = "The name of the credentials profile from the provider configuration used to manage this resource. " +
+		"On import, it is given as the prefix of the import ID, e.g. 'credentials_profile=other;<ID>'"
+	accountSwitchKeyDescription = "The account switch key used to manage this resource, overrides the one from the provider configuration. " +
+		"On import, it is given as the prefix of the import ID, e.g. 'account_switch_key=1-ABC:1-DEF;<ID>'"
+)
+
+type (
+	crudFunc interface {
+		~func(context.Context, *schema.ResourceData, any) diag.Diagnostics
+	}
+
+	metaArgumentsGetter interface {
+		Get(string) any
+		Id() string
+	}
+)
+
+// addMetaArguments extends the schema of the given resources with the provider meta-arguments
+// and wraps their CRUD functions, so that they receive meta resolved from those arguments,
+// log entries carrying the resource type and ID, and are traced.
+// Importers are wrapped as well. Terraform does not pass the configuration to them,
+// so the meta-arguments are given as the prefix of the import ID, see splitImportID
+func addMetaArguments(resources map[string]*schema.Resource) {
+	for name, r := range resources {
+		if r.Schema == nil {
+			r.Schema = make(map[string]*schema.Schema)
+		}
+		r.Schema[credentialsProfileKey] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: credentialsProfileDescription,
+		}
+		r.Schema[accountSwitchKeyKey] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: accountSwitchKeyDescription,
+		}
+
+		r.CreateContext = withMetaArguments(name, withTracing(name, "Create", r.CreateContext))
+		r.ReadContext = withMetaArguments(name, withTracing(name, "Read", r.ReadContext))
+		r.UpdateContext = skipMetaArgumentsUpdate(withMetaArguments(name, withTracing(name, "Update", r.UpdateContext)))
+		r.DeleteContext = withMetaArguments(name, withTracing(name, "Delete", r.DeleteContext))
+		r.CreateWithoutTimeout = withMetaArguments(name, withTracing(name, "Create", r.CreateWithoutTimeout))
+		r.ReadWithoutTimeout = withMetaArguments(name, withTracing(name, "Read", r.ReadWithoutTimeout))
+		r.UpdateWithoutTimeout = skipMetaArgumentsUpdate(withMetaArguments(name, withTracing(name, "Update", r.UpdateWithoutTimeout)))
+		r.DeleteWithoutTimeout = withMetaArguments(name, withTracing(name, "Delete", r.DeleteWithoutTimeout))
+		if r.Importer != nil {
+			r.Importer = withMetaArgumentsImporter(name, r.Importer)
+		}
+		if r.CustomizeDiff != nil {
+			customizeDiff := r.CustomizeDiff
+			r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+				ctx, m, err := resolveMetaArguments(ctx, name, d, m)
+				if err != nil {
+					return err
+				}
+				return customizeDiff(ctx, d, m)
+			}
+		}
+	}
+}
+
+func withMetaArguments[F crudFunc](name string, f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		ctx, m, err := resolveMetaArguments(ctx, name, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, m)
+	}
+}
+
+func withMetaArgumentsImporter(name string, importer *schema.ResourceImporter) *schema.ResourceImporter {
+	stateContext := importer.StateContext
+	if stateContext == nil && importer.State != nil {
+		state := importer.State
+		stateContext = func(_ context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+			return state(d, m)
+		}
+	}
+	if stateContext == nil {
+		return importer
+	}
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+			id, args, err := splitImportID(d.Id())
+			if err != nil {
+				return nil, err
+			}
+			d.SetId(id)
+			for key, value := range args {
+				if err := d.Set(key, value); err != nil {
+					return nil, err
+				}
+			}
+			ctx, m, err = resolveMetaArguments(ctx, name, d, m)
+			if err != nil {
+				return nil, err
+			}
+			return stateContext(ctx, d, m)
+		},
+	}
+}
+
+// splitImportID splits the import ID into the ID of the resource and the meta-arguments given as its prefix,
+// which is a comma separated list of 'name=value' pairs followed by importMetaArgumentsSeparator.
+// IDs without such prefix are returned unchanged.
+func splitImportID(importID string) (string, map[string]string, error) {
+	prefix, id, found := strings.Cut(importID, importMetaArgumentsSeparator)
+	if !found {
+		return importID, nil, nil
+	}
+	args := make(map[string]string)
+	for _, pair := range strings.Split(prefix, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			// not a meta-arguments prefix, but a part of the ID
+			return importID, nil, nil
+		}
+		if !slices.Contains(metaArguments, name) {
+			return "", nil, fmt.Errorf("unsupported meta-argument %q in import ID %q, supported are: %s",
+				name, importID, strings.Join(metaArguments, ", "))
+		}
+		args[name] = value
+	}
+	return id, args, nil
+}
+
+// skipMetaArgumentsUpdate prevents calling the update function when only meta-arguments have changed,
+// as the change of those arguments does not require any API calls.
+// Updates without changes of meta-arguments are always passed through, as they may be planned
+// by the resource itself, e.g. by marking computed attributes as unknown in CustomizeDiff.
+func skipMetaArgumentsUpdate[F crudFunc](f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		if d.HasChanges(metaArguments...) && !d.HasChangesExcept(metaArguments...) {
+			return nil
+		}
+		return f(ctx, d, m)
+	}
+}
+
+// resolveMetaArguments returns the meta bound to the credentials profile selected in the resource
+// and the context carrying the resource's account switch key. The cache is not used with the context
+// when the resource selects a credentials profile or an account switch key.
+// Both add the resource type and ID to the log entries, so that the entries logged for
+// one resource, including the API requests, can be correlated. Terraform does not pass
+// the resource address to providers, hence the type and ID are used instead.
+func resolveMetaArguments(ctx context.Context, name string, d metaArgumentsGetter, m any) (context.Context, meta.Meta, error) {
+	profile, _ := d.Get(credentialsProfileKey).(string)
+	accountSwitchKey, _ := d.Get(accountSwitchKeyKey).(string)
+	return resolveMeta(ctx, name, d.Id(), profile, accountSwitchKey, m)
+}
+
+// resolveMeta implements resolveMetaArguments for the given values of the meta-arguments
+func resolveMeta(ctx context.Context, name, id, profile, accountSwitchKey string, m any) (context.Context, meta.Meta, error) {
+	profileMeta, err := meta.Must(m).CredentialsProfile(profile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logFields := []interface{}{resourceTypeLogField, name, resourceIDLogField, id}
+	ctx = logger.ContextWithFields(ctx, logFields...)
+
+	if profile != "" || accountSwitchKey != "" {
+		// cached data is read with the default credentials and account and must not be shared with other accounts
+		ctx = cache.ContextWithoutCache(ctx)
+	}
+	return contextWithAccountSwitchKey(ctx, accountSwitchKey), profileMeta.WithLogFields(logFields...), nil
+}
@@ -0,0 +1,179 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddMetaArguments(t *testing.T) {
+	cache.Enable(true)
+	defer cache.Enable(false)
+	defaultSess := session.Must(session.New())
+	profileSess := session.Must(session.New())
+	m, err := meta.New(defaultSess, hclog.NewNullLogger(), "opID", meta.WithCredentialsProfiles(map[string]session.Session{
+		"other": profileSess,
+	}))
+	require.NoError(t, err)
+
+	var usedSess session.Session
+	var usedAccountKey any
+	var usedCache bool
+	var usedLogFields []interface{}
+	var updated bool
+	resources := map[string]*schema.Resource{
+		"akamai_test": {
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"computed": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+			ReadContext: func(ctx context.Context, _ *schema.ResourceData, m any) diag.Diagnostics {
+				usedSess = meta.Must(m).Session()
+				usedAccountKey = ctx.Value(accountSwitchKeyContextKey{})
+				usedCache = !errors.Is(cache.Get(ctx, cache.BucketName("test"), "key", nil), cache.ErrDisabled)
+				usedLogFields = logger.FieldsFromContext(ctx)
+				return nil
+			},
+			UpdateContext: func(_ context.Context, _ *schema.ResourceData, _ any) diag.Diagnostics {
+				updated = true
+				return nil
+			},
+			Importer: &schema.ResourceImporter{
+				State: func(d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+					usedSess = meta.Must(m).Session()
+					return []*schema.ResourceData{d}, nil
+				},
+			},
+		},
+	}
+	addMetaArguments(resources)
+	res := resources["akamai_test"]
+	require.Contains(t, res.Schema, credentialsProfileKey)
+	require.Contains(t, res.Schema, accountSwitchKeyKey)
+
+	tests := map[string]struct {
+		profile            string
+		accountKey         string
+		expectedSess       session.Session
+		expectedAccountKey any
+		expectedCache      bool
+		expectedError      string
+	}{
+		"default credentials": {
+			expectedSess:  defaultSess,
+			expectedCache: true,
+		},
+		"named profile": {
+			profile:      "other",
+			expectedSess: profileSess,
+		},
+		"account switch key": {
+			accountKey:         "key",
+			expectedSess:       defaultSess,
+			expectedAccountKey: "key",
+		},
+		"unknown profile": {
+			profile:       "unknown",
+			expectedError: `credentials profile not found: "unknown"`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			usedSess, usedAccountKey = nil, nil
+			d := schema.TestResourceDataRaw(t, res.Schema, map[string]any{
+				credentialsProfileKey: test.profile,
+				accountSwitchKeyKey:   test.accountKey,
+			})
+
+			diags := res.ReadContext(context.Background(), d, m)
+			if test.expectedError != "" {
+				require.True(t, diags.HasError())
+				assert.Equal(t, test.expectedError, diags[0].Summary)
+				return
+			}
+			require.False(t, diags.HasError())
+			assert.Same(t, test.expectedSess, usedSess)
+			assert.Equal(t, test.expectedAccountKey, usedAccountKey)
+			assert.Equal(t, test.expectedCache, usedCache)
+			assert.Equal(t, []interface{}{"ResourceType", "akamai_test", "ResourceID", ""}, usedLogFields)
+		})
+	}
+
+	t.Run("import uses default credentials", func(t *testing.T) {
+		usedSess = nil
+		d := schema.TestResourceDataRaw(t, res.Schema, map[string]any{})
+		d.SetId("1")
+
+		require.Nil(t, res.Importer.State)
+		imported, err := res.Importer.StateContext(context.Background(), d, m)
+		require.NoError(t, err)
+		assert.Len(t, imported, 1)
+		assert.Same(t, defaultSess, usedSess)
+	})
+
+	t.Run("import with meta-arguments in import ID", func(t *testing.T) {
+		usedSess = nil
+		d := schema.TestResourceDataRaw(t, res.Schema, map[string]any{})
+		d.SetId("credentials_profile=other,account_switch_key=1-A:1-B;1")
+
+		imported, err := res.Importer.StateContext(context.Background(), d, m)
+		require.NoError(t, err)
+		require.Len(t, imported, 1)
+		assert.Same(t, profileSess, usedSess)
+		assert.Equal(t, "1", imported[0].Id())
+		assert.Equal(t, "other", imported[0].Get(credentialsProfileKey))
+		assert.Equal(t, "1-A:1-B", imported[0].Get(accountSwitchKeyKey))
+	})
+
+	t.Run("update is skipped when only meta-arguments changed", func(t *testing.T) {
+		state := &terraform.InstanceState{
+			ID:         "1",
+			Attributes: map[string]string{"id": "1", "name": "a", "computed": "a"},
+		}
+		newData := func(diff map[string]*terraform.ResourceAttrDiff) *schema.ResourceData {
+			d, err := schema.InternalMap(res.Schema).Data(state, &terraform.InstanceDiff{Attributes: diff})
+			require.NoError(t, err)
+			return d
+		}
+
+		d := newData(map[string]*terraform.ResourceAttrDiff{
+			credentialsProfileKey: {Old: "", New: "other"},
+		})
+		diags := res.UpdateContext(context.Background(), d, m)
+		require.False(t, diags.HasError())
+		assert.False(t, updated)
+
+		d = newData(map[string]*terraform.ResourceAttrDiff{
+			credentialsProfileKey: {Old: "", New: "other"},
+			"name":                {Old: "a", New: "b"},
+		})
+		diags = res.UpdateContext(context.Background(), d, m)
+		require.False(t, diags.HasError())
+		assert.True(t, updated)
+
+		updated = false
+		d = newData(map[string]*terraform.ResourceAttrDiff{
+			"computed": {Old: "a", NewComputed: true},
+		})
+		diags = res.UpdateContext(context.Background(), d, m)
+		require.False(t, diags.HasError())
+		assert.True(t, updated)
+	})
+}
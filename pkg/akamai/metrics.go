@@ -0,0 +1,293 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+	"github.com/apex/log"
+)
+
+type (
+	// metricsTransport records the metrics of every API request attempt, including the retried ones
+	metricsTransport struct {
+		next     http.RoundTripper
+		registry *metricsRegistry
+	}
+
+	// metricsRegistry aggregates the metrics of API requests made by the provider process
+	// per API family and resource type
+	metricsRegistry struct {
+		mu          sync.Mutex
+		metrics     map[metricsKey]*requestMetrics
+		summaryFile string
+		log         log.Interface
+	}
+
+	metricsKey struct {
+		api          string
+		resourceType string
+	}
+
+	requestMetrics struct {
+		requests        int64
+		retries         int64
+		clientErrors    int64
+		serverErrors    int64
+		transportErrors int64
+		bytesSent       int64
+		bytesReceived   int64
+		totalLatency    time.Duration
+		maxLatency      time.Duration
+	}
+
+	// metricsSummary is the summary of API requests written to the metrics summary file
+	metricsSummary struct {
+		APIs []apiMetricsSummary `json:"apis"`
+	}
+
+	apiMetricsSummary struct {
+		API             string `json:"api"`
+		ResourceType    string `json:"resourceType,omitempty"`
+		Requests        int64  `json:"requests"`
+		Retries         int64  `json:"retries"`
+		ClientErrors    int64  `json:"clientErrors"`
+		ServerErrors    int64  `json:"serverErrors"`
+		TransportErrors int64  `json:"transportErrors"`
+		BytesSent       int64  `json:"bytesSent"`
+		BytesReceived   int64  `json:"bytesReceived"`
+		TotalLatencyMs  int64  `json:"totalLatencyMs"`
+		AvgLatencyMs    int64  `json:"avgLatencyMs"`
+		MaxLatencyMs    int64  `json:"maxLatencyMs"`
+	}
+
+	// countingReadCloser counts the bytes read from the response body and records them
+	// when the body is read to the end or closed
+	countingReadCloser struct {
+		io.ReadCloser
+		count    int64
+		once     sync.Once
+		onFinish func(int64)
+	}
+)
+
+// providerMetrics holds the metrics of all API requests made by the provider process
+var providerMetrics = newMetricsRegistry()
+
+func newMetricsRegistry() *metricsRegistry {
+	return &metricsRegistry{
+		metrics: make(map[metricsKey]*requestMetrics),
+		log:     logger.Get(),
+	}
+}
+
+// configure sets the logger and the file the summary is emitted to
+func (r *metricsRegistry) configure(summaryFile string, log log.Interface) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if summaryFile != "" {
+		r.summaryFile = summaryFile
+	}
+	r.log = log
+}
+
+func (r *metricsRegistry) record(key metricsKey, update func(*requestMetrics)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m, ok := r.metrics[key]
+	if !ok {
+		m = &requestMetrics{}
+		r.metrics[key] = m
+	}
+	update(m)
+}
+
+// summary returns the metrics sorted by the total latency, so that the bottlenecks come first
+func (r *metricsRegistry) summary() metricsSummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	summary := metricsSummary{APIs: make([]apiMetricsSummary, 0, len(r.metrics))}
+	for key, m := range r.metrics {
+		s := apiMetricsSummary{
+			API:             key.api,
+			ResourceType:    key.resourceType,
+			Requests:        m.requests,
+			Retries:         m.retries,
+			ClientErrors:    m.clientErrors,
+			ServerErrors:    m.serverErrors,
+			TransportErrors: m.transportErrors,
+			BytesSent:       m.bytesSent,
+			BytesReceived:   m.bytesReceived,
+			TotalLatencyMs:  m.totalLatency.Milliseconds(),
+			MaxLatencyMs:    m.maxLatency.Milliseconds(),
+		}
+		if m.requests > 0 {
+			s.AvgLatencyMs = m.totalLatency.Milliseconds() / m.requests
+		}
+		summary.APIs = append(summary.APIs, s)
+	}
+	sort.Slice(summary.APIs, func(i, j int) bool {
+		a, b := summary.APIs[i], summary.APIs[j]
+		if a.TotalLatencyMs != b.TotalLatencyMs {
+			return a.TotalLatencyMs > b.TotalLatencyMs
+		}
+		if a.API != b.API {
+			return a.API < b.API
+		}
+		return a.ResourceType < b.ResourceType
+	})
+	return summary
+}
+
+// emitSummary logs the summary and writes it to the summary file of this process, if configured.
+// Nothing is emitted if no API requests were made.
+func (r *metricsRegistry) emitSummary() error {
+	summary := r.summary()
+	if len(summary.APIs) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	log, summaryFile := r.log, r.summaryFile
+	r.mu.Unlock()
+
+	for _, s := range summary.APIs {
+		log.WithFields(apexFields(
+			"API", s.API,
+			"ResourceType", s.ResourceType,
+			"Requests", s.Requests,
+			"Retries", s.Retries,
+			"ClientErrors", s.ClientErrors,
+			"ServerErrors", s.ServerErrors,
+			"TransportErrors", s.TransportErrors,
+			"BytesSent", s.BytesSent,
+			"BytesReceived", s.BytesReceived,
+			"TotalLatencyMs", s.TotalLatencyMs,
+			"AvgLatencyMs", s.AvgLatencyMs,
+			"MaxLatencyMs", s.MaxLatencyMs,
+		)).Info("API requests summary")
+	}
+
+	if summaryFile == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(processSummaryFile(summaryFile, os.Getpid()), data, 0600); err != nil {
+		return fmt.Errorf("cannot write metrics summary file: %w", err)
+	}
+	return nil
+}
+
+// processSummaryFile returns the summary file of the process with the given pid. Terraform starts separate
+// provider processes e.g. for plan and apply, or for each aliased provider configuration,
+// so that every process writes its summary to its own file next to the configured one,
+// e.g. summary.1234.json for the summary.json.
+func processSummaryFile(summaryFile string, pid int) string {
+	ext := filepath.Ext(summaryFile)
+	return fmt.Sprintf("%s.%d%s", strings.TrimSuffix(summaryFile, ext), pid, ext)
+}
+
+// RoundTrip executes the request and records its metrics
+func (t *metricsTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	key := metricsKey{
+		api:          apiFamily(r.URL.Path),
+		resourceType: resourceTypeFromContext(r.Context()),
+	}
+	retried := false
+	if attempts, ok := r.Context().Value(retryAttemptsContextKey{}).(*int); ok {
+		retried = *attempts > 0
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(r)
+	latency := time.Since(start)
+
+	t.registry.record(key, func(m *requestMetrics) {
+		m.requests++
+		if retried {
+			m.retries++
+		}
+		if r.ContentLength > 0 {
+			m.bytesSent += r.ContentLength
+		}
+		m.totalLatency += latency
+		m.maxLatency = max(m.maxLatency, latency)
+		switch {
+		case err != nil:
+			m.transportErrors++
+		case resp.StatusCode >= 500:
+			m.serverErrors++
+		case resp.StatusCode >= 400:
+			m.clientErrors++
+		}
+	})
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+
+	resp.Body = &countingReadCloser{
+		ReadCloser: resp.Body,
+		onFinish: func(count int64) {
+			t.registry.record(key, func(m *requestMetrics) {
+				m.bytesReceived += count
+			})
+		},
+	}
+	return resp, nil
+}
+
+// Read reads from the body and counts the read bytes
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.count += int64(n)
+	if err == io.EOF {
+		c.finish()
+	}
+	return n, err
+}
+
+// Close closes the body and records the read bytes
+func (c *countingReadCloser) Close() error {
+	c.finish()
+	return c.ReadCloser.Close()
+}
+
+func (c *countingReadCloser) finish() {
+	c.once.Do(func() {
+		c.onFinish(c.count)
+	})
+}
+
+// resourceTypeFromContext returns the type of the resource the request is made for
+func resourceTypeFromContext(ctx context.Context) string {
+	fields := logger.FieldsFromContext(ctx)
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i] == resourceTypeLogField {
+			resourceType, _ := fields[i+1].(string)
+			return resourceType
+		}
+	}
+	return ""
+}
+
+// apexFields converts the key-value pairs to log.Fields
+func apexFields(args ...interface{}) log.Fields {
+	fields := make(log.Fields, len(args)/2)
+	for i := 0; i+1 < len(args); i += 2 {
+		fields[fmt.Sprint(args[i])] = args[i+1]
+	}
+	return fields
+}
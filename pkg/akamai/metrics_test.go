@@ -0,0 +1,141 @@
+package akamai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/papi/v1/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/appsec/v1/configs":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, err := w.Write([]byte(`{"ok":true}`))
+			require.NoError(t, err)
+		}
+	}))
+	defer srv.Close()
+
+	registry := newMetricsRegistry()
+	transport := &metricsTransport{next: http.DefaultTransport, registry: registry}
+	roundTrip := func(t *testing.T, ctx context.Context, method, path, body string) {
+		req, err := http.NewRequestWithContext(ctx, method, srv.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		_, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	propertyCtx := logger.ContextWithFields(context.Background(), resourceTypeLogField, "akamai_property", resourceIDLogField, "prp_1")
+	roundTrip(t, propertyCtx, http.MethodPut, "/papi/v1/properties/prp_1", `{"rules":{}}`)
+	roundTrip(t, propertyCtx, http.MethodGet, "/papi/v1/missing", "")
+	attempts := 1
+	retryCtx := context.WithValue(propertyCtx, retryAttemptsContextKey{}, &attempts)
+	roundTrip(t, retryCtx, http.MethodGet, "/papi/v1/properties/prp_1", "")
+	roundTrip(t, context.Background(), http.MethodGet, "/appsec/v1/configs", "")
+
+	failing := &metricsTransport{next: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}), registry: registry}
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/appsec/v1/configs", nil)
+	require.NoError(t, err)
+	_, err = failing.RoundTrip(req)
+	require.Error(t, err)
+
+	summary := registry.summary()
+	require.Len(t, summary.APIs, 2)
+	byAPI := make(map[string]apiMetricsSummary)
+	for _, s := range summary.APIs {
+		byAPI[s.API] = s
+	}
+
+	papi := byAPI["papi"]
+	assert.Equal(t, "akamai_property", papi.ResourceType)
+	assert.Equal(t, int64(3), papi.Requests)
+	assert.Equal(t, int64(1), papi.Retries)
+	assert.Equal(t, int64(1), papi.ClientErrors)
+	assert.Equal(t, int64(0), papi.ServerErrors)
+	assert.Equal(t, int64(len(`{"rules":{}}`)), papi.BytesSent)
+	assert.Equal(t, int64(2*len(`{"ok":true}`)), papi.BytesReceived)
+
+	appsec := byAPI["appsec"]
+	assert.Empty(t, appsec.ResourceType)
+	assert.Equal(t, int64(2), appsec.Requests)
+	assert.Equal(t, int64(1), appsec.ServerErrors)
+	assert.Equal(t, int64(1), appsec.TransportErrors)
+}
+
+func TestEmitMetricsSummary(t *testing.T) {
+	summaryFile := filepath.Join(t.TempDir(), "summary.json")
+
+	t.Run("nothing is emitted without requests", func(t *testing.T) {
+		registry := newMetricsRegistry()
+		registry.configure(summaryFile, logger.Get("test"))
+		require.NoError(t, registry.emitSummary())
+		assert.NoFileExists(t, processSummaryFile(summaryFile, os.Getpid()))
+	})
+
+	t.Run("summary is written to the file", func(t *testing.T) {
+		registry := newMetricsRegistry()
+		registry.configure(summaryFile, logger.Get("test"))
+		registry.configure("", logger.Get("test"))
+		registry.record(metricsKey{api: "config-dns", resourceType: "akamai_dns_record"}, func(m *requestMetrics) {
+			m.requests = 4
+			m.totalLatency = 400_000_000
+			m.maxLatency = 200_000_000
+		})
+		registry.record(metricsKey{api: "papi", resourceType: "akamai_property"}, func(m *requestMetrics) {
+			m.requests = 1
+			m.totalLatency = 1_000_000_000
+		})
+		require.NoError(t, registry.emitSummary())
+
+		data, err := os.ReadFile(processSummaryFile(summaryFile, os.Getpid()))
+		require.NoError(t, err)
+		var summary metricsSummary
+		require.NoError(t, json.Unmarshal(data, &summary))
+		assert.Equal(t, metricsSummary{APIs: []apiMetricsSummary{
+			{API: "papi", ResourceType: "akamai_property", Requests: 1, TotalLatencyMs: 1000, AvgLatencyMs: 1000},
+			{API: "config-dns", ResourceType: "akamai_dns_record", Requests: 4, TotalLatencyMs: 400, AvgLatencyMs: 100, MaxLatencyMs: 200},
+		}}, summary)
+	})
+}
+
+func TestProcessSummaryFile(t *testing.T) {
+	tests := map[string]struct {
+		summaryFile string
+		expected    string
+	}{
+		"file with extension": {
+			summaryFile: filepath.Join("metrics", "summary.json"),
+			expected:    filepath.Join("metrics", "summary.1234.json"),
+		},
+		"file without extension": {
+			summaryFile: "summary",
+			expected:    "summary.1234",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, processSummaryFile(test.summaryFile, 1234))
+		})
+	}
+}
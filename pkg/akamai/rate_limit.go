@@ -0,0 +1,197 @@
+package akamai
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/apex/log"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// rateLimitRemainingHeader is the Akamai header with the number of requests left in the current rate-limit window
+	rateLimitRemainingHeader = "Akamai-RateLimit-Remaining"
+
+	// adaptiveInitialLimit is the requests per second limit set for the API family without configured limit,
+	// when the adaptive rate limiting observes throttling for the first time
+	adaptiveInitialLimit = rate.Limit(10)
+	// adaptiveMinLimit is the lowest requests per second limit the adaptive rate limiting can slow down to
+	adaptiveMinLimit = rate.Limit(0.1)
+	// adaptiveIncrease is the increase of the requests per second limit after every not throttled response
+	adaptiveIncrease = rate.Limit(0.1)
+)
+
+// ErrInvalidRateLimit is returned when the rate limit of an API family is configured with invalid values
+var ErrInvalidRateLimit = errors.New("invalid rate limit")
+
+type (
+	// rateLimit is the configured limit of requests per second made to the API family
+	rateLimit struct {
+		api               string
+		requestsPerSecond float64
+		burst             int
+	}
+
+	// rateLimitTransport delays the requests, so that they do not exceed the rate limits of their API families.
+	//
+	// In the adaptive mode, the limit of an API family is halved every time the API responds with 429 Too Many Requests
+	// or reports that no requests are left in the current window, and the API is not called until the time from
+	// Akamai-RateLimit-Next header. The limit is then increased gradually back to the configured one.
+	rateLimitTransport struct {
+		next     http.RoundTripper
+		log      log.Interface
+		adaptive bool
+		limits   map[string]rateLimit
+
+		mu       sync.Mutex
+		limiters map[string]*apiRateLimiter
+	}
+
+	apiRateLimiter struct {
+		mu          sync.Mutex
+		limiter     *rate.Limiter
+		ceiling     rate.Limit
+		pausedUntil time.Time
+	}
+)
+
+// newRateLimitTransport returns the transport limiting the requests to the given API families
+func newRateLimitTransport(next http.RoundTripper, limits []rateLimit, adaptive bool, log log.Interface) (*rateLimitTransport, error) {
+	t := &rateLimitTransport{
+		next:     next,
+		log:      log,
+		adaptive: adaptive,
+		limits:   make(map[string]rateLimit, len(limits)),
+		limiters: make(map[string]*apiRateLimiter),
+	}
+	for _, limit := range limits {
+		if limit.api == "" || limit.requestsPerSecond <= 0 || limit.burst < 0 {
+			return nil, fmt.Errorf("%w: api %q, requests per second %v, burst %d", ErrInvalidRateLimit, limit.api, limit.requestsPerSecond, limit.burst)
+		}
+		if _, ok := t.limits[limit.api]; ok {
+			return nil, fmt.Errorf("%w: duplicated api %q", ErrInvalidRateLimit, limit.api)
+		}
+		t.limits[limit.api] = limit
+	}
+	return t, nil
+}
+
+// RoundTrip waits until the request is allowed by the rate limit of its API family and executes it
+func (t *rateLimitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	api := apiFamily(r.URL.Path)
+	limiter := t.limiter(api)
+	if limiter == nil {
+		return t.next.RoundTrip(r)
+	}
+
+	if err := limiter.wait(r); err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(r)
+	if err != nil || !t.adaptive {
+		return resp, err
+	}
+
+	if isThrottled(resp) {
+		limit := limiter.slowDown(resp)
+		t.log.Debugf("API %s is throttled, limiting requests to %.2f per second", api, limit)
+	} else {
+		limiter.speedUp()
+	}
+	return resp, nil
+}
+
+// limiter returns the rate limiter of the API family or nil if requests to this API are not limited
+func (t *rateLimitTransport) limiter(api string) *apiRateLimiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if limiter, ok := t.limiters[api]; ok {
+		return limiter
+	}
+
+	limit, ok := t.limits[api]
+	if !ok && !t.adaptive {
+		return nil
+	}
+
+	limiter := &apiRateLimiter{ceiling: rate.Inf, limiter: rate.NewLimiter(rate.Inf, 1)}
+	if ok {
+		burst := limit.burst
+		if burst == 0 {
+			burst = int(math.Max(1, math.Ceil(limit.requestsPerSecond)))
+		}
+		limiter.ceiling = rate.Limit(limit.requestsPerSecond)
+		limiter.limiter = rate.NewLimiter(limiter.ceiling, burst)
+	}
+	t.limiters[api] = limiter
+	return limiter
+}
+
+func (l *apiRateLimiter) wait(r *http.Request) error {
+	l.mu.Lock()
+	pause := time.Until(l.pausedUntil)
+	l.mu.Unlock()
+
+	if pause > 0 {
+		timer := time.NewTimer(pause)
+		defer timer.Stop()
+		select {
+		case <-r.Context().Done():
+			return r.Context().Err()
+		case <-timer.C:
+		}
+	}
+	return l.limiter.Wait(r.Context())
+}
+
+// slowDown halves the limit and pauses the requests until the time requested by the API
+func (l *apiRateLimiter) slowDown(resp *http.Response) rate.Limit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit := l.limiter.Limit()
+	if limit == rate.Inf {
+		limit = adaptiveInitialLimit
+		l.limiter.SetBurst(1)
+	} else {
+		limit = max(limit/2, adaptiveMinLimit)
+	}
+	l.limiter.SetLimit(limit)
+
+	if next, err := time.Parse(time.RFC3339Nano, resp.Header.Get(rateLimitNextHeader)); err == nil && next.After(l.pausedUntil) {
+		l.pausedUntil = next
+	}
+	return limit
+}
+
+// speedUp increases the limit towards the configured one, the limit is removed
+// if the API family has no configured limit and the initial adaptive limit is reached
+func (l *apiRateLimiter) speedUp() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit := l.limiter.Limit()
+	if limit == l.ceiling {
+		return
+	}
+	limit += adaptiveIncrease
+	if l.ceiling == rate.Inf && limit >= adaptiveInitialLimit {
+		limit = rate.Inf
+	}
+	l.limiter.SetLimit(min(limit, l.ceiling))
+}
+
+// isThrottled checks whether the API rejected the request or reported that no more requests are allowed in the current window
+func isThrottled(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get(rateLimitRemainingHeader))
+	return err == nil && remaining <= 0
+}
@@ -0,0 +1,167 @@
+package akamai
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestNewRateLimitTransport(t *testing.T) {
+	tests := map[string]struct {
+		limits    []rateLimit
+		withError bool
+	}{
+		"valid limits": {
+			limits: []rateLimit{{api: "papi", requestsPerSecond: 5}, {api: "appsec", requestsPerSecond: 0.5, burst: 2}},
+		},
+		"missing api": {
+			limits:    []rateLimit{{requestsPerSecond: 5}},
+			withError: true,
+		},
+		"non-positive requests per second": {
+			limits:    []rateLimit{{api: "papi"}},
+			withError: true,
+		},
+		"duplicated api": {
+			limits:    []rateLimit{{api: "papi", requestsPerSecond: 5}, {api: "papi", requestsPerSecond: 1}},
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newRateLimitTransport(http.DefaultTransport, test.limits, false, logger.Get("test"))
+			if test.withError {
+				assert.ErrorIs(t, err, ErrInvalidRateLimit)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	var throttle atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if throttle.Load() {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	roundTrip := func(t *testing.T, transport http.RoundTripper, path string) {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		require.NoError(t, err)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	t.Run("requests are limited per API family", func(t *testing.T) {
+		transport, err := newRateLimitTransport(http.DefaultTransport, []rateLimit{{api: "papi", requestsPerSecond: 10, burst: 1}}, false, logger.Get("test"))
+		require.NoError(t, err)
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			roundTrip(t, transport, "/papi/v1/properties")
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+		start = time.Now()
+		for i := 0; i < 3; i++ {
+			roundTrip(t, transport, "/appsec/v1/configs")
+		}
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+		assert.Nil(t, transport.limiter("appsec"))
+	})
+
+	t.Run("adaptive mode slows down throttled API and recovers", func(t *testing.T) {
+		transport, err := newRateLimitTransport(http.DefaultTransport, []rateLimit{{api: "papi", requestsPerSecond: 100}}, true, logger.Get("test"))
+		require.NoError(t, err)
+
+		throttle.Store(true)
+		roundTrip(t, transport, "/papi/v1/properties")
+		roundTrip(t, transport, "/config-dns/v2/zones")
+		throttle.Store(false)
+
+		assert.Equal(t, rate.Limit(50), transport.limiter("papi").limiter.Limit())
+		assert.Equal(t, adaptiveInitialLimit, transport.limiter("config-dns").limiter.Limit())
+
+		roundTrip(t, transport, "/config-dns/v2/zones")
+		assert.Equal(t, rate.Inf, transport.limiter("config-dns").limiter.Limit())
+	})
+}
+
+func TestAPIRateLimiter(t *testing.T) {
+	newLimiter := func(limit rate.Limit) *apiRateLimiter {
+		return &apiRateLimiter{ceiling: limit, limiter: rate.NewLimiter(limit, 1)}
+	}
+	throttled := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+
+	t.Run("limit is halved down to the minimum", func(t *testing.T) {
+		l := newLimiter(1)
+		assert.Equal(t, rate.Limit(0.5), l.slowDown(throttled))
+		for i := 0; i < 10; i++ {
+			l.slowDown(throttled)
+		}
+		assert.Equal(t, adaptiveMinLimit, l.limiter.Limit())
+	})
+
+	t.Run("limit recovers up to the configured one", func(t *testing.T) {
+		l := newLimiter(1)
+		l.slowDown(throttled)
+		for i := 0; i < 10; i++ {
+			l.speedUp()
+		}
+		assert.Equal(t, rate.Limit(1), l.limiter.Limit())
+	})
+
+	t.Run("limit is removed when not configured", func(t *testing.T) {
+		l := newLimiter(rate.Inf)
+		assert.Equal(t, adaptiveInitialLimit, l.slowDown(throttled))
+		l.speedUp()
+		assert.Equal(t, rate.Inf, l.limiter.Limit())
+	})
+
+	t.Run("requests are paused until the time from rate-limit header", func(t *testing.T) {
+		l := newLimiter(rate.Inf)
+		next := time.Now().Add(100 * time.Millisecond).UTC()
+		l.slowDown(&http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Akamai-Ratelimit-Next": []string{next.Format(time.RFC3339Nano)}},
+		})
+
+		req, err := http.NewRequest(http.MethodGet, "https://host.com/papi/v1/properties", nil)
+		require.NoError(t, err)
+		require.NoError(t, l.wait(req))
+		assert.False(t, time.Now().Before(next))
+	})
+}
+
+func TestIsThrottled(t *testing.T) {
+	tests := map[string]struct {
+		status   int
+		headers  http.Header
+		expected bool
+	}{
+		"429":                   {status: http.StatusTooManyRequests, expected: true},
+		"no remaining requests": {status: http.StatusOK, headers: http.Header{"Akamai-Ratelimit-Remaining": []string{"0"}}, expected: true},
+		"remaining requests":    {status: http.StatusOK, headers: http.Header{"Akamai-Ratelimit-Remaining": []string{"10"}}},
+		"no headers":            {status: http.StatusOK},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, isThrottled(&http.Response{StatusCode: test.status, Header: test.headers}))
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/retryablehttp"
+)
+
+const (
+	// retryAfterHeader is the standard header with the time after which the request can be retried
+	retryAfterHeader = "Retry-After"
+	// rateLimitNextHeader is the Akamai header with the time when the next request will be accepted by the API
+	rateLimitNextHeader = "Akamai-RateLimit-Next"
+)
+
+// ErrUnsupportedRetryMethod is returned when the retry policy is configured with an unknown HTTP method
+var ErrUnsupportedRetryMethod = errors.New("unsupported retry method")
+
+var (
+	// defaultRetryMethods are the read-only methods, which are safe to be retried after a transient failure.
+	// Writes, even the idempotent ones, are retried after transient failures only when listed in 'retry_methods'
+	defaultRetryMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions}
+
+	// now is used by the retry backoff to determine the time left until the time given in the rate-limit headers
+	now = time.Now
+)
+
+type (
+	// retryPolicy decides which failed requests are retried and how long to wait before the next attempt.
+	//
+	// Requests rejected with 429 Too Many Requests are retried regardless of their method, as they were not processed by the API.
+	// Other transient failures (connection errors and 5xx responses) are retried only for the methods from the allow-list.
+	// Read requests are retried up to maxRetries times, while the write requests have a separate budget of maxWriteRetries.
+	retryPolicy struct {
+		methods         map[string]struct{}
+		maxRetries      int
+		maxWriteRetries int
+	}
+
+	// retryAttemptsTransport binds the counter of retries to every request executed by the retryablehttp client
+	retryAttemptsTransport struct {
+		next http.RoundTripper
+	}
+
+	retryAttemptsContextKey struct{}
+)
+
+// newRetryPolicy returns the retry policy for the given allow-list of methods,
+// falling back to the idempotent methods if the list is empty
+func newRetryPolicy(methods []string, maxRetries, maxWriteRetries int) (*retryPolicy, error) {
+	if len(methods) == 0 {
+		methods = defaultRetryMethods
+	}
+
+	policy := &retryPolicy{
+		methods:         make(map[string]struct{}, len(methods)),
+		maxRetries:      maxRetries,
+		maxWriteRetries: maxWriteRetries,
+	}
+	for _, method := range methods {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodPost, http.MethodPatch:
+			policy.methods[method] = struct{}{}
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnsupportedRetryMethod, method)
+		}
+	}
+
+	return policy, nil
+}
+
+// retryMax returns the maximum number of retries of any request, which has to be set in the retryablehttp client
+func (p *retryPolicy) retryMax() int {
+	return max(p.maxRetries, p.maxWriteRetries)
+}
+
+// CheckRetry implements retryablehttp.CheckRetry
+func (p *retryPolicy) CheckRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	method := requestMethod(resp, err)
+	if !p.shouldRetry(ctx, method, resp, err) {
+		return false, nil
+	}
+
+	budget := p.maxRetries
+	if !isReadOnlyMethod(method) {
+		budget = p.maxWriteRetries
+	}
+	if attempts, ok := ctx.Value(retryAttemptsContextKey{}).(*int); ok {
+		if *attempts >= budget {
+			return false, nil
+		}
+		*attempts++
+	}
+
+	return true, nil
+}
+
+func (p *retryPolicy) shouldRetry(ctx context.Context, method string, resp *http.Response, err error) bool {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if _, ok := p.methods[method]; !ok {
+		return false
+	}
+	if resp != nil && resp.StatusCode == http.StatusConflict {
+		return method == http.MethodGet
+	}
+	shouldRetry, _ := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	return shouldRetry
+}
+
+// Backoff implements retryablehttp.Backoff. It waits for the time requested by the API in
+// Retry-After or Akamai-RateLimit-Next headers, limited to max, and falls back to exponential backoff otherwise.
+func (p *retryPolicy) Backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(resp.Header.Get(retryAfterHeader)); ok {
+			return limitWait(wait, max)
+		}
+		if next, err := time.Parse(time.RFC3339Nano, resp.Header.Get(rateLimitNextHeader)); err == nil {
+			return limitWait(untilOrZero(next), max)
+		}
+	}
+	return retryablehttp.DefaultBackoff(min, max, attemptNum, resp)
+}
+
+// RoundTrip executes the request with a fresh counter of retries
+func (t *retryAttemptsTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return t.next.RoundTrip(r.WithContext(context.WithValue(r.Context(), retryAttemptsContextKey{}, new(int))))
+}
+
+// parseRetryAfter parses the value of Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return untilOrZero(date), true
+	}
+	return 0, false
+}
+
+// limitWait returns the wait requested by the API, which can be no longer than max
+func limitWait(wait, max time.Duration) time.Duration {
+	if wait > max {
+		return max
+	}
+	return wait
+}
+
+func untilOrZero(t time.Time) time.Duration {
+	if wait := t.Sub(now()); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// requestMethod returns the method of the failed request from either its response or the returned error
+func requestMethod(resp *http.Response, err error) string {
+	if resp != nil && resp.Request != nil {
+		return resp.Request.Method
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return strings.ToUpper(urlErr.Op)
+	}
+	return ""
+}
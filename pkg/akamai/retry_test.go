@@ -0,0 +1,230 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/retryablehttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRetryPolicy(t *testing.T) {
+	t.Run("read-only methods by default", func(t *testing.T) {
+		policy, err := newRetryPolicy(nil, 10, 3)
+		require.NoError(t, err)
+		assert.Len(t, policy.methods, len(defaultRetryMethods))
+		assert.Contains(t, policy.methods, http.MethodGet)
+		assert.NotContains(t, policy.methods, http.MethodPut)
+		assert.NotContains(t, policy.methods, http.MethodDelete)
+		assert.NotContains(t, policy.methods, http.MethodPost)
+		assert.Equal(t, 10, policy.retryMax())
+	})
+
+	t.Run("methods are case insensitive", func(t *testing.T) {
+		policy, err := newRetryPolicy([]string{"get", " Post"}, 1, 5)
+		require.NoError(t, err)
+		assert.Len(t, policy.methods, 2)
+		assert.Contains(t, policy.methods, http.MethodPost)
+		assert.Equal(t, 5, policy.retryMax())
+	})
+
+	t.Run("unsupported method", func(t *testing.T) {
+		_, err := newRetryPolicy([]string{"GET", "CONNECT"}, 1, 1)
+		assert.ErrorIs(t, err, ErrUnsupportedRetryMethod)
+	})
+}
+
+func TestRetryPolicyCheckRetry(t *testing.T) {
+	policy, err := newRetryPolicy([]string{http.MethodGet, http.MethodPut, http.MethodDelete}, 10, 3)
+	require.NoError(t, err)
+
+	response := func(method string, status int) *http.Response {
+		return &http.Response{StatusCode: status, Request: &http.Request{Method: method}}
+	}
+
+	tests := map[string]struct {
+		resp          *http.Response
+		err           error
+		expectRetried bool
+	}{
+		"GET 500 is retried": {
+			resp:          response(http.MethodGet, http.StatusInternalServerError),
+			expectRetried: true,
+		},
+		"GET 409 is retried": {
+			resp:          response(http.MethodGet, http.StatusConflict),
+			expectRetried: true,
+		},
+		"GET 404 is not retried": {
+			resp: response(http.MethodGet, http.StatusNotFound),
+		},
+		"PUT 503 is retried": {
+			resp:          response(http.MethodPut, http.StatusServiceUnavailable),
+			expectRetried: true,
+		},
+		"PUT 409 is not retried": {
+			resp: response(http.MethodPut, http.StatusConflict),
+		},
+		"DELETE connection error is retried": {
+			err:           &url.Error{Op: "Delete", URL: "https://host.com", Err: errors.New("connection reset")},
+			expectRetried: true,
+		},
+		"POST 500 is not retried": {
+			resp: response(http.MethodPost, http.StatusInternalServerError),
+		},
+		"POST connection error is not retried": {
+			err: &url.Error{Op: "Post", URL: "https://host.com", Err: errors.New("connection reset")},
+		},
+		"POST 429 is retried": {
+			resp:          response(http.MethodPost, http.StatusTooManyRequests),
+			expectRetried: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			retried, err := policy.CheckRetry(context.Background(), test.resp, test.err)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectRetried, retried)
+		})
+	}
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		retried, err := policy.CheckRetry(ctx, response(http.MethodGet, http.StatusInternalServerError), nil)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, retried)
+	})
+
+	t.Run("separate budgets of reads and writes", func(t *testing.T) {
+		retries := func(method string) int {
+			ctx := context.WithValue(context.Background(), retryAttemptsContextKey{}, new(int))
+			var count int
+			for {
+				retried, err := policy.CheckRetry(ctx, response(method, http.StatusTooManyRequests), nil)
+				require.NoError(t, err)
+				if !retried {
+					return count
+				}
+				count++
+			}
+		}
+		assert.Equal(t, 10, retries(http.MethodGet))
+		assert.Equal(t, 3, retries(http.MethodPut))
+	})
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	current := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	policy, err := newRetryPolicy(nil, 10, 3)
+	require.NoError(t, err)
+
+	response := func(status int, headers map[string]string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		for k, v := range headers {
+			resp.Header.Set(k, v)
+		}
+		return resp
+	}
+
+	tests := map[string]struct {
+		resp         *http.Response
+		attempt      int
+		expectedWait time.Duration
+	}{
+		"exponential backoff without response": {
+			attempt:      2,
+			expectedWait: 4 * time.Second,
+		},
+		"exponential backoff limited by max wait": {
+			resp:         response(http.StatusInternalServerError, nil),
+			attempt:      10,
+			expectedWait: 30 * time.Second,
+		},
+		"Retry-After in seconds": {
+			resp:         response(http.StatusTooManyRequests, map[string]string{"Retry-After": "7"}),
+			expectedWait: 7 * time.Second,
+		},
+		"Retry-After as HTTP date": {
+			resp:         response(http.StatusServiceUnavailable, map[string]string{"Retry-After": current.Add(12 * time.Second).Format(http.TimeFormat)}),
+			expectedWait: 12 * time.Second,
+		},
+		"Akamai-RateLimit-Next": {
+			resp:         response(http.StatusTooManyRequests, map[string]string{"Akamai-RateLimit-Next": "2024-04-01T10:00:02.500Z"}),
+			expectedWait: 2500 * time.Millisecond,
+		},
+		"Akamai-RateLimit-Next in the past": {
+			resp:         response(http.StatusTooManyRequests, map[string]string{"Akamai-RateLimit-Next": "2024-04-01T09:59:00Z"}),
+			expectedWait: 0,
+		},
+		"Retry-After limited by max wait": {
+			resp:         response(http.StatusTooManyRequests, map[string]string{"Retry-After": "86400"}),
+			expectedWait: 30 * time.Second,
+		},
+		"Akamai-RateLimit-Next limited by max wait": {
+			resp:         response(http.StatusTooManyRequests, map[string]string{"Akamai-RateLimit-Next": "2024-04-02T10:00:00Z"}),
+			expectedWait: 30 * time.Second,
+		},
+		"invalid headers fall back to exponential backoff": {
+			resp:         response(http.StatusTooManyRequests, map[string]string{"Retry-After": "soon", "Akamai-RateLimit-Next": "later"}),
+			attempt:      1,
+			expectedWait: 2 * time.Second,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedWait, policy.Backoff(time.Second, 30*time.Second, test.attempt, test.resp))
+		})
+	}
+}
+
+func TestRetryPolicyWithClient(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	policy, err := newRetryPolicy(nil, 10, 1)
+	require.NoError(t, err)
+	retryClient := retryablehttp.NewClient()
+	retryClient.RetryMax = policy.retryMax()
+	retryClient.CheckRetry = policy.CheckRetry
+	retryClient.Backoff = policy.Backoff
+	client := &http.Client{Transport: &retryAttemptsTransport{next: retryClient.StandardClient().Transport}}
+
+	req, err := http.NewRequest(http.MethodPut, srv.URL, strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+
+	calls.Store(0)
+	req, err = http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
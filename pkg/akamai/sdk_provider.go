@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
@@ -66,20 +67,170 @@ func NewSDKProvider(subprovs ...subprovider.Subprovider) plugin.ProviderFunc {
 					},
 				},
 			},
+			"credentials_profile": {
+				Description: "Named EdgeGrid credentials which can be selected with the `credentials_profile` argument of resources and data sources",
+				Optional:    true,
+				Type:        schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The name of the credentials profile",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"edgerc": {
+							Description: "The path to the edgerc file, defaults to the provider's edgerc",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"config_section": {
+							Description: "The section of the edgerc file to use, defaults to the name of the profile",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"access_token": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"client_token": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"client_secret": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"max_body": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"account_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"account_switch_key": {
+				Optional: true,
+				Type:     schema.TypeString,
+				Description: "The account switch key applied to every API request. It overrides `account_key` of the default credentials, " +
+					"but not the one of a credentials profile, and is overridden by `account_switch_key` of a resource",
+			},
 			"cache_enabled": {
 				Optional: true,
 				Type:     schema.TypeBool,
 			},
+			"cache_backend": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The storage of the cache: 'memory' (default) or 'file', which persists the cache between runs",
+			},
+			"cache_dir": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The directory of the 'file' cache backend, default is the user cache directory",
+			},
+			"cache_ttl": {
+				Optional:    true,
+				Type:        schema.TypeInt,
+				Description: "The time in seconds after which the cache entries expire, default is 600 sec",
+			},
 			"request_limit": {
 				Optional:    true,
 				Type:        schema.TypeInt,
 				Description: "The maximum number of API requests to be made per second (0 for no limit)",
 			},
+			"rate_limit": {
+				Description: "The client-side limit of requests per second made to an API family",
+				Optional:    true,
+				Type:        schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api": {
+							Description: "The API family, which is the first segment of the API path, e.g. 'papi', 'appsec', 'config-dns' or 'config-gtm'",
+							Required:    true,
+							Type:        schema.TypeString,
+						},
+						"requests_per_second": {
+							Description: "The maximum number of requests per second made to the API family",
+							Required:    true,
+							Type:        schema.TypeFloat,
+						},
+						"burst": {
+							Description: "The maximum number of requests made at once, defaults to the requests per second rounded up",
+							Optional:    true,
+							Type:        schema.TypeInt,
+						},
+					},
+				},
+			},
+			"rate_limit_adaptive": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Description: "Should the rate of requests to an API family be reduced when the API throttles the requests, default false",
+			},
+			"http_cassette_dir": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The directory of sanitized HTTP interactions recorded or replayed according to `http_cassette_mode`",
+			},
+			"http_cassette_mode": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "Either 'record', which records the API traffic into `http_cassette_dir`, or 'replay', which serves the recorded responses without network access",
+			},
+			"log_format": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The format of the provider logs, either 'text' or 'json', which writes every entry as a single JSON object, default 'text'",
+			},
+			"log_file": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The file the provider logs are appended to instead of the Terraform log",
+			},
+			"metrics_summary_file": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The file the JSON summary of API requests made per API family and resource type is written to when the provider shuts down. Every provider process writes to its own file with the process ID inserted before the extension, e.g. `summary.1234.json`",
+			},
+			"tracing_exporter": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The tracing exporter of provider operations, either 'otlp', which exports the spans to `tracing_endpoint`, or 'file', which writes them to `tracing_file`. Tracing is disabled by default",
+			},
+			"tracing_endpoint": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The URL of the OTLP/HTTP endpoint the spans are exported to, defaults to `OTEL_EXPORTER_OTLP_ENDPOINT` environment variable or http://localhost:4318",
+			},
+			"tracing_file": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The file the spans are written to as JSON by the 'file' tracing exporter",
+			},
 			"retry_max": {
 				Optional:    true,
 				Type:        schema.TypeInt,
 				Description: "The maximum number retires of API requests, default 10",
 			},
+			"retry_write_max": {
+				Optional:    true,
+				Type:        schema.TypeInt,
+				Description: "The maximum number retries of API requests modifying objects, default 3",
+			},
+			"retry_methods": {
+				Optional:    true,
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The HTTP methods of API requests retried after transient failures, default GET, HEAD and OPTIONS. Requests rejected with 429 status are retried regardless of their method",
+			},
 			"retry_wait_min": {
 				Optional:    true,
 				Type:        schema.TypeInt,
@@ -110,6 +261,9 @@ func NewSDKProvider(subprovs ...subprovider.Subprovider) plugin.ProviderFunc {
 		}
 	}
 
+	addMetaArguments(prov.ResourcesMap)
+	addMetaArguments(prov.DataSourcesMap)
+
 	prov.ConfigureContextFunc = configureProviderContext(prov)
 
 	return func() *schema.Provider {
@@ -169,16 +323,140 @@ func configureProviderContext(p *schema.Provider) schema.ConfigureContextFunc {
 			return nil, diag.FromErr(err)
 		}
 
+		profilesSet, err := tf.GetSetValue("credentials_profile", d)
+		if err != nil && !errors.Is(err, tf.ErrNotFound) {
+			return nil, diag.FromErr(err)
+		}
+
+		var profiles []credentialsProfile
+		for _, p := range profilesSet.List() {
+			profileMap, ok := p.(map[string]any)
+			if !ok {
+				return nil, diag.FromErr(fmt.Errorf("%w: %s, %q", tf.ErrInvalidType, "credentials_profile", "map[string]any"))
+			}
+			profiles = append(profiles, credentialsProfile{
+				name:       profileMap["name"].(string),
+				edgercPath: profileMap["edgerc"].(string),
+				section:    profileMap["config_section"].(string),
+				config: configBearer{
+					accessToken:  profileMap["access_token"].(string),
+					accountKey:   profileMap["account_key"].(string),
+					clientSecret: profileMap["client_secret"].(string),
+					clientToken:  profileMap["client_token"].(string),
+					host:         profileMap["host"].(string),
+					maxBody:      profileMap["max_body"].(int),
+				},
+			})
+		}
+
+		profileConfigs, err := newProfilesEdgegridConfigs(edgercPath, profiles)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		accountKey, err := getPluginConfigString(d, "account_switch_key", "AKAMAI_ACCOUNT_SWITCH_KEY")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		cacheBackend, err := getPluginConfigString(d, "cache_backend", "AKAMAI_CACHE_BACKEND")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		cacheDir, err := getPluginConfigString(d, "cache_dir", "AKAMAI_CACHE_DIR")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		cacheTTL, err := getPluginConfigInt(d, "cache_ttl", "AKAMAI_CACHE_TTL")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
 		requestLimit, err := getPluginConfigInt(d, "request_limit", "AKAMAI_REQUEST_LIMIT")
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
 
+		rateLimitsSet, err := tf.GetSetValue("rate_limit", d)
+		if err != nil && !errors.Is(err, tf.ErrNotFound) {
+			return nil, diag.FromErr(err)
+		}
+
+		var rateLimits []rateLimit
+		for _, l := range rateLimitsSet.List() {
+			rateLimitMap, ok := l.(map[string]any)
+			if !ok {
+				return nil, diag.FromErr(fmt.Errorf("%w: %s, %q", tf.ErrInvalidType, "rate_limit", "map[string]any"))
+			}
+			rateLimits = append(rateLimits, rateLimit{
+				api:               rateLimitMap["api"].(string),
+				requestsPerSecond: rateLimitMap["requests_per_second"].(float64),
+				burst:             rateLimitMap["burst"].(int),
+			})
+		}
+
+		rateLimitAdaptive, err := getPluginConfigBool(d, "rate_limit_adaptive", "AKAMAI_RATE_LIMIT_ADAPTIVE")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		cassetteDir, err := getPluginConfigString(d, "http_cassette_dir", "AKAMAI_HTTP_CASSETTE_DIR")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		cassetteMode, err := getPluginConfigString(d, "http_cassette_mode", "AKAMAI_HTTP_CASSETTE_MODE")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		logFormat, err := getPluginConfigString(d, "log_format", "AKAMAI_LOG_FORMAT")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		logFile, err := getPluginConfigString(d, "log_file", "AKAMAI_LOG_FILE")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		metricsSummaryFile, err := getPluginConfigString(d, "metrics_summary_file", "AKAMAI_METRICS_SUMMARY_FILE")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		tracingExporter, err := getPluginConfigString(d, "tracing_exporter", "AKAMAI_TRACING_EXPORTER")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		tracingEndpoint, err := getPluginConfigString(d, "tracing_endpoint", "AKAMAI_TRACING_ENDPOINT")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		tracingFile, err := getPluginConfigString(d, "tracing_file", "AKAMAI_TRACING_FILE")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
 		retryMax, err := getPluginConfigInt(d, "retry_max", "AKAMAI_RETRY_MAX")
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
 
+		retryWriteMax, err := getPluginConfigInt(d, "retry_write_max", "AKAMAI_RETRY_WRITE_MAX")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		retryMethods, err := getPluginConfigStringList(d, "retry_methods", "AKAMAI_RETRY_METHODS")
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
 		retryWaitMin, err := getPluginConfigInt(d, "retry_wait_min", "AKAMAI_RETRY_WAIT_MIN")
 		if err != nil {
 			return nil, diag.FromErr(err)
@@ -195,15 +473,32 @@ func configureProviderContext(p *schema.Provider) schema.ConfigureContextFunc {
 		}
 
 		meta, err := configureContext(contextConfig{
-			edgegridConfig: edgegridConfig,
-			userAgent:      userAgent(p.TerraformVersion),
-			ctx:            ctx,
-			requestLimit:   requestLimit,
-			enableCache:    cacheEnabled,
-			retryMax:       retryMax,
-			retryWaitMin:   time.Duration(retryWaitMin) * time.Second,
-			retryWaitMax:   time.Duration(retryWaitMax) * time.Second,
-			retryDisabled:  retryDisabled,
+			edgegridConfig:     edgegridConfig,
+			profiles:           profileConfigs,
+			accountKey:         accountKey,
+			userAgent:          userAgent(p.TerraformVersion),
+			ctx:                ctx,
+			requestLimit:       requestLimit,
+			rateLimits:         rateLimits,
+			rateLimitAdaptive:  rateLimitAdaptive,
+			cassetteDir:        cassetteDir,
+			cassetteMode:       cassetteMode,
+			logFormat:          logFormat,
+			logFile:            logFile,
+			metricsSummaryFile: metricsSummaryFile,
+			tracingExporter:    tracingExporter,
+			tracingEndpoint:    tracingEndpoint,
+			tracingFile:        tracingFile,
+			enableCache:        cacheEnabled,
+			cacheBackend:       cacheBackend,
+			cacheDir:           cacheDir,
+			cacheTTL:           time.Duration(cacheTTL) * time.Second,
+			retryMax:           retryMax,
+			retryWriteMax:      retryWriteMax,
+			retryMethods:       retryMethods,
+			retryWaitMin:       time.Duration(retryWaitMin) * time.Second,
+			retryWaitMax:       time.Duration(retryWaitMax) * time.Second,
+			retryDisabled:      retryDisabled,
 		})
 		if err != nil {
 			return nil, diag.FromErr(err)
@@ -224,6 +519,30 @@ func NewProtoV6SDKProvider(subproviders []subprovider.Subprovider) (func() tfpro
 	}, err
 }
 
+func getPluginConfigString(d *schema.ResourceData, key string, envKey string) (string, error) {
+	value, err := tf.GetStringValue(key, d)
+	if err != nil {
+		if !errors.Is(err, tf.ErrNotFound) {
+			return "", err
+		}
+		value = os.Getenv(envKey)
+	}
+	return value, nil
+}
+
+func getPluginConfigStringList(d *schema.ResourceData, key string, envKey string) ([]string, error) {
+	value, err := tf.GetTypedListValue[string](key, d)
+	if err != nil {
+		if !errors.Is(err, tf.ErrNotFound) {
+			return nil, err
+		}
+		if v := os.Getenv(envKey); v != "" {
+			value = strings.Split(v, ",")
+		}
+	}
+	return value, nil
+}
+
 func getPluginConfigInt(d *schema.ResourceData, key string, envKey string) (int, error) {
 	value, err := tf.GetIntValue(key, d)
 	if err != nil {
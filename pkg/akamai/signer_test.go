@@ -0,0 +1,84 @@
+package akamai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountSwitchKeySigner(t *testing.T) {
+	config := edgegrid.Config{
+		Host:         "host.com",
+		ClientToken:  "client_token",
+		ClientSecret: "client_secret",
+		AccessToken:  "access_token",
+		MaxBody:      edgegrid.MaxBodySize,
+	}
+
+	tests := map[string]struct {
+		configKey     string
+		contextKey    string
+		query         string
+		signTimes     int
+		expectedKey   []string
+		expectedQuery string
+	}{
+		"no account switch key": {
+			signTimes: 1,
+		},
+		"key from config": {
+			configKey:   "config-key",
+			signTimes:   1,
+			expectedKey: []string{"config-key"},
+		},
+		"key from context overrides config": {
+			configKey:   "config-key",
+			contextKey:  "context-key",
+			signTimes:   1,
+			expectedKey: []string{"context-key"},
+		},
+		"key is not duplicated when request is signed again": {
+			configKey:   "config-key",
+			signTimes:   3,
+			expectedKey: []string{"config-key"},
+		},
+		"rest of the query is kept unchanged": {
+			configKey:     "config-key",
+			query:         "contractId=1&groupId=grp%2F2&accountSwitchKey=old-key&acceptHeader",
+			signTimes:     2,
+			expectedKey:   []string{"config-key"},
+			expectedQuery: "contractId=1&groupId=grp%2F2&acceptHeader&accountSwitchKey=config-key",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := config
+			cfg.AccountKey = test.configKey
+			signer := newAccountSwitchKeySigner(cfg)
+
+			ctx := contextWithAccountSwitchKey(context.Background(), test.contextKey)
+			query := test.query
+			if query == "" {
+				query = "contractId=1"
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://host.com/papi/v1/groups?"+query, nil)
+			require.NoError(t, err)
+
+			for i := 0; i < test.signTimes; i++ {
+				signer.SignRequest(req)
+			}
+
+			assert.Equal(t, test.expectedKey, req.URL.Query()[accountSwitchKeyParam])
+			assert.Equal(t, "1", req.URL.Query().Get("contractId"))
+			if test.expectedQuery != "" {
+				assert.Equal(t, test.expectedQuery, req.URL.RawQuery)
+			}
+			assert.Contains(t, req.Header.Get("Authorization"), "client_token=client_token")
+		})
+	}
+}
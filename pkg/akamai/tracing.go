@@ -0,0 +1,100 @@
+package akamai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	resourceTypeKey = attribute.Key("akamai.resource_type")
+	resourceIDKey   = attribute.Key("akamai.resource_id")
+	apiKey          = attribute.Key("akamai.api")
+	httpMethodKey   = attribute.Key("http.request.method")
+	httpStatusKey   = attribute.Key("http.response.status_code")
+	urlPathKey      = attribute.Key("url.path")
+)
+
+// tracingTransport starts a span for every API request attempt, which is a child of the span from the request context
+type tracingTransport struct {
+	next        http.RoundTripper
+	operationID string
+}
+
+// withTracing wraps the CRUD function of the resource with a span named after the resource type and the operation,
+// e.g. 'akamai_property.Create'
+func withTracing[F crudFunc](name, operation string, f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		operationID := meta.Must(m).OperationID()
+		ctx = tracing.ContextWithOperationID(ctx, operationID)
+		ctx, span := tracing.StartSpan(ctx, name+"."+operation, resourceTypeKey.String(name), resourceIDKey.String(d.Id()))
+		defer span.End()
+
+		diags := f(ctx, d, m)
+		span.SetAttributes(resourceIDKey.String(d.Id()))
+		for _, diagnostic := range diags {
+			if diagnostic.Severity == diag.Error {
+				tracing.RecordError(span, errors.New(diagnostic.Summary))
+			}
+		}
+		return diags
+	}
+}
+
+// startFrameworkSpan starts the span of the operation of the framework resource or data source, named like in withTracing,
+// e.g. 'akamai_iam_user.Create'. The OperationID is taken from the meta the provider was configured with
+func startFrameworkSpan(ctx context.Context, name, operation string, raw tftypes.Value, providerData any) (context.Context, trace.Span) {
+	if m, ok := providerData.(meta.Meta); ok {
+		ctx = tracing.ContextWithOperationID(ctx, m.OperationID())
+	}
+	return tracing.StartSpan(ctx, name+"."+operation, resourceTypeKey.String(name), resourceIDKey.String(rawID(raw)))
+}
+
+// endFrameworkSpan ends the span started with startFrameworkSpan, recording the ID from the resulting state and the errors
+func endFrameworkSpan(span trace.Span, raw tftypes.Value, diags fwdiag.Diagnostics) {
+	if id := rawID(raw); id != "" {
+		span.SetAttributes(resourceIDKey.String(id))
+	}
+	for _, diagnostic := range diags.Errors() {
+		tracing.RecordError(span, errors.New(diagnostic.Summary()))
+	}
+	span.End()
+}
+
+// RoundTrip executes the request within the span
+func (t *tracingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx, span := tracing.StartSpan(r.Context(), fmt.Sprintf("%s %s", r.Method, apiFamily(r.URL.Path)),
+		tracing.OperationIDKey.String(t.operationID),
+		apiKey.String(apiFamily(r.URL.Path)),
+		httpMethodKey.String(r.Method),
+		urlPathKey.String(r.URL.Path),
+	)
+	defer span.End()
+	if !span.IsRecording() {
+		return t.next.RoundTrip(r)
+	}
+
+	resp, err := t.next.RoundTrip(r.WithContext(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return resp, err
+	}
+	span.SetAttributes(httpStatusKey.Int(resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		tracing.RecordError(span, errors.New(resp.Status))
+	}
+	return resp, nil
+}
@@ -0,0 +1,164 @@
+package akamai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+	return recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range span.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
+
+func TestTracing(t *testing.T) {
+	recorder := recordSpans(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "opID")
+	require.NoError(t, err)
+	transport := &tracingTransport{next: http.DefaultTransport, operationID: "opID"}
+
+	res := &schema.Resource{
+		Schema: map[string]*schema.Schema{"name": {Type: schema.TypeString, Optional: true}},
+	}
+	create := withTracing("akamai_property", "Create", func(ctx context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/papi/v1/properties", nil)
+		require.NoError(t, err)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		d.SetId("prp_1")
+		return diag.Errorf("creation failed")
+	})
+
+	diags := create(context.Background(), schema.TestResourceDataRaw(t, res.Schema, map[string]any{}), m)
+	require.True(t, diags.HasError())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	httpSpan, crudSpan := spans[0], spans[1]
+
+	assert.Equal(t, "akamai_property.Create", crudSpan.Name())
+	assert.Equal(t, codes.Error, crudSpan.Status().Code)
+	crudAttrs := spanAttributes(crudSpan)
+	assert.Equal(t, "opID", crudAttrs["akamai.operation_id"].AsString())
+	assert.Equal(t, "akamai_property", crudAttrs[resourceTypeKey].AsString())
+	assert.Equal(t, "prp_1", crudAttrs[resourceIDKey].AsString())
+
+	assert.Equal(t, "POST papi", httpSpan.Name())
+	assert.Equal(t, crudSpan.SpanContext().SpanID(), httpSpan.Parent().SpanID())
+	assert.Equal(t, codes.Error, httpSpan.Status().Code)
+	httpAttrs := spanAttributes(httpSpan)
+	assert.Equal(t, "opID", httpAttrs["akamai.operation_id"].AsString())
+	assert.Equal(t, "papi", httpAttrs[apiKey].AsString())
+	assert.Equal(t, "/papi/v1/properties", httpAttrs[urlPathKey].AsString())
+	assert.Equal(t, int64(http.StatusBadGateway), httpAttrs[httpStatusKey].AsInt64())
+}
+
+func TestFrameworkTracing(t *testing.T) {
+	ctx := context.Background()
+	recorder := recordSpans(t)
+	m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), "opID")
+	require.NoError(t, err)
+
+	var usedMeta meta.Meta
+	var usedKey any
+	r := withFrameworkResourceMetaArguments([]func() resource.Resource{func() resource.Resource {
+		return &testFrameworkResource{usedMeta: &usedMeta, usedKey: &usedKey}
+	}})[0]()
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: m}, &resource.ConfigureResponse{})
+	d := withFrameworkDataSourceMetaArguments([]func() datasource.DataSource{func() datasource.DataSource {
+		return &testFrameworkDataSource{usedMeta: &usedMeta, usedKey: &usedKey}
+	}})[0]()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: m}, &datasource.ConfigureResponse{})
+
+	var resourceSchema resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resourceSchema)
+	s := resourceSchema.Schema
+	object := func(id any, profile any) tftypes.Value {
+		return tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, id),
+			"name":                tftypes.NewValue(tftypes.String, "a"),
+			credentialsProfileKey: tftypes.NewValue(tftypes.String, profile),
+			accountSwitchKeyKey:   tftypes.NewValue(tftypes.String, nil),
+		})
+	}
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}}
+	r.Create(ctx, resource.CreateRequest{
+		Config: tfsdk.Config{Schema: s, Raw: object(nil, nil)},
+		Plan:   tfsdk.Plan{Schema: s, Raw: object(tftypes.UnknownValue, nil)},
+	}, &createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	deleteResp := resource.DeleteResponse{State: tfsdk.State{Schema: s, Raw: object("1", "unknown")}}
+	r.Delete(ctx, resource.DeleteRequest{State: tfsdk.State{Schema: s, Raw: object("1", "unknown")}}, &deleteResp)
+	require.True(t, deleteResp.Diagnostics.HasError())
+
+	var dataSourceSchema datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &dataSourceSchema)
+	config := tftypes.NewValue(dataSourceSchema.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, nil),
+		"name":                tftypes.NewValue(tftypes.String, "a"),
+		credentialsProfileKey: tftypes.NewValue(tftypes.String, nil),
+		accountSwitchKeyKey:   tftypes.NewValue(tftypes.String, nil),
+	})
+	readResp := datasource.ReadResponse{State: tfsdk.State{Schema: dataSourceSchema.Schema, Raw: config}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: dataSourceSchema.Schema, Raw: config}}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	tests := []struct {
+		name string
+		code codes.Code
+		id   string
+	}{
+		{name: "akamai_test.Create", code: codes.Unset, id: "1"},
+		{name: "akamai_test.Delete", code: codes.Error, id: "1"},
+		{name: "akamai_test.Read", code: codes.Unset, id: "1"},
+	}
+	for i, test := range tests {
+		assert.Equal(t, test.name, spans[i].Name())
+		assert.Equal(t, test.code, spans[i].Status().Code)
+		attrs := spanAttributes(spans[i])
+		assert.Equal(t, "opID", attrs["akamai.operation_id"].AsString())
+		assert.Equal(t, "akamai_test", attrs[resourceTypeKey].AsString())
+		assert.Equal(t, test.id, attrs[resourceIDKey].AsString())
+	}
+}
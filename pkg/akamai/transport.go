@@ -0,0 +1,124 @@
+package akamai
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cassette"
+	"github.com/apex/log"
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+const (
+	// cassetteModeRecord records the API traffic into the cassette directory
+	cassetteModeRecord = "record"
+	// cassetteModeReplay serves the responses recorded in the cassette directory without network access
+	cassetteModeReplay = "replay"
+)
+
+var (
+	// ErrUnsupportedCassetteMode is returned when the configured HTTP cassette mode is not supported
+	ErrUnsupportedCassetteMode = errors.New("unsupported HTTP cassette mode")
+	// ErrMissingCassetteDir is returned when the HTTP cassette mode is configured without the cassette directory
+	ErrMissingCassetteDir = errors.New("HTTP cassette directory is required")
+)
+
+// cacheBucketsByAPI maps the path prefixes of the APIs to the cache buckets holding data read from them
+var cacheBucketsByAPI = map[string][]cache.Bucket{
+	"/appsec/": {cache.BucketName("appsec"), cache.BucketName("botman")},
+	"/papi/":   {cache.BucketName("property")},
+}
+
+// readOnlyPostPaths lists the paths of the APIs with cached data, which are requested with POST, but do not modify anything
+var readOnlyPostPaths = map[string]bool{
+	"/papi/v1/search/find-by-value": true,
+}
+
+type (
+	// cacheFlushTransport flushes the cache buckets of an API after every successful write request made to it,
+	// as a fallback for the modifications which are not followed by cache.Invalidate
+	cacheFlushTransport struct {
+		next http.RoundTripper
+		log  log.Interface
+	}
+)
+
+// newTransport returns the http.RoundTripper used for executing the API requests
+func newTransport(cfg contextConfig, log log.Interface) (http.RoundTripper, error) {
+	var transport http.RoundTripper = cleanhttp.DefaultPooledTransport()
+
+	if cfg.cassetteMode != "" && cfg.cassetteDir == "" {
+		return nil, fmt.Errorf("%w in %q mode", ErrMissingCassetteDir, cfg.cassetteMode)
+	}
+	switch cfg.cassetteMode {
+	case "":
+	case cassetteModeRecord:
+		recorder, err := cassette.NewRecorder(cfg.cassetteDir, transport, log)
+		if err != nil {
+			return nil, err
+		}
+		transport = recorder
+	case cassetteModeReplay:
+		replayer, err := cassette.NewReplayer(cfg.cassetteDir)
+		if err != nil {
+			return nil, err
+		}
+		transport = replayer
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCassetteMode, cfg.cassetteMode)
+	}
+
+	transport = &metricsTransport{next: transport, registry: providerMetrics}
+	transport = &logTransport{next: transport, log: log}
+	transport = &tracingTransport{next: transport, operationID: cfg.operationID}
+
+	if len(cfg.rateLimits) > 0 || cfg.rateLimitAdaptive {
+		rateLimitTransport, err := newRateLimitTransport(transport, cfg.rateLimits, cfg.rateLimitAdaptive, log)
+		if err != nil {
+			return nil, err
+		}
+		transport = rateLimitTransport
+	}
+
+	// the resources invalidate the cache entries they modify, while every other successful write request may still
+	// modify the objects cached by any resource or data source, whichever backend keeps them
+	transport = &cacheFlushTransport{next: transport, log: log}
+
+	return transport, nil
+}
+
+// RoundTrip executes the request and flushes the cache buckets if the request modified API objects.
+// Requests rejected by the API modify nothing, unlike the ones which got no response, as their outcome is unknown
+func (t *cacheFlushTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(r)
+	if isReadOnlyMethod(r.Method) || readOnlyPostPaths[r.URL.Path] || err == nil && resp.StatusCode >= http.StatusBadRequest {
+		return resp, err
+	}
+
+	for prefix, buckets := range cacheBucketsByAPI {
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			continue
+		}
+		for _, bucket := range buckets {
+			if err := cache.Flush(bucket); err != nil && !errors.Is(err, cache.ErrDisabled) {
+				t.log.Warnf("failed to flush cache bucket %s: %s", bucket.Name(), err)
+			}
+		}
+	}
+
+	return resp, err
+}
+
+// apiFamily returns the name of the API the request path belongs to, which is the first segment of the path,
+// e.g. 'papi' for '/papi/v1/properties' or 'config-dns' for '/config-dns/v2/zones'
+func apiFamily(path string) string {
+	api, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return api
+}
+
+func isReadOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -0,0 +1,143 @@
+package akamai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheFlushTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/papi/v1/properties/prp_2/versions/1/rules" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	tests := map[string]struct {
+		method        string
+		path          string
+		expectFlushed bool
+	}{
+		"GET does not flush": {
+			method: http.MethodGet,
+			path:   "/papi/v1/properties",
+		},
+		"PUT flushes the API buckets": {
+			method:        http.MethodPut,
+			path:          "/papi/v1/properties/prp_1/versions/1/rules",
+			expectFlushed: true,
+		},
+		"failed PUT does not flush": {
+			method: http.MethodPut,
+			path:   "/papi/v1/properties/prp_2/versions/1/rules",
+		},
+		"read-only POST does not flush": {
+			method: http.MethodPost,
+			path:   "/papi/v1/search/find-by-value",
+		},
+		"write to other API does not flush": {
+			method: http.MethodPost,
+			path:   "/config-dns/v2/zones",
+		},
+	}
+
+	stores := map[string]func(t *testing.T) (cache.Store, error){
+		"memory": func(*testing.T) (cache.Store, error) {
+			return cache.NewMemoryStore(cache.DefaultTTL)
+		},
+		"file": func(t *testing.T) (cache.Store, error) {
+			return cache.NewFileStore(t.TempDir(), "host.com", cache.DefaultTTL)
+		},
+	}
+
+	for name, test := range tests {
+		for backend, newStore := range stores {
+			t.Run(fmt.Sprintf("%s with %s backend", name, backend), func(t *testing.T) {
+				store, err := newStore(t)
+				require.NoError(t, err)
+				cache.SetStore(store)
+				cache.Enable(true)
+				defer cache.Enable(false)
+				require.NoError(t, cache.Set(context.Background(), cache.BucketName("property"), "key", "value"))
+
+				transport := &cacheFlushTransport{next: http.DefaultTransport, log: logger.Get("test")}
+				req, err := http.NewRequest(test.method, srv.URL+test.path, nil)
+				require.NoError(t, err)
+				resp, err := transport.RoundTrip(req)
+				require.NoError(t, err)
+				require.NoError(t, resp.Body.Close())
+
+				var out string
+				err = cache.Get(context.Background(), cache.BucketName("property"), "key", &out)
+				if test.expectFlushed {
+					assert.ErrorIs(t, err, cache.ErrEntryNotFound)
+				} else {
+					assert.NoError(t, err)
+				}
+			})
+		}
+	}
+}
+
+func TestAPIFamily(t *testing.T) {
+	tests := map[string]string{
+		"/papi/v1/properties":      "papi",
+		"/config-dns/v2/zones":     "config-dns",
+		"appsec/v1/configs":        "appsec",
+		"/":                        "",
+		"/identity-management/v3/": "identity-management",
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			assert.Equal(t, expected, apiFamily(path))
+		})
+	}
+}
+
+func TestNewTransportCassette(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"groups":[]}`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	roundTrip := func(t *testing.T, transport http.RoundTripper) string {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/papi/v1/groups", nil)
+		require.NoError(t, err)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return string(body)
+	}
+
+	dir := t.TempDir()
+	transport, err := newTransport(contextConfig{cassetteMode: cassetteModeRecord, cassetteDir: dir}, logger.Get("test"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"groups":[]}`, roundTrip(t, transport))
+
+	srv.Close()
+	transport, err = newTransport(contextConfig{cassetteMode: cassetteModeReplay, cassetteDir: dir}, logger.Get("test"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"groups":[]}`, roundTrip(t, transport))
+
+	_, err = newTransport(contextConfig{cassetteMode: "stream", cassetteDir: dir}, logger.Get("test"))
+	assert.ErrorIs(t, err, ErrUnsupportedCassetteMode)
+
+	_, err = newTransport(contextConfig{cassetteMode: cassetteModeReplay}, logger.Get("test"))
+	assert.ErrorIs(t, err, ErrMissingCassetteDir)
+}
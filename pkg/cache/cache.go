@@ -2,13 +2,16 @@
 package cache
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"sync"
 	"time"
 
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
-	"github.com/allegro/bigcache/v2"
+	"github.com/apex/log"
 )
 
 var (
@@ -18,13 +21,22 @@ var (
 	ErrEntryNotFound = errors.New("cache entry not found")
 )
 
-var defaultCache = newCache(10 * time.Minute)
+// DefaultTTL is the default time after which cache entries expire
+const DefaultTTL = 10 * time.Minute
 
+var defaultCache = newCache(DefaultTTL)
+
+// cache guards the store and the enabled flag, as the provider may be configured concurrently with the operations
+// of the other provider server. The store is replaced only when no operation is using it.
 type cache struct {
-	cache   *bigcache.BigCache
-	enabled bool
+	mu       sync.RWMutex
+	store    Store
+	storeKey string
+	enabled  bool
 }
 
+type disabledContextKey struct{}
+
 // BucketName can be used as a bucket argument to Set and Get functions
 type BucketName string
 
@@ -39,66 +51,159 @@ type Bucket interface {
 }
 
 func newCache(eviction time.Duration) *cache {
-	c, err := bigcache.NewBigCache(bigcache.DefaultConfig(eviction))
+	s, err := NewMemoryStore(eviction)
 	if err != nil {
 		panic(err)
 	}
 
-	return &cache{cache: c}
+	return &cache{store: s}
 }
 
 // Enable is used to enable or disable cache
 func Enable(enabled bool) {
+	defaultCache.mu.Lock()
+	defer defaultCache.mu.Unlock()
 	defaultCache.enabled = enabled
 }
 
 // IsEnabled returns whether cache is enabled
 func IsEnabled() bool {
+	defaultCache.mu.RLock()
+	defer defaultCache.mu.RUnlock()
 	return defaultCache.enabled
 }
 
+// SetStore replaces the storage backend of the cache, closing the previous one if possible
+func SetStore(store Store) {
+	defaultCache.mu.Lock()
+	defer defaultCache.mu.Unlock()
+	defaultCache.replaceStore(store, "")
+}
+
+// ConfigureStore sets the storage backend returned by newStore, unless the current one was configured with the same key.
+// The key identifies the configuration of the store, so that the store is created once even though the provider
+// is configured by both the SDK and the framework provider servers.
+func ConfigureStore(key string, newStore func() (Store, error)) error {
+	defaultCache.mu.Lock()
+	defer defaultCache.mu.Unlock()
+	if defaultCache.storeKey != "" && defaultCache.storeKey == key {
+		return nil
+	}
+	store, err := newStore()
+	if err != nil {
+		return err
+	}
+	defaultCache.replaceStore(store, key)
+	return nil
+}
+
+// replaceStore sets the store and closes the previous one if possible. It must be called with the lock held,
+// so that the previous store is not used by Get, Set or Flush while it is closed
+func (c *cache) replaceStore(store Store, key string) {
+	if closer, ok := c.store.(io.Closer); ok && c.store != store {
+		if err := closer.Close(); err != nil {
+			logger.Get("cache", "SetStore").Warnf("failed to close cache store: %s", err)
+		}
+	}
+	c.store, c.storeKey = store, key
+}
+
+// ContextWithoutCache returns a context for which Get and Set behave as if the cache was disabled.
+// The cache entries are not keyed by the credentials and account the data was read with, so it has to be used
+// for the operations made with other credentials or account than the default ones of the provider.
+func ContextWithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, disabledContextKey{}, true)
+}
+
+// isEnabled must be called with the lock held
+func isEnabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(disabledContextKey{}).(bool)
+	return defaultCache.enabled && !disabled
+}
+
 // Set sets the given value under the key in cache
-func Set(bucket Bucket, key string, val any) error {
+func Set(ctx context.Context, bucket Bucket, key string, val any) error {
 	log := logger.Get("cache", "CacheSet")
 
-	if !defaultCache.enabled {
+	defaultCache.mu.RLock()
+	defer defaultCache.mu.RUnlock()
+	if !isEnabled(ctx) {
 		log.Debug("cache disabled")
 		return ErrDisabled
 	}
 
-	key = fmt.Sprintf("%s:%s", key, bucket.Name())
-
 	data, err := json.Marshal(val)
 	if err != nil {
 		return fmt.Errorf("failed to marshal object to cache: %w", err)
 	}
 
-	log.Debugf("cache set for for key %s [%d bytes]", key, len(data))
+	log.Debugf("cache set for for key %s:%s [%d bytes]", key, bucket.Name(), len(data))
 
-	return defaultCache.cache.Set(key, data)
+	return defaultCache.store.Set(bucket.Name(), key, data)
 }
 
 // Get returns value stored under the key from cache and writes it into out
-func Get(bucket Bucket, key string, out any) error {
+func Get(ctx context.Context, bucket Bucket, key string, out any) error {
 	log := logger.Get("cache", "CacheGet")
 
-	if !defaultCache.enabled {
+	defaultCache.mu.RLock()
+	defer defaultCache.mu.RUnlock()
+	if !isEnabled(ctx) {
 		log.Debug("cache disabled")
 		return ErrDisabled
 	}
 
-	key = fmt.Sprintf("%s:%s", key, bucket.Name())
-
-	data, err := defaultCache.cache.Get(key)
+	data, err := defaultCache.store.Get(bucket.Name(), key)
 	if err != nil {
-		if errors.Is(err, bigcache.ErrEntryNotFound) {
-			log.Debugf("cache miss for key %s", key)
-			return ErrEntryNotFound
+		if errors.Is(err, ErrEntryNotFound) {
+			log.Debugf("cache miss for key %s:%s", key, bucket.Name())
 		}
 		return err
 	}
 
-	log.Debugf("cache get for for key %s: [%d bytes]", key, len(data))
+	log.Debugf("cache get for for key %s:%s: [%d bytes]", key, bucket.Name(), len(data))
 
 	return json.Unmarshal(data, out)
 }
+
+// Flush removes all entries from the bucket
+func Flush(bucket Bucket) error {
+	log := logger.Get("cache", "CacheFlush")
+
+	defaultCache.mu.RLock()
+	defer defaultCache.mu.RUnlock()
+	if !defaultCache.enabled {
+		log.Debug("cache disabled")
+		return ErrDisabled
+	}
+
+	log.Debugf("cache flush for bucket %s", bucket.Name())
+
+	return defaultCache.store.Flush(bucket.Name())
+}
+
+// Invalidate removes all entries with keys starting with keyPrefix from the bucket.
+// It should be called after any change of the objects which are cached under those keys.
+// Both the keys and the prefix should end with a delimiter, so that e.g. 'get:1:' does not match 'get:12:'.
+func Invalidate(bucket Bucket, keyPrefix string) error {
+	log := logger.Get("cache", "CacheInvalidate")
+
+	defaultCache.mu.RLock()
+	defer defaultCache.mu.RUnlock()
+	if !defaultCache.enabled {
+		log.Debug("cache disabled")
+		return ErrDisabled
+	}
+
+	log.Debugf("cache invalidate for key prefix %s in bucket %s", keyPrefix, bucket.Name())
+
+	return defaultCache.store.Delete(bucket.Name(), keyPrefix)
+}
+
+// TryInvalidate removes all entries with keys starting with keyPrefix from the bucket, as Invalidate does.
+// Failure of the invalidation is only logged, as it does not affect the result of the caller's operation.
+func TryInvalidate(bucket Bucket, keyPrefix string, logger log.Interface) {
+	if err := Invalidate(bucket, keyPrefix); err != nil && !errors.Is(err, ErrDisabled) {
+		logger.Warnf("unable to invalidate cache entries %s: %s", keyPrefix, err.Error())
+	}
+}
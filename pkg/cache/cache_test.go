@@ -1,8 +1,15 @@
 package cache
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/apex/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -11,35 +18,215 @@ type TestObject struct {
 	ID string
 }
 
+// closingStore records whether it was closed
+type closingStore struct {
+	Store
+	closed bool
+}
+
+func (s *closingStore) Close() error {
+	s.closed = true
+	return nil
+}
+
+// useTestStore sets a new store of the default cache, which may be closed by the test, and restores the original one after the test
+func useTestStore(t *testing.T) {
+	original := defaultCache.store
+	store, err := NewMemoryStore(time.Minute)
+	require.NoError(t, err)
+	defaultCache.store = store
+	t.Cleanup(func() {
+		// the store is closed already when the test replaced it
+		if defaultCache.store == store {
+			require.NoError(t, store.(io.Closer).Close())
+		}
+		defaultCache.store, defaultCache.storeKey = original, ""
+	})
+}
+
 func TestCache(t *testing.T) {
 	bucket := BucketName("testBucket")
 	key := "testKey"
 	object := TestObject{"1234"}
 
-	err := Set(bucket, key, object)
+	err := Set(context.Background(), bucket, key, object)
 	assert.ErrorIs(t, err, ErrDisabled)
 
-	err = Get(bucket, key, nil)
+	err = Get(context.Background(), bucket, key, nil)
 	assert.ErrorIs(t, err, ErrDisabled)
 
 	Enable(true)
 
-	err = Set(bucket, key, object)
+	err = Set(context.Background(), bucket, key, object)
 	require.NoError(t, err)
 
 	var out TestObject
-	err = Get(bucket, key, &out)
+	err = Get(context.Background(), bucket, key, &out)
 	require.NoError(t, err)
 	assert.Equal(t, object, out)
 
-	err = Get(bucket, key+"5", &out)
+	err = Get(context.Background(), bucket, key+"5", &out)
 	assert.ErrorIs(t, err, ErrEntryNotFound)
 
 	Enable(false)
 
-	err = Set(bucket, key, object)
+	err = Set(context.Background(), bucket, key, object)
+	assert.ErrorIs(t, err, ErrDisabled)
+
+	err = Get(context.Background(), bucket, key, nil)
 	assert.ErrorIs(t, err, ErrDisabled)
+}
 
-	err = Get(bucket, key, nil)
+func TestFlush(t *testing.T) {
+	bucket := BucketName("testBucket")
+	otherBucket := BucketName("otherBucket")
+	object := TestObject{"1234"}
+
+	err := Flush(bucket)
 	assert.ErrorIs(t, err, ErrDisabled)
+
+	Enable(true)
+	defer Enable(false)
+
+	require.NoError(t, Set(context.Background(), bucket, "key1", object))
+	require.NoError(t, Set(context.Background(), bucket, "key2", object))
+	require.NoError(t, Set(context.Background(), otherBucket, "key1", object))
+
+	require.NoError(t, Flush(bucket))
+
+	var out TestObject
+	assert.ErrorIs(t, Get(context.Background(), bucket, "key1", &out), ErrEntryNotFound)
+	assert.ErrorIs(t, Get(context.Background(), bucket, "key2", &out), ErrEntryNotFound)
+	require.NoError(t, Get(context.Background(), otherBucket, "key1", &out))
+	assert.Equal(t, object, out)
+}
+
+func TestSetStore(t *testing.T) {
+	useTestStore(t)
+
+	store, err := NewFileStore(t.TempDir(), "host.com", time.Minute)
+	require.NoError(t, err)
+	SetStore(store)
+
+	Enable(true)
+	defer Enable(false)
+
+	require.NoError(t, Set(context.Background(), BucketName("testBucket"), "key", TestObject{"1234"}))
+
+	data, err := store.Get("testBucket", "key")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ID":"1234"}`, string(data))
+}
+
+func TestConfigureStore(t *testing.T) {
+	useTestStore(t)
+
+	newStore := func(created *int, store *closingStore) func() (Store, error) {
+		return func() (Store, error) {
+			*created++
+			return store, nil
+		}
+	}
+
+	var created int
+	first := &closingStore{}
+	require.NoError(t, ConfigureStore("memory|host.com", newStore(&created, first)))
+	require.NoError(t, ConfigureStore("memory|host.com", newStore(&created, &closingStore{})))
+	assert.Equal(t, 1, created)
+	assert.False(t, first.closed)
+
+	second := &closingStore{}
+	require.NoError(t, ConfigureStore("memory|other.com", newStore(&created, second)))
+	assert.Equal(t, 2, created)
+	assert.True(t, first.closed)
+	assert.Same(t, second, defaultCache.store)
+
+	err := ConfigureStore("file|host.com", func() (Store, error) { return nil, errors.New("oops") })
+	assert.EqualError(t, err, "oops")
+	assert.Same(t, second, defaultCache.store)
+	assert.False(t, second.closed)
+}
+
+func TestConfigureStoreConcurrently(t *testing.T) {
+	useTestStore(t)
+	Enable(true)
+	defer Enable(false)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		key := fmt.Sprintf("memory|%d", i%2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, ConfigureStore(key, func() (Store, error) { return NewMemoryStore(time.Minute) }))
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, Set(context.Background(), BucketName("testBucket"), "key", TestObject{"1234"}))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestInvalidate(t *testing.T) {
+	useTestStore(t)
+	bucket := BucketName("testBucket")
+	object := TestObject{"1234"}
+
+	err := Invalidate(bucket, "key")
+	assert.ErrorIs(t, err, ErrDisabled)
+
+	Enable(true)
+	defer Enable(false)
+
+	require.NoError(t, Set(context.Background(), bucket, "getConfig:1:", object))
+	require.NoError(t, Set(context.Background(), bucket, "getConfig:1:2:", object))
+	require.NoError(t, Set(context.Background(), bucket, "getConfig:12:", object))
+	require.NoError(t, Set(context.Background(), bucket, "getConfig:2:", object))
+	require.NoError(t, Set(context.Background(), BucketName("otherBucket"), "getConfig:1:", object))
+
+	require.NoError(t, Invalidate(bucket, "getConfig:1:"))
+
+	var out TestObject
+	assert.ErrorIs(t, Get(context.Background(), bucket, "getConfig:1:", &out), ErrEntryNotFound)
+	assert.ErrorIs(t, Get(context.Background(), bucket, "getConfig:1:2:", &out), ErrEntryNotFound)
+	// the delimiter ending the prefix keeps the entries of other IDs starting with the same digits
+	assert.NoError(t, Get(context.Background(), bucket, "getConfig:12:", &out))
+	assert.NoError(t, Get(context.Background(), bucket, "getConfig:2:", &out))
+	assert.NoError(t, Get(context.Background(), BucketName("otherBucket"), "getConfig:1:", &out))
+}
+
+func TestTryInvalidate(t *testing.T) {
+	useTestStore(t)
+	bucket := BucketName("testBucket")
+	object := TestObject{"1234"}
+
+	TryInvalidate(bucket, "key", log.Log)
+
+	Enable(true)
+	defer Enable(false)
+
+	require.NoError(t, Set(context.Background(), bucket, "getConfig:1", object))
+	require.NoError(t, Set(context.Background(), bucket, "getConfig:2", object))
+
+	TryInvalidate(bucket, "getConfig:1", log.Log)
+
+	var out TestObject
+	assert.ErrorIs(t, Get(context.Background(), bucket, "getConfig:1", &out), ErrEntryNotFound)
+	assert.NoError(t, Get(context.Background(), bucket, "getConfig:2", &out))
+}
+
+func TestContextWithoutCache(t *testing.T) {
+	Enable(true)
+	defer Enable(false)
+	bucket := BucketName("testBucket")
+	ctx := ContextWithoutCache(context.Background())
+
+	assert.ErrorIs(t, Set(ctx, bucket, "key", TestObject{"1234"}), ErrDisabled)
+	require.NoError(t, Set(context.Background(), bucket, "key", TestObject{"1234"}))
+
+	var out TestObject
+	assert.ErrorIs(t, Get(ctx, bucket, "key", &out), ErrDisabled)
+	require.NoError(t, Get(context.Background(), bucket, "key", &out))
+	assert.Equal(t, TestObject{"1234"}, out)
 }
@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type (
+	fileStore struct {
+		dir string
+		ttl time.Duration
+	}
+
+	fileEntry struct {
+		Key     string    `json:"key"`
+		Expires time.Time `json:"expires"`
+		Data    []byte    `json:"data"`
+	}
+)
+
+// tmpFilePrefix is the prefix of files which hold entries that are being written
+const tmpFilePrefix = ".tmp-"
+
+var _ Store = &fileStore{}
+
+// NewFileStore returns a Store which persists entries as files, so they survive between provider runs.
+//
+// Entries are kept in a separate directory for every namespace (e.g. the account and the API host),
+// so different credentials never read each other's entries.
+func NewFileStore(dir, namespace string, ttl time.Duration) (Store, error) {
+	dir = filepath.Join(dir, hash(namespace))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &fileStore{dir: dir, ttl: ttl}, nil
+}
+
+func (s *fileStore) Get(bucket, key string) ([]byte, error) {
+	path := s.path(bucket, key)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrEntryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry fileEntry
+	if err := json.Unmarshal(content, &entry); err != nil || entry.Key != key {
+		return nil, ErrEntryNotFound
+	}
+	if time.Now().After(entry.Expires) {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		return nil, ErrEntryNotFound
+	}
+
+	return entry.Data, nil
+}
+
+func (s *fileStore) Set(bucket, key string, data []byte) error {
+	content, err := json.Marshal(fileEntry{
+		Key:     key,
+		Expires: time.Now().Add(s.ttl),
+		Data:    data,
+	})
+	if err != nil {
+		return err
+	}
+
+	path := s.path(bucket, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so concurrent readers never see partially written entry
+	tmp, err := os.CreateTemp(filepath.Dir(path), tmpFilePrefix+"*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *fileStore) Flush(bucket string) error {
+	return os.RemoveAll(filepath.Join(s.dir, hash(bucket)))
+}
+
+func (s *fileStore) Delete(bucket, keyPrefix string) error {
+	dir := filepath.Join(s.dir, hash(bucket))
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if strings.HasPrefix(file.Name(), tmpFilePrefix) {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		content, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		var entry fileEntry
+		if err := json.Unmarshal(content, &entry); err != nil || strings.HasPrefix(entry.Key, keyPrefix) {
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *fileStore) path(bucket, key string) string {
+	return filepath.Join(s.dir, hash(bucket), hash(key))
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
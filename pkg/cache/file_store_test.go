@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("set and get", func(t *testing.T) {
+		store, err := NewFileStore(dir, "host.com", time.Minute)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Set("bucket", "key", []byte("data")))
+
+		data, err := store.Get("bucket", "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("data"), data)
+
+		_, err = store.Get("bucket", "other")
+		assert.ErrorIs(t, err, ErrEntryNotFound)
+		_, err = store.Get("other", "key")
+		assert.ErrorIs(t, err, ErrEntryNotFound)
+	})
+
+	t.Run("entries persist between instances", func(t *testing.T) {
+		store, err := NewFileStore(dir, "host.com", time.Minute)
+		require.NoError(t, err)
+
+		data, err := store.Get("bucket", "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("data"), data)
+	})
+
+	t.Run("entries are not shared between namespaces", func(t *testing.T) {
+		store, err := NewFileStore(dir, "other.com", time.Minute)
+		require.NoError(t, err)
+
+		_, err = store.Get("bucket", "key")
+		assert.ErrorIs(t, err, ErrEntryNotFound)
+	})
+
+	t.Run("expired entry is not returned", func(t *testing.T) {
+		store, err := NewFileStore(dir, "expired.com", -time.Second)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Set("bucket", "key", []byte("data")))
+
+		_, err = store.Get("bucket", "key")
+		assert.ErrorIs(t, err, ErrEntryNotFound)
+	})
+
+	t.Run("flush removes bucket entries", func(t *testing.T) {
+		store, err := NewFileStore(dir, "flush.com", time.Minute)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Set("bucket", "key", []byte("data")))
+		require.NoError(t, store.Set("other", "key", []byte("other data")))
+
+		require.NoError(t, store.Flush("bucket"))
+
+		_, err = store.Get("bucket", "key")
+		assert.ErrorIs(t, err, ErrEntryNotFound)
+		data, err := store.Get("other", "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("other data"), data)
+	})
+
+	t.Run("delete removes entries with key prefix", func(t *testing.T) {
+		store, err := NewFileStore(dir, "delete.com", time.Minute)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Set("bucket", "getConfig:1", []byte("data")))
+		require.NoError(t, store.Set("bucket", "getConfig:2", []byte("data")))
+
+		require.NoError(t, store.Delete("bucket", "getConfig:1"))
+		require.NoError(t, store.Delete("not_existing", "getConfig:1"))
+
+		_, err = store.Get("bucket", "getConfig:1")
+		assert.ErrorIs(t, err, ErrEntryNotFound)
+		_, err = store.Get("bucket", "getConfig:2")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/allegro/bigcache/v2"
+)
+
+type (
+	// Store is the storage backend of the cache
+	Store interface {
+		// Get returns data stored under the key in the bucket, or ErrEntryNotFound if there is no such entry
+		Get(bucket, key string) ([]byte, error)
+
+		// Set stores data under the key in the bucket
+		Set(bucket, key string, data []byte) error
+
+		// Flush removes all entries from the bucket
+		Flush(bucket string) error
+
+		// Delete removes the entries with keys starting with keyPrefix from the bucket
+		Delete(bucket, keyPrefix string) error
+	}
+
+	// memoryStore keeps the keys of every bucket, as the keys returned by the bigcache iterator
+	// are not retained by the garbage collector and may be overwritten while being matched
+	memoryStore struct {
+		cache *bigcache.BigCache
+		mu    sync.Mutex
+		keys  map[string]map[string]struct{}
+	}
+)
+
+var _ Store = &memoryStore{}
+
+// NewMemoryStore returns a Store which keeps entries in memory for the lifetime of the provider process
+func NewMemoryStore(ttl time.Duration) (Store, error) {
+	c, err := bigcache.NewBigCache(bigcache.DefaultConfig(ttl))
+	if err != nil {
+		return nil, err
+	}
+
+	return &memoryStore{cache: c, keys: map[string]map[string]struct{}{}}, nil
+}
+
+func (s *memoryStore) Get(bucket, key string) ([]byte, error) {
+	data, err := s.cache.Get(memoryKey(bucket, key))
+	if errors.Is(err, bigcache.ErrEntryNotFound) {
+		return nil, ErrEntryNotFound
+	}
+	return data, err
+}
+
+func (s *memoryStore) Set(bucket, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys[bucket] == nil {
+		s.keys[bucket] = map[string]struct{}{}
+	}
+	s.keys[bucket][key] = struct{}{}
+	return s.cache.Set(memoryKey(bucket, key), data)
+}
+
+func (s *memoryStore) Flush(bucket string) error {
+	return s.deleteMatching(bucket, func(string) bool { return true })
+}
+
+func (s *memoryStore) Delete(bucket, keyPrefix string) error {
+	return s.deleteMatching(bucket, func(k string) bool {
+		return strings.HasPrefix(k, keyPrefix)
+	})
+}
+
+// deleteMatching removes the entries of the bucket with matching keys. The keys of the entries evicted by bigcache
+// are kept until they are matched, which is harmless
+func (s *memoryStore) deleteMatching(bucket string, match func(string) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.keys[bucket] {
+		if !match(key) {
+			continue
+		}
+		if err := s.cache.Delete(memoryKey(bucket, key)); err != nil && !errors.Is(err, bigcache.ErrEntryNotFound) {
+			return err
+		}
+		delete(s.keys[bucket], key)
+	}
+	return nil
+}
+
+// Close stops the background eviction of the entries
+func (s *memoryStore) Close() error {
+	return s.cache.Close()
+}
+
+func memoryKey(bucket, key string) string {
+	return fmt.Sprintf("%s:%s", key, bucket)
+}
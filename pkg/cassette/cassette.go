@@ -0,0 +1,176 @@
+// Package cassette provides recording of the HTTP traffic to the Akamai APIs and its replay without network access
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the sanitized values in the recorded interactions
+const Redacted = "REDACTED"
+
+// accountSwitchKeyParam is the query parameter identifying the account, which is never recorded
+const accountSwitchKeyParam = "accountSwitchKey"
+
+var (
+	// sensitiveHeaders are never recorded, the Authorization header carries the EdgeGrid signature and client token
+	sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+	// sensitiveKeyRegexp matches the names of JSON fields which values are redacted, e.g. client_secret,
+	// connector tokens, passwords or TSIG secrets
+	sensitiveKeyRegexp = regexp.MustCompile(`(?i)(secret|password|passphrase|token|private_?key)`)
+)
+
+type (
+	// Interaction is a single recorded request and the response to it
+	Interaction struct {
+		Request  Request  `json:"request"`
+		Response Response `json:"response"`
+	}
+
+	// Request is the sanitized recorded request
+	Request struct {
+		Method  string      `json:"method"`
+		URI     string      `json:"uri"`
+		Headers http.Header `json:"headers,omitempty"`
+		Body    string      `json:"body,omitempty"`
+	}
+
+	// Response is the sanitized recorded response
+	Response struct {
+		StatusCode int         `json:"status_code"`
+		Headers    http.Header `json:"headers,omitempty"`
+		Body       string      `json:"body,omitempty"`
+	}
+)
+
+// key returns the value identifying the request during replay, which does not depend on the API host
+func (r Request) key() string {
+	return r.Method + " " + r.URI + "\n" + r.Body
+}
+
+// newRequest returns the sanitized copy of the request, the body of the original request is preserved
+func newRequest(r *http.Request) (Request, error) {
+	body, err := readRequestBody(r)
+	if err != nil {
+		return Request{}, err
+	}
+	return Request{
+		Method:  r.Method,
+		URI:     sanitizeURI(r.URL),
+		Headers: sanitizeHeaders(r.Header),
+		Body:    sanitizeBody(body),
+	}, nil
+}
+
+// newResponse returns the sanitized copy of the response, the body of the original response is preserved
+func newResponse(resp *http.Response) (Response, error) {
+	var body []byte
+	if resp.Body != nil {
+		var err error
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return Response{}, err
+		}
+		if err := resp.Body.Close(); err != nil {
+			return Response{}, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return Response{
+		StatusCode: resp.StatusCode,
+		Headers:    sanitizeHeaders(resp.Header),
+		Body:       sanitizeBody(body),
+	}, nil
+}
+
+func readRequestBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	if r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = body.Close() }()
+		return io.ReadAll(body)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Body.Close(); err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// sanitizeURI returns the path and the sorted query of the request without the account switch key
+func sanitizeURI(u *url.URL) string {
+	query := u.Query()
+	query.Del(accountSwitchKeyParam)
+	if len(query) == 0 {
+		return u.EscapedPath()
+	}
+	return u.EscapedPath() + "?" + query.Encode()
+}
+
+func sanitizeHeaders(headers http.Header) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	sanitized := headers.Clone()
+	for _, header := range sensitiveHeaders {
+		sanitized.Del(header)
+	}
+	return sanitized
+}
+
+// sanitizeBody redacts the values of the sensitive fields in JSON body, other bodies are returned as they are
+func sanitizeBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return string(body)
+	}
+	sanitized, err := json.Marshal(redact(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(sanitized)
+}
+
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if _, isString := field.(string); isString && sensitiveKeyRegexp.MatchString(key) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redact(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redact(item)
+		}
+	}
+	return value
+}
+
+// isInteractionFile returns whether the file in the cassette directory holds a recorded interaction
+func isInteractionFile(name string) bool {
+	return strings.HasSuffix(name, ".json")
+}
@@ -0,0 +1,68 @@
+package cassette
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeBody(t *testing.T) {
+	tests := map[string]struct {
+		body         string
+		expected     string
+		expectedJSON bool
+	}{
+		"empty body": {},
+		"not JSON body": {
+			body:     "client_secret=abc",
+			expected: "client_secret=abc",
+		},
+		"sensitive fields are redacted": {
+			body:         `{"name":"test","clientSecret":"abc","connector":{"accessToken":"def","tokens":["ghi"]},"tsig":{"secret":"jkl"}}`,
+			expected:     `{"connector":{"accessToken":"REDACTED","tokens":["ghi"]},"name":"test","tsig":{"secret":"REDACTED"},"clientSecret":"REDACTED"}`,
+			expectedJSON: true,
+		},
+		"sensitive fields in arrays are redacted": {
+			body:         `[{"password":"abc","id":12345678901234567890}]`,
+			expected:     `[{"id":12345678901234567890,"password":"REDACTED"}]`,
+			expectedJSON: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sanitized := sanitizeBody([]byte(test.body))
+			if test.expectedJSON {
+				assert.JSONEq(t, test.expected, sanitized)
+				return
+			}
+			assert.Equal(t, test.expected, sanitized)
+		})
+	}
+}
+
+func TestSanitizeURI(t *testing.T) {
+	u, err := url.Parse("https://akab-host.luna.akamaiapis.net/papi/v1/properties?groupId=grp_2&accountSwitchKey=ACC-1&contractId=ctr_1")
+	require.NoError(t, err)
+	assert.Equal(t, "/papi/v1/properties?contractId=ctr_1&groupId=grp_2", sanitizeURI(u))
+
+	u, err = url.Parse("https://akab-host.luna.akamaiapis.net/papi/v1/groups?accountSwitchKey=ACC-1")
+	require.NoError(t, err)
+	assert.Equal(t, "/papi/v1/groups", sanitizeURI(u))
+}
+
+func TestSanitizeHeaders(t *testing.T) {
+	headers := http.Header{
+		"Authorization": []string{"EG1-HMAC-SHA256 client_token=abc;access_token=def;signature=ghi"},
+		"Content-Type":  []string{"application/json"},
+		"Set-Cookie":    []string{"session=abc"},
+	}
+
+	sanitized := sanitizeHeaders(headers)
+	assert.Equal(t, http.Header{"Content-Type": []string{"application/json"}}, sanitized)
+	assert.Len(t, headers, 3)
+	assert.Nil(t, sanitizeHeaders(nil))
+}
@@ -0,0 +1,72 @@
+package cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/apex/log"
+)
+
+// Recorder is an http.RoundTripper which executes the requests with the next RoundTripper
+// and writes every sanitized interaction to a separate file in the cassette directory.
+//
+// The files are named after the time of the request, so that the interactions recorded
+// by subsequent provider runs are replayed in the original order.
+type Recorder struct {
+	next http.RoundTripper
+	dir  string
+	log  log.Interface
+	seq  atomic.Uint64
+}
+
+var _ http.RoundTripper = &Recorder{}
+
+// NewRecorder returns a Recorder writing the interactions into dir, which is created if it does not exist.
+// Failures to record an interaction are logged with log
+func NewRecorder(dir string, next http.RoundTripper, log log.Interface) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("cannot create cassette directory: %w", err)
+	}
+	return &Recorder{next: next, dir: dir, log: log}, nil
+}
+
+// RoundTrip executes the request and records the interaction
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	request, err := newRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot record request: %w", err)
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := newResponse(resp)
+	if err != nil {
+		return nil, fmt.Errorf("cannot record response: %w", err)
+	}
+	if err := r.write(Interaction{Request: request, Response: response}); err != nil {
+		// the request was executed already, so it cannot be reported as failed
+		r.log.WithError(err).Errorf("cannot record %s %s", req.Method, req.URL.Path)
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) write(interaction Interaction) error {
+	data, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal interaction: %w", err)
+	}
+	name := fmt.Sprintf("%020d-%06d.json", time.Now().UnixNano(), r.seq.Add(1))
+	if err := os.WriteFile(filepath.Join(r.dir, name), data, 0600); err != nil {
+		return fmt.Errorf("cannot write interaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package cassette
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/apex/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			assert.JSONEq(t, `{"name":"zone","tsigKey":{"secret":"abc"}}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, err = w.Write([]byte(`{"id":1,"status":"PENDING"}`))
+		default:
+			status := "PENDING"
+			if calls.Load() > 2 {
+				status = "ACTIVE"
+			}
+			_, err = w.Write([]byte(`{"id":1,"status":"` + status + `"}`))
+		}
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	dir := filepath.Join(t.TempDir(), "cassette")
+	recorder, err := NewRecorder(dir, http.DefaultTransport, log.Log)
+	require.NoError(t, err)
+
+	roundTrip := func(t *testing.T, transport http.RoundTripper, method, path, body string) (int, string) {
+		var bodyReader io.Reader
+		if body != "" {
+			bodyReader = strings.NewReader(body)
+		}
+		req, err := http.NewRequest(method, srv.URL+path, bodyReader)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "EG1-HMAC-SHA256 client_token=abc;signature=def")
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode, string(respBody)
+	}
+
+	status, body := roundTrip(t, recorder, http.MethodPost, "/config-dns/v2/zones?accountSwitchKey=ACC-1", `{"name":"zone","tsigKey":{"secret":"abc"}}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, `{"id":1,"status":"PENDING"}`, body)
+	roundTrip(t, recorder, http.MethodGet, "/config-dns/v2/zones/1", "")
+	roundTrip(t, recorder, http.MethodGet, "/config-dns/v2/zones/1", "")
+	assert.Equal(t, int32(3), calls.Load())
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "signature")
+	assert.NotContains(t, string(data), "ACC-1")
+	var interaction Interaction
+	require.NoError(t, json.Unmarshal(data, &interaction))
+	assert.Equal(t, "/config-dns/v2/zones", interaction.Request.URI)
+	assert.JSONEq(t, `{"name":"zone","tsigKey":{"secret":"REDACTED"}}`, interaction.Request.Body)
+
+	replayer, err := NewReplayer(dir)
+	require.NoError(t, err)
+
+	status, body = roundTrip(t, replayer, http.MethodPost, "/config-dns/v2/zones", `{"name":"zone","tsigKey":{"secret":"other"}}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.JSONEq(t, `{"id":1,"status":"PENDING"}`, body)
+	_, body = roundTrip(t, replayer, http.MethodGet, "/config-dns/v2/zones/1", "")
+	assert.JSONEq(t, `{"id":1,"status":"PENDING"}`, body)
+	_, body = roundTrip(t, replayer, http.MethodGet, "/config-dns/v2/zones/1", "")
+	assert.JSONEq(t, `{"id":1,"status":"ACTIVE"}`, body)
+	_, body = roundTrip(t, replayer, http.MethodGet, "/config-dns/v2/zones/1", "")
+	assert.JSONEq(t, `{"id":1,"status":"ACTIVE"}`, body)
+	assert.Equal(t, int32(3), calls.Load())
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/config-dns/v2/zones/2", nil)
+	require.NoError(t, err)
+	_, err = replayer.RoundTrip(req)
+	assert.ErrorIs(t, err, ErrInteractionNotFound)
+}
+
+func TestNewReplayer(t *testing.T) {
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := NewReplayer(filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid interaction", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "1.json"), []byte("{"), 0600))
+		_, err := NewReplayer(dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("other files are ignored", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("{"), 0600))
+		_, err := NewReplayer(dir)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRecorderWriteFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, err := w.Write([]byte(`{"id":1}`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	dir := filepath.Join(t.TempDir(), "cassette")
+	recorder, err := NewRecorder(dir, http.DefaultTransport, log.Log)
+	require.NoError(t, err)
+	require.NoError(t, os.RemoveAll(dir))
+
+	// the response of the executed request is returned even though it cannot be recorded
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/config-dns/v2/zones", strings.NewReader(`{"name":"zone"}`))
+	require.NoError(t, err)
+	resp, err := recorder.RoundTrip(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, `{"id":1}`, string(body))
+}
@@ -0,0 +1,105 @@
+package cassette
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrInteractionNotFound is returned when the replayed request was not recorded
+var ErrInteractionNotFound = errors.New("interaction not found in cassette")
+
+// Replayer is an http.RoundTripper which serves the recorded responses without network access.
+//
+// Requests are matched by their method, path, query and body. Responses recorded for the same request
+// are served in the recorded order, the last one is repeated after the others are used up,
+// so that status polling and repeated plans work with a single recording.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions map[string][]Response
+	served       map[string]int
+}
+
+var _ http.RoundTripper = &Replayer{}
+
+// NewReplayer returns a Replayer serving the interactions recorded in dir
+func NewReplayer(dir string) (*Replayer, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read cassette directory: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	r := &Replayer{
+		interactions: make(map[string][]Response),
+		served:       make(map[string]int),
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !isInteractionFile(entry.Name()) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read interaction: %w", err)
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(data, &interaction); err != nil {
+			return nil, fmt.Errorf("cannot parse interaction %s: %w", entry.Name(), err)
+		}
+		key := interaction.Request.key()
+		r.interactions[key] = append(r.interactions[key], interaction.Response)
+	}
+
+	return r, nil
+}
+
+// RoundTrip returns the response recorded for the request
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	request, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	key := request.key()
+	r.mu.Lock()
+	responses, ok := r.interactions[key]
+	if !ok {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, request.Method, request.URI)
+	}
+	i := min(r.served[key], len(responses)-1)
+	r.served[key]++
+	r.mu.Unlock()
+
+	response := responses[i]
+	headers := response.Headers.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	// the body may be changed by the sanitization, so the recorded length is not valid anymore
+	headers.Del("Content-Length")
+	return &http.Response{
+		Status:        strconv.Itoa(response.StatusCode) + " " + http.StatusText(response.StatusCode),
+		StatusCode:    response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          io.NopCloser(strings.NewReader(response.Body)),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}, nil
+}
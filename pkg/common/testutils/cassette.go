@@ -0,0 +1,27 @@
+package testutils
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cassette"
+	"github.com/stretchr/testify/require"
+)
+
+// ReplaySession returns a session serving the API responses recorded in the given cassette directory,
+// e.g. with the provider's `http_cassette_mode = "record"`. It can be passed to subprovider clients
+// in place of the hand-written mocks.
+func ReplaySession(t *testing.T, dir string) session.Session {
+	t.Helper()
+	replayer, err := cassette.NewReplayer(dir)
+	require.NoError(t, err)
+
+	sess, err := session.New(
+		session.WithClient(&http.Client{Transport: replayer}),
+		session.WithSigner(&edgegrid.Config{Host: "replay.luna.akamaiapis.net"}),
+	)
+	require.NoError(t, err)
+	return sess
+}
@@ -0,0 +1,23 @@
+package logger
+
+import (
+	"context"
+)
+
+type fieldsContextKey struct{}
+
+// ContextWithFields returns a context carrying the log fields given as key-value pairs,
+// which are added to the entries logged for requests made with that context
+func ContextWithFields(ctx context.Context, args ...interface{}) context.Context {
+	if len(args) == 0 {
+		return ctx
+	}
+	fields := append(FieldsFromContext(ctx), args...)
+	return context.WithValue(ctx, fieldsContextKey{}, fields)
+}
+
+// FieldsFromContext returns the log fields carried by the context as key-value pairs
+func FieldsFromContext(ctx context.Context) []interface{} {
+	fields, _ := ctx.Value(fieldsContextKey{}).([]interface{})
+	return append([]interface{}(nil), fields...)
+}
@@ -0,0 +1,23 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWithFields(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, FieldsFromContext(ctx))
+	assert.Equal(t, ctx, ContextWithFields(ctx))
+
+	parent := ContextWithFields(ctx, "ResourceType", "akamai_dns_zone")
+	child := ContextWithFields(parent, "ResourceID", "example.com")
+	assert.Equal(t, []interface{}{"ResourceType", "akamai_dns_zone"}, FieldsFromContext(parent))
+	assert.Equal(t, []interface{}{"ResourceType", "akamai_dns_zone", "ResourceID", "example.com"}, FieldsFromContext(child))
+
+	fields := FieldsFromContext(child)
+	fields[1] = "changed"
+	assert.Equal(t, "akamai_dns_zone", FieldsFromContext(child)[1])
+}
@@ -3,8 +3,12 @@ package logger
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/apex/log"
 	"github.com/hashicorp/go-hclog"
@@ -19,6 +23,22 @@ type Logger struct {
 
 const defaultTimestampFormat = "2006/01/02 03:04:05"
 
+const (
+	// FormatText is the default human-readable log format
+	FormatText = "text"
+	// FormatJSON writes every log entry as a single JSON object
+	FormatJSON = "json"
+)
+
+// ErrUnsupportedFormat is returned when the requested log format is not supported
+var ErrUnsupportedFormat = errors.New("unsupported log format")
+
+var (
+	// logFiles holds the log files opened by NewHCLog, which are shared by all loggers writing to the same path
+	logFiles   = make(map[string]*os.File)
+	logFilesMu sync.Mutex
+)
+
 func init() {
 	if fmt, ok := os.LookupEnv("AKAMAI_TS_FORMAT"); ok {
 		hclog.DefaultOptions.TimeFormat = fmt
@@ -66,6 +86,71 @@ func Get(args ...interface{}) *Logger {
 	return FromHCLog(hclog.Default().With(args...))
 }
 
+// NewHCLog returns the hclog logger writing entries in the given format to the file at path,
+// or to the standard error if path is empty. The level is taken from the Terraform logging
+// environment variables and defaults to info.
+func NewHCLog(format, path string) (hclog.Logger, error) {
+	switch format {
+	case "", FormatText, FormatJSON:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	var output io.Writer = os.Stderr
+	if path != "" {
+		f, err := openLogFile(path)
+		if err != nil {
+			return nil, err
+		}
+		output = f
+	}
+
+	level := hclog.LevelFromString(logging.LogLevel())
+	if level == hclog.NoLevel {
+		level = hclog.Info
+	}
+
+	return hclog.New(&hclog.LoggerOptions{
+		Name:       "provider.terraform-provider-akamai",
+		Level:      level,
+		Output:     output,
+		JSONFormat: format == FormatJSON,
+		TimeFormat: hclog.DefaultOptions.TimeFormat,
+	}), nil
+}
+
+// openLogFile returns the log file at path, which is opened only once per process
+func openLogFile(path string) (*os.File, error) {
+	logFilesMu.Lock()
+	defer logFilesMu.Unlock()
+
+	if f, ok := logFiles[path]; ok {
+		return f, nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open log file: %w", err)
+	}
+	logFiles[path] = f
+	return f, nil
+}
+
+// CloseFiles closes the log files opened by NewHCLog. It is called when the provider process is shutting down,
+// loggers writing to the closed files must not be used afterwards.
+func CloseFiles() error {
+	logFilesMu.Lock()
+	defer logFilesMu.Unlock()
+
+	var errs []error
+	for path, f := range logFiles {
+		if err := f.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("cannot close log file: %w", err))
+		}
+		delete(logFiles, path)
+	}
+	return errors.Join(errs...)
+}
+
 // FromContext returns the logger from the context
 func FromContext(ctx context.Context, args ...interface{}) *Logger {
 	return FromHCLog(hclog.FromContext(ctx).With(args...))
@@ -76,20 +161,24 @@ func (l *Logger) HandleLog(e *log.Entry) error {
 	fields := make([]interface{}, 0)
 
 	for k, v := range e.Fields {
-		fields = append(fields, k, v)
+		if sensitiveNameRegexp.MatchString(k) {
+			v = redacted
+		}
+		fields = append(fields, k, redactValue(v))
 	}
 
+	message := Redact(e.Message)
 	switch e.Level {
 	case log.DebugLevel:
-		l.hclog.Debug(e.Message, fields...)
+		l.hclog.Debug(message, fields...)
 	case log.InfoLevel:
-		l.hclog.Info(e.Message, fields...)
+		l.hclog.Info(message, fields...)
 	case log.WarnLevel:
-		l.hclog.Warn(e.Message, fields...)
+		l.hclog.Warn(message, fields...)
 	case log.ErrorLevel:
-		l.hclog.Error(e.Message, fields...)
+		l.hclog.Error(message, fields...)
 	case log.FatalLevel:
-		panic(e.Message)
+		panic(message)
 	}
 
 	return nil
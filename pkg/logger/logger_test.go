@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHCLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "provider.log")
+
+	first, err := NewHCLog(FormatText, path)
+	require.NoError(t, err)
+	second, err := NewHCLog(FormatText, path)
+	require.NoError(t, err)
+	assert.Len(t, logFiles, 1)
+
+	first.Info("first")
+	second.Info("second")
+	require.NoError(t, CloseFiles())
+	assert.Empty(t, logFiles)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "first")
+	assert.Contains(t, lines[1], "second")
+}
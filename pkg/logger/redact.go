@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// redacted replaces the secrets in the log entries
+const redacted = "REDACTED"
+
+// sensitiveName matches the names of fields holding secrets, e.g. client_secret, connector tokens or TSIG secrets
+const sensitiveName = `[\w-]*(?:secret|password|passphrase|token|private_?key|signature)[\w-]*`
+
+// sensitiveNameRegexp matches the names of log entry fields which values are redacted
+var sensitiveNameRegexp = regexp.MustCompile(`(?i)^` + sensitiveName + `$`)
+
+var redactions = []struct {
+	regexp      *regexp.Regexp
+	replacement string
+}{
+	// Authorization header in HTTP request dumps, which carries the EdgeGrid client token, access token and signature
+	{regexp.MustCompile(`(?i)(Authorization:\s*)[^\r\n]+`), "${1}" + redacted},
+	// JSON fields, e.g. "clientSecret": "value"
+	{regexp.MustCompile(`(?i)("` + sensitiveName + `"\s*:\s*)"(?:[^"\\]|\\.)*"`), `${1}"` + redacted + `"`},
+	// key=value pairs, e.g. client_secret=value in EdgeGrid headers or HCL-like dumps
+	{regexp.MustCompile(`(?i)\b(` + sensitiveName + `\s*=\s*)"?[^\s;&,"}]+"?`), "${1}" + redacted},
+	// Go structs formatted with %+v, e.g. {Secret:value}
+	{regexp.MustCompile(`(?i)\b(` + sensitiveName + `:)[^\s}\]"]+`), "${1}" + redacted},
+}
+
+// Redact removes secrets from the log message
+func Redact(message string) string {
+	for _, r := range redactions {
+		message = r.regexp.ReplaceAllString(message, r.replacement)
+	}
+	return message
+}
+
+// redactValue removes secrets from the value of a log entry field
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		return Redact(v)
+	case error:
+		return Redact(v.Error())
+	case fmt.Stringer:
+		return Redact(v.String())
+	default:
+		return value
+	}
+}
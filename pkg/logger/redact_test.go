@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	tests := map[string]struct {
+		message  string
+		expected string
+	}{
+		"no secrets": {
+			message:  "reading property prp_1",
+			expected: "reading property prp_1",
+		},
+		"authorization header": {
+			message:  "GET /papi/v1/groups HTTP/1.1\r\nAuthorization: EG1-HMAC-SHA256 client_token=abc;access_token=def;signature=ghi\r\nAccept: */*",
+			expected: "GET /papi/v1/groups HTTP/1.1\r\nAuthorization: REDACTED\r\nAccept: */*",
+		},
+		"JSON fields": {
+			message:  `{"name":"test","clientSecret":"abc","tsig":{"secret": "d\"ef"}}`,
+			expected: `{"name":"test","clientSecret":"REDACTED","tsig":{"secret": "REDACTED"}}`,
+		},
+		"key value pairs": {
+			message:  "client_token=abc;client_secret=def host=akab.net",
+			expected: "client_token=REDACTED;client_secret=REDACTED host=akab.net",
+		},
+		"structs": {
+			message:  "config: {Host:akab.net ClientSecret:abc AccessToken:def}",
+			expected: "config: {Host:akab.net ClientSecret:REDACTED AccessToken:REDACTED}",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Redact(test.message))
+		})
+	}
+}
+
+func TestHandleLogRedactsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	log := FromHCLog(hclog.New(&hclog.LoggerOptions{Output: &buf, JSONFormat: true}))
+
+	log.WithField("client_secret", "abc").
+		WithField("request", `{"password":"def"}`).
+		WithError(errors.New("invalid token=ghi")).
+		Error(`creating zone with {"secret":"jkl"}`)
+
+	out := buf.String()
+	for _, secret := range []string{"abc", "def", "ghi", "jkl"} {
+		assert.NotContains(t, out, secret)
+	}
+	assert.Contains(t, out, `"client_secret":"REDACTED"`)
+}
+
+func TestHandleLogRedactsFatalMessage(t *testing.T) {
+	log := FromHCLog(hclog.NewNullLogger())
+
+	assert.PanicsWithValue(t, `creating zone with {"secret":"REDACTED"}`, func() {
+		log.Fatal(`creating zone with {"secret":"jkl"}`)
+	})
+}
@@ -24,6 +24,12 @@ type (
 
 		// Session returns the operation API session
 		Session() session.Session
+
+		// CredentialsProfile returns a copy of the meta which uses the session of the named credentials profile
+		CredentialsProfile(name string) (Meta, error)
+
+		// WithLogFields returns a copy of the meta which adds the given key-value pairs to every log entry
+		WithLogFields(args ...interface{}) Meta
 	}
 
 	// OperationMeta is the implementation of Meta interface
@@ -31,7 +37,11 @@ type (
 		operationID string
 		log         hclog.Logger
 		sess        session.Session
+		profiles    map[string]session.Session
 	}
+
+	// Option is a functional option for OperationMeta
+	Option func(*OperationMeta)
 )
 
 // ErrNilLog is an error returned from New(...) when log argument is nil
@@ -40,19 +50,33 @@ var ErrNilLog = errors.New("nil log argument")
 // ErrNilSession is an error returned from New(...) when session argument is nil
 var ErrNilSession = errors.New("nil session argument")
 
+// ErrProfileNotFound is returned from CredentialsProfile(...) when there is no profile with the given name
+var ErrProfileNotFound = errors.New("credentials profile not found")
+
 // New returns a new OperationMeta
-func New(sess session.Session, log hclog.Logger, operationID string) (*OperationMeta, error) {
+func New(sess session.Session, log hclog.Logger, operationID string, opts ...Option) (*OperationMeta, error) {
 	if log == nil {
 		return nil, ErrNilLog
 	}
 	if sess == nil {
 		return nil, ErrNilSession
 	}
-	return &OperationMeta{
+	m := &OperationMeta{
 		operationID: operationID,
 		sess:        sess,
 		log:         log,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
+}
+
+// WithCredentialsProfiles sets the sessions of named credentials profiles
+func WithCredentialsProfiles(profiles map[string]session.Session) Option {
+	return func(m *OperationMeta) {
+		m.profiles = profiles
+	}
 }
 
 // Must performs type assertion on m and panics if m does not hold Meta value
@@ -78,3 +102,25 @@ func (m *OperationMeta) OperationID() string {
 func (m *OperationMeta) Session() session.Session {
 	return m.sess
 }
+
+// CredentialsProfile returns a copy of the meta which uses the session of the named credentials profile.
+// Empty name means the default provider credentials.
+func (m *OperationMeta) CredentialsProfile(name string) (Meta, error) {
+	if name == "" {
+		return m, nil
+	}
+	sess, ok := m.profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrProfileNotFound, name)
+	}
+	profileMeta := *m
+	profileMeta.sess = sess
+	return &profileMeta, nil
+}
+
+// WithLogFields returns a copy of the meta which adds the given key-value pairs to every log entry
+func (m *OperationMeta) WithLogFields(args ...interface{}) Meta {
+	fieldsMeta := *m
+	fieldsMeta.log = m.log.With(args...)
+	return &fieldsMeta
+}
@@ -1,6 +1,8 @@
 package meta
 
 import (
+	"bytes"
+
 	"testing"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
@@ -61,3 +63,52 @@ func TestMust(t *testing.T) {
 		})
 	})
 }
+
+func TestCredentialsProfile(t *testing.T) {
+	var sess = session.Must(session.New())
+	var profileSess = session.Must(session.New())
+	var logger = hclog.New(hclog.DefaultOptions)
+
+	meta, err := New(sess, logger, "opID", WithCredentialsProfiles(map[string]session.Session{
+		"other": profileSess,
+	}))
+	require.NoError(t, err)
+
+	t.Run("empty name returns default session", func(t *testing.T) {
+		m, err := meta.CredentialsProfile("")
+		require.NoError(t, err)
+		assert.Same(t, sess, m.Session())
+	})
+	t.Run("named profile returns profile session", func(t *testing.T) {
+		m, err := meta.CredentialsProfile("other")
+		require.NoError(t, err)
+		assert.Same(t, profileSess, m.Session())
+		assert.Equal(t, "opID", m.OperationID())
+		assert.Same(t, sess, meta.Session())
+	})
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := meta.CredentialsProfile("unknown")
+		assert.ErrorIs(t, err, ErrProfileNotFound)
+	})
+}
+
+func TestWithLogFields(t *testing.T) {
+	var sess = session.Must(session.New())
+	var buf bytes.Buffer
+	var logger = hclog.New(&hclog.LoggerOptions{Output: &buf, JSONFormat: true})
+
+	meta, err := New(sess, logger, "opID")
+	require.NoError(t, err)
+
+	m := meta.WithLogFields("ResourceType", "akamai_test", "ResourceID", "1")
+	assert.Same(t, sess, m.Session())
+	assert.Equal(t, "opID", m.OperationID())
+
+	m.Log().Info("message")
+	assert.Contains(t, buf.String(), `"ResourceType":"akamai_test"`)
+	assert.Contains(t, buf.String(), `"ResourceID":"1"`)
+
+	buf.Reset()
+	meta.Log().Info("message")
+	assert.NotContains(t, buf.String(), "ResourceType")
+}
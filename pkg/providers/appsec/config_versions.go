@@ -9,6 +9,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/appsec"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
 	akameta "github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/apex/log"
 )
 
 // Utility functions for determining current and latest versions of a security
@@ -42,9 +43,9 @@ func getModifiableConfigVersion(ctx context.Context, configID int, resource stri
 	logger := meta.Log("APPSEC", "getModifiableConfigVersion")
 
 	// If the version info is in the cache, return it immediately.
-	cacheKey := fmt.Sprintf("%s:%d", "getModifiableConfigVersion", configID)
+	cacheKey := fmt.Sprintf("%s:%d:", "getModifiableConfigVersion", configID)
 	configuration := &appsec.GetConfigurationResponse{}
-	if err := cache.Get(cache.BucketName(SubproviderName), cacheKey, configuration); err == nil {
+	if err := cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, configuration); err == nil {
 		logger.Debugf("Resource %s returning modifiable version %d from cache", resource, configuration.LatestVersion)
 		return configuration.LatestVersion, nil
 	}
@@ -57,7 +58,7 @@ func getModifiableConfigVersion(ctx context.Context, configID int, resource stri
 	}()
 
 	// If the version info is in the cache, return it immediately.
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, configuration)
+	err := cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, configuration)
 	if err == nil {
 		logger.Debugf("Resource %s returning modifiable version %d from cache", resource, configuration.LatestVersion)
 		return configuration.LatestVersion, nil
@@ -81,7 +82,7 @@ func getModifiableConfigVersion(ctx context.Context, configID int, resource stri
 	stagingVersion := configuration.StagingVersion
 	productionVersion := configuration.ProductionVersion
 	if latestVersion != stagingVersion && latestVersion != productionVersion {
-		if err := cache.Set(cache.BucketName(SubproviderName), cacheKey, configuration); err != nil {
+		if err := cache.Set(ctx, cache.BucketName(SubproviderName), cacheKey, configuration); err != nil {
 			if !errors.Is(err, cache.ErrDisabled) {
 				logger.Errorf("unable to set latestVersion %d into cache")
 			}
@@ -103,7 +104,8 @@ func getModifiableConfigVersion(ctx context.Context, configID int, resource stri
 	}
 
 	configuration.LatestVersion = ccr.Version
-	if err := cache.Set(cache.BucketName(SubproviderName), cacheKey, configuration); err != nil && !errors.Is(err, cache.ErrDisabled) {
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:", "getLatestConfigVersion", configID), logger)
+	if err := cache.Set(ctx, cache.BucketName(SubproviderName), cacheKey, configuration); err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("unable to set latestVersion %d into cache: %s", err.Error())
 	}
 
@@ -120,9 +122,9 @@ func getLatestConfigVersion(ctx context.Context, configID int, m interface{}) (i
 	logger := meta.Log("APPSEC", "getLatestConfigVersion")
 
 	// Return the cached value if we have one
-	cacheKey := fmt.Sprintf("%s:%d", "getLatestConfigVersion", configID)
+	cacheKey := fmt.Sprintf("%s:%d:", "getLatestConfigVersion", configID)
 	configuration := &appsec.GetConfigurationResponse{}
-	if err := cache.Get(cache.BucketName(SubproviderName), cacheKey, configuration); err == nil {
+	if err := cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, configuration); err == nil {
 		logger.Debugf("Found config %d, returning %d as its latest version", configuration.ID, configuration.LatestVersion)
 		return configuration.LatestVersion, nil
 	}
@@ -134,7 +136,7 @@ func getLatestConfigVersion(ctx context.Context, configID int, m interface{}) (i
 		latestVersionMutex.Unlock()
 	}()
 
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, configuration)
+	err := cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, configuration)
 	if err == nil {
 		logger.Debugf("Found config %d, returning %d as its latest version", configuration.ID, configuration.LatestVersion)
 		return configuration.LatestVersion, nil
@@ -150,7 +152,7 @@ func getLatestConfigVersion(ctx context.Context, configID int, m interface{}) (i
 		logger.Errorf("error calling GetConfiguration: %s", err.Error())
 		return 0, err
 	}
-	if err := cache.Set(cache.BucketName(SubproviderName), cacheKey, configuration); err != nil && !errors.Is(err, cache.ErrDisabled) {
+	if err := cache.Set(ctx, cache.BucketName(SubproviderName), cacheKey, configuration); err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching latestVersion into cache: %s", err.Error())
 	}
 
@@ -158,6 +160,14 @@ func getLatestConfigVersion(ctx context.Context, configID int, m interface{}) (i
 	return configuration.LatestVersion, nil
 }
 
+// invalidateConfigVersionsCache removes the cached latest and modifiable versions of the given
+// security configuration. It has to be called after every operation which creates a new version
+// of the configuration or changes its active versions.
+func invalidateConfigVersionsCache(configID int, logger log.Interface) {
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:", "getModifiableConfigVersion", configID), logger)
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:", "getLatestConfigVersion", configID), logger)
+}
+
 // getActiveConfigVersions returns the version numbers of the given security configuration
 // active in staging and production respectively. API calls are made using the supplied
 // context and the API client obtained from m. Log messages are written to m's logger.
@@ -10,6 +10,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/appsec"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -138,6 +139,7 @@ func resourceActivationsCreate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	invalidateConfigVersionsCache(configID, logger)
 
 	d.SetId(strconv.Itoa(activationResp.ActivationID))
 
@@ -249,6 +251,7 @@ func resourceActivationsUpdate(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	invalidateConfigVersionsCache(configID, logger)
 
 	d.SetId(strconv.Itoa(activationResp.ActivationID))
 
@@ -328,6 +331,7 @@ func resourceActivationsDelete(ctx context.Context, d *schema.ResourceData, m in
 		logger.Errorf("calling 'removeActivations': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateConfigVersionsCache(configID, logger)
 
 	d.SetId(strconv.Itoa(postresp.ActivationID))
 
@@ -343,6 +347,9 @@ func resourceActivationsDelete(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	ctx, span := tracing.StartSpan(ctx, "appsec.pollDeactivation")
+	defer span.End()
+
 	for activation.Status != appsec.StatusDeactivated && activation.Status != appsec.StatusAborted && activation.Status != appsec.StatusFailed {
 		select {
 		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
@@ -491,6 +498,9 @@ func createActivation(ctx context.Context, client appsec.APPSEC, request appsec.
 }
 
 func pollActivation(ctx context.Context, client appsec.APPSEC, activationStatus appsec.StatusValue, getActivationRequest appsec.GetActivationsRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "appsec.pollActivation")
+	defer span.End()
+
 	retriesMax := 5
 	retries5xx := 0
 
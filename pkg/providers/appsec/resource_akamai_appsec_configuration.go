@@ -312,5 +312,6 @@ func resourceConfigurationDelete(ctx context.Context, d *schema.ResourceData, m
 		logger.Errorf("calling 'removeConfiguration': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateConfigVersionsCache(configID, logger)
 	return nil
 }
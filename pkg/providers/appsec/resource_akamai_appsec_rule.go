@@ -12,6 +12,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	akameta "github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/apex/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -137,9 +138,9 @@ func getWAFMode(ctx context.Context, m interface{}, configID int, version int, p
 	client := inst.Client(meta)
 	logger := meta.Log("APPSEC", "getWAFMode")
 
-	cacheKey := fmt.Sprintf("%s:%d:%d:%s", "getWAFMode", configID, version, policyID)
+	cacheKey := fmt.Sprintf("%s:%d:%d:%s:", "getWAFMode", configID, version, policyID)
 	getWAFModeResponse := &appsec.GetWAFModeResponse{}
-	if err := cache.Get(cache.BucketName(SubproviderName), cacheKey, getWAFModeResponse); err == nil {
+	if err := cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, getWAFModeResponse); err == nil {
 		logger.Debugf("returning wafMode %s for config/version/policy %d/%d/%s",
 			getWAFModeResponse.Mode, configID, version, policyID)
 		return getWAFModeResponse.Mode, nil
@@ -152,7 +153,7 @@ func getWAFMode(ctx context.Context, m interface{}, configID int, version int, p
 		getWAFModeMutex.Unlock()
 	}()
 
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, getWAFModeResponse)
+	err := cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, getWAFModeResponse)
 	if err == nil {
 		logger.Debugf("returning wafMode %s for config/version/policy %d/%d/%s",
 			getWAFModeResponse.Mode, configID, version, policyID)
@@ -174,7 +175,7 @@ func getWAFMode(ctx context.Context, m interface{}, configID int, version int, p
 		logger.Errorf("calling 'GetWAFMode': %s", err.Error())
 		return "", err
 	}
-	if err := cache.Set(cache.BucketName(SubproviderName), cacheKey, wafMode); err != nil {
+	if err := cache.Set(ctx, cache.BucketName(SubproviderName), cacheKey, wafMode); err != nil {
 		if !errors.Is(err, cache.ErrDisabled) {
 			logger.Errorf("error caching WAFMode: %s", err.Error())
 		}
@@ -182,6 +183,12 @@ func getWAFMode(ctx context.Context, m interface{}, configID int, version int, p
 	return wafMode.Mode, nil
 }
 
+// invalidateWAFModeCache removes the cached WAF mode of the given security policy. It has to be called
+// after every operation which changes the WAF mode, creates or removes the security policy.
+func invalidateWAFModeCache(configID, version int, policyID string, logger log.Interface) {
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:%d:%s:", "getWAFMode", configID, version, policyID), logger)
+}
+
 func resourceRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	meta := akameta.Must(m)
 	client := inst.Client(meta)
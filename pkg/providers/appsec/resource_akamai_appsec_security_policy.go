@@ -114,6 +114,7 @@ func resourceSecurityPolicyCreate(ctx context.Context, d *schema.ResourceData, m
 			logger.Errorf("calling 'createSecurityPolicyClone': %s", err.Error())
 			return diag.FromErr(err)
 		}
+		invalidateWAFModeCache(configID, version, spcr.PolicyID, logger)
 
 		d.SetId(fmt.Sprintf("%d:%s", createSecurityPolicyClone.ConfigID, spcr.PolicyID))
 
@@ -131,6 +132,7 @@ func resourceSecurityPolicyCreate(ctx context.Context, d *schema.ResourceData, m
 			logger.Errorf("calling 'createSecurityPolicy': %s", err.Error())
 			return diag.FromErr(err)
 		}
+		invalidateWAFModeCache(configID, version, spcr.PolicyID, logger)
 		if err := d.Set("security_policy_id", spcr.PolicyID); err != nil {
 			return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
 		}
@@ -287,6 +289,7 @@ func resourceSecurityPolicyDelete(ctx context.Context, d *schema.ResourceData, m
 			logger.Errorf("calling 'removeSecurityPolicy': %s", err.Error())
 			return diag.FromErr(err)
 		}
+		invalidateWAFModeCache(configID, version, securityPolicyID, logger)
 	}
 
 	return nil
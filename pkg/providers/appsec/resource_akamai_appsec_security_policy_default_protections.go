@@ -86,6 +86,7 @@ func resourceSecurityPolicyDefaultProtectionsCreate(ctx context.Context, d *sche
 		logger.Errorf("calling 'createSecurityPolicyWithDefaultProtections': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateWAFModeCache(configID, version, response.PolicyID, logger)
 	if err := d.Set("security_policy_id", response.PolicyID); err != nil {
 		return diag.Errorf("%s: %s", tf.ErrValueSet, err.Error())
 	}
@@ -236,6 +237,7 @@ func resourceSecurityPolicyDefaultProtectionsDelete(ctx context.Context, d *sche
 		logger.Errorf("calling 'removeSecurityPolicy': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateWAFModeCache(configID, version, securityPolicyID, logger)
 
 	return nil
 }
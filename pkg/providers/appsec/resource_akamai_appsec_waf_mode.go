@@ -116,6 +116,7 @@ func resourceWAFModeCreate(ctx context.Context, d *schema.ResourceData, m interf
 		logger.Errorf("calling 'createWAFMode': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateWAFModeCache(createWAFMode.ConfigID, createWAFMode.Version, createWAFMode.PolicyID, logger)
 
 	d.SetId(fmt.Sprintf("%d:%s", createWAFMode.ConfigID, createWAFMode.PolicyID))
 
@@ -224,6 +225,7 @@ func resourceWAFModeUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		logger.Errorf("calling 'updateWAFMode': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	invalidateWAFModeCache(updateWAFMode.ConfigID, updateWAFMode.Version, updateWAFMode.PolicyID, logger)
 
 	return resourceWAFModeRead(ctx, d, m)
 }
@@ -28,9 +28,9 @@ func getBotDetectionAction(ctx context.Context, request botman.GetBotDetectionAc
 	client := inst.Client(meta)
 	logger := meta.Log("BotMan", "getBotDetectionAction")
 
-	cacheKey := fmt.Sprintf("%s:%d:%d:%s", "getBotDetectionAction", request.ConfigID, request.Version, request.SecurityPolicyID)
+	cacheKey := fmt.Sprintf("%s:%d:%d:%s:", "getBotDetectionAction", request.ConfigID, request.Version, request.SecurityPolicyID)
 	botDetectionActions := &botman.GetBotDetectionActionListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, botDetectionActions)
+	err := cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, botDetectionActions)
 	// if cache is disabled use GetBotDetectionAction to fetch one action at a time
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetBotDetectionAction(ctx, request)
@@ -45,7 +45,7 @@ func getBotDetectionAction(ctx context.Context, request botman.GetBotDetectionAc
 		botDetectionActionMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, botDetectionActions)
+	err = cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, botDetectionActions)
 	if err == nil {
 		return filterBotDetectionAction(botDetectionActions, request, logger)
 	}
@@ -65,7 +65,7 @@ func getBotDetectionAction(ctx context.Context, request botman.GetBotDetectionAc
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, botDetectionActions)
+	err = cache.Set(ctx, cache.BucketName(SubproviderName), cacheKey, botDetectionActions)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching botDetectionActions into cache: %s", err.Error())
 		return nil, err
@@ -90,9 +90,9 @@ func getCustomBotCategoryAction(ctx context.Context, request botman.GetCustomBot
 	client := inst.Client(meta)
 	logger := meta.Log("BotMan", "getCustomBotCategoryAction")
 
-	cacheKey := fmt.Sprintf("%s:%d:%d:%s", "getCustomBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID)
+	cacheKey := fmt.Sprintf("%s:%d:%d:%s:", "getCustomBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID)
 	customBotCategoryActions := &botman.GetCustomBotCategoryActionListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, customBotCategoryActions)
+	err := cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, customBotCategoryActions)
 	// if cache is disabled use GetCustomBotCategoryAction to fetch one action at a time
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetCustomBotCategoryAction(ctx, request)
@@ -107,7 +107,7 @@ func getCustomBotCategoryAction(ctx context.Context, request botman.GetCustomBot
 		customBotCategoryActionMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, customBotCategoryActions)
+	err = cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, customBotCategoryActions)
 	if err == nil {
 		return filterCustomBotCategoryAction(customBotCategoryActions, request, logger)
 	}
@@ -127,7 +127,7 @@ func getCustomBotCategoryAction(ctx context.Context, request botman.GetCustomBot
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, customBotCategoryActions)
+	err = cache.Set(ctx, cache.BucketName(SubproviderName), cacheKey, customBotCategoryActions)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching customBotCategoryActions into cache: %s", err.Error())
 		return nil, err
@@ -152,9 +152,9 @@ func getAkamaiBotCategoryAction(ctx context.Context, request botman.GetAkamaiBot
 	client := inst.Client(meta)
 	logger := meta.Log("BotMan", "getAkamaiBotCategoryAction")
 
-	cacheKey := fmt.Sprintf("%s:%d:%d:%s", "getAkamaiBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID)
+	cacheKey := fmt.Sprintf("%s:%d:%d:%s:", "getAkamaiBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID)
 	akamaiBotCategoryActions := &botman.GetAkamaiBotCategoryActionListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryActions)
+	err := cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryActions)
 	// if cache is disabled use GetAkamaiBotCategoryAction to fetch one action at a time
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetAkamaiBotCategoryAction(ctx, request)
@@ -169,7 +169,7 @@ func getAkamaiBotCategoryAction(ctx context.Context, request botman.GetAkamaiBot
 		akamaiBotCategoryActionMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryActions)
+	err = cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryActions)
 	if err == nil {
 		return filterAkamaiBotCategoryAction(akamaiBotCategoryActions, request, logger)
 	}
@@ -189,7 +189,7 @@ func getAkamaiBotCategoryAction(ctx context.Context, request botman.GetAkamaiBot
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryActions)
+	err = cache.Set(ctx, cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryActions)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching akamaiBotCategoryActions into cache: %s", err.Error())
 		return nil, err
@@ -214,9 +214,9 @@ func getTransactionalEndpoint(ctx context.Context, request botman.GetTransaction
 	client := inst.Client(meta)
 	logger := meta.Log("BotMan", "getTransactionalEndpoint")
 
-	cacheKey := fmt.Sprintf("%s:%d:%d:%s", "getTransactionalEndpoint", request.ConfigID, request.Version, request.SecurityPolicyID)
+	cacheKey := fmt.Sprintf("%s:%d:%d:%s:", "getTransactionalEndpoint", request.ConfigID, request.Version, request.SecurityPolicyID)
 	transactionalEndpoints := &botman.GetTransactionalEndpointListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, transactionalEndpoints)
+	err := cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, transactionalEndpoints)
 	// if cache is disabled use GetTransactionalEndpoint to fetch one action at a time
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetTransactionalEndpoint(ctx, request)
@@ -231,7 +231,7 @@ func getTransactionalEndpoint(ctx context.Context, request botman.GetTransaction
 		transactionalEndpointMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, transactionalEndpoints)
+	err = cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, transactionalEndpoints)
 	if err == nil {
 		return filterTransactionalEndpoint(transactionalEndpoints, request, logger)
 	}
@@ -251,7 +251,7 @@ func getTransactionalEndpoint(ctx context.Context, request botman.GetTransaction
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, transactionalEndpoints)
+	err = cache.Set(ctx, cache.BucketName(SubproviderName), cacheKey, transactionalEndpoints)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching transactionalEndpoints into cache: %s", err.Error())
 		return nil, err
@@ -277,7 +277,7 @@ func getAkamaiBotCategoryList(ctx context.Context, request botman.GetAkamaiBotCa
 
 	cacheKey := fmt.Sprintf("%s", "getAkamaiBotCategory")
 	akamaiBotCategoryList := &botman.GetAkamaiBotCategoryListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryList)
+	err := cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryList)
 	// if cache is disabled make a direct all to GetAkamaiBotCategoryList
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetAkamaiBotCategoryList(ctx, request)
@@ -292,7 +292,7 @@ func getAkamaiBotCategoryList(ctx context.Context, request botman.GetAkamaiBotCa
 		akamaiBotCategoryMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryList)
+	err = cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryList)
 	if err == nil {
 		return filterAkamaiBotCategoryList(akamaiBotCategoryList, request), nil
 	}
@@ -309,7 +309,7 @@ func getAkamaiBotCategoryList(ctx context.Context, request botman.GetAkamaiBotCa
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryList)
+	err = cache.Set(ctx, cache.BucketName(SubproviderName), cacheKey, akamaiBotCategoryList)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching akamaiBotCategoryList into cache: %s", err.Error())
 		return nil, err
@@ -339,7 +339,7 @@ func getAkamaiDefinedBotList(ctx context.Context, request botman.GetAkamaiDefine
 
 	cacheKey := fmt.Sprintf("%s", "getAkamaiDefinedBot")
 	akamaiDefinedBotList := &botman.GetAkamaiDefinedBotListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, akamaiDefinedBotList)
+	err := cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, akamaiDefinedBotList)
 	// if cache is disabled make a direct all to GetAkamaiDefinedBotList
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetAkamaiDefinedBotList(ctx, request)
@@ -354,7 +354,7 @@ func getAkamaiDefinedBotList(ctx context.Context, request botman.GetAkamaiDefine
 		akamaiDefinedBotMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, akamaiDefinedBotList)
+	err = cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, akamaiDefinedBotList)
 	if err == nil {
 		return filterAkamaiDefinedBotList(akamaiDefinedBotList, request), nil
 	}
@@ -371,7 +371,7 @@ func getAkamaiDefinedBotList(ctx context.Context, request botman.GetAkamaiDefine
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, akamaiDefinedBotList)
+	err = cache.Set(ctx, cache.BucketName(SubproviderName), cacheKey, akamaiDefinedBotList)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching akamaiDefinedBotList into cache: %s", err.Error())
 		return nil, err
@@ -400,7 +400,7 @@ func getBotDetectionList(ctx context.Context, request botman.GetBotDetectionList
 
 	cacheKey := fmt.Sprintf("%s", "getBotDetection")
 	botDetectionList := &botman.GetBotDetectionListResponse{}
-	err := cache.Get(cache.BucketName(SubproviderName), cacheKey, botDetectionList)
+	err := cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, botDetectionList)
 	// if cache is disabled make a direct all to GetBotDetectionList
 	if errors.Is(err, cache.ErrDisabled) {
 		return client.GetBotDetectionList(ctx, request)
@@ -415,7 +415,7 @@ func getBotDetectionList(ctx context.Context, request botman.GetBotDetectionList
 		botDetectionMutex.Unlock()
 	}()
 
-	err = cache.Get(cache.BucketName(SubproviderName), cacheKey, botDetectionList)
+	err = cache.Get(ctx, cache.BucketName(SubproviderName), cacheKey, botDetectionList)
 	if err == nil {
 		return filterBotDetectionList(botDetectionList, request), nil
 	}
@@ -432,7 +432,7 @@ func getBotDetectionList(ctx context.Context, request botman.GetBotDetectionList
 		return nil, err
 	}
 
-	err = cache.Set(cache.BucketName(SubproviderName), cacheKey, botDetectionList)
+	err = cache.Set(ctx, cache.BucketName(SubproviderName), cacheKey, botDetectionList)
 	if err != nil && !errors.Is(err, cache.ErrDisabled) {
 		logger.Errorf("error caching botDetectionList into cache: %s", err.Error())
 		return nil, err
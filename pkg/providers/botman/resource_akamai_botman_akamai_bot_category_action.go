@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/botman"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -96,6 +97,7 @@ func resourceAkamaiBotCategoryActionCreate(ctx context.Context, d *schema.Resour
 		logger.Errorf("calling 'UpdateAkamaiBotCategoryAction': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:%d:%s:", "getAkamaiBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID), logger)
 
 	d.SetId(fmt.Sprintf("%d:%s:%s", configID, securityPolicyID, categoryID))
 
@@ -216,6 +218,7 @@ func resourceAkamaiBotCategoryActionUpdate(ctx context.Context, d *schema.Resour
 		logger.Errorf("calling 'UpdateAkamaiBotCategoryAction': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:%d:%s:", "getAkamaiBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID), logger)
 
 	return akamaiBotCategoryActionRead(ctx, d, m, false)
 }
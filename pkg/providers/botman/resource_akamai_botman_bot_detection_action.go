@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/botman"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -97,6 +98,7 @@ func resourceBotDetectionActionCreate(ctx context.Context, d *schema.ResourceDat
 		logger.Errorf("calling 'request': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:%d:%s:", "getBotDetectionAction", request.ConfigID, request.Version, request.SecurityPolicyID), logger)
 
 	d.SetId(fmt.Sprintf("%d:%s:%s", configID, securityPolicyID, detectionID))
 
@@ -214,6 +216,7 @@ func resourceBotDetectionActionUpdate(ctx context.Context, d *schema.ResourceDat
 		logger.Errorf("calling 'request': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:%d:%s:", "getBotDetectionAction", request.ConfigID, request.Version, request.SecurityPolicyID), logger)
 
 	return botDetectionActionRead(ctx, d, m, false)
 }
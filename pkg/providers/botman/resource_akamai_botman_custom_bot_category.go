@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/botman"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
@@ -80,6 +81,8 @@ func resourceCustomBotCategoryCreate(ctx context.Context, d *schema.ResourceData
 		logger.Errorf("calling 'request': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	// A new category gets an action in every security policy of the configuration.
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:%d:", "getCustomBotCategoryAction", configID, version), logger)
 
 	d.SetId(fmt.Sprintf("%d:%s", configID, str.From((response)["categoryId"])))
 
@@ -222,5 +225,6 @@ func resourceCustomBotCategoryDelete(ctx context.Context, d *schema.ResourceData
 		logger.Errorf("calling 'removeCustomBotCategory': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:%d:", "getCustomBotCategoryAction", configID, version), logger)
 	return nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/botman"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -98,6 +99,7 @@ func resourceCustomBotCategoryActionCreate(ctx context.Context, d *schema.Resour
 		logger.Errorf("calling 'request': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:%d:%s:", "getCustomBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID), logger)
 
 	d.SetId(fmt.Sprintf("%d:%s:%s", configID, securityPolicyID, categoryID))
 
@@ -216,6 +218,7 @@ func resourceCustomBotCategoryActionUpdate(ctx context.Context, d *schema.Resour
 		logger.Errorf("calling 'request': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:%d:%s:", "getCustomBotCategoryAction", request.ConfigID, request.Version, request.SecurityPolicyID), logger)
 
 	return customBotCategoryActionRead(ctx, d, m, false)
 }
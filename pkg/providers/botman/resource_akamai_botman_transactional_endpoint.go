@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/botman"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/cache"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -95,6 +96,7 @@ func resourceTransactionalEndpointCreate(ctx context.Context, d *schema.Resource
 		logger.Errorf("calling 'CreateTransactionalEndpoint': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:%d:%s:", "getTransactionalEndpoint", request.ConfigID, request.Version, request.SecurityPolicyID), logger)
 
 	d.SetId(fmt.Sprintf("%d:%s:%s", configID, securityPolicyID, (response)["operationId"]))
 
@@ -223,6 +225,7 @@ func resourceTransactionalEndpointUpdate(ctx context.Context, d *schema.Resource
 		logger.Errorf("calling 'UpdateTransactionalEndpoint': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:%d:%s:", "getTransactionalEndpoint", request.ConfigID, request.Version, request.SecurityPolicyID), logger)
 
 	return transactionalEndpointRead(ctx, d, m, false)
 }
@@ -264,5 +267,6 @@ func resourceTransactionalEndpointDelete(ctx context.Context, d *schema.Resource
 		logger.Errorf("calling 'RemoveTransactionalEndpoint': %s", err.Error())
 		return diag.FromErr(err)
 	}
+	cache.TryInvalidate(cache.BucketName(SubproviderName), fmt.Sprintf("%s:%d:%d:%s:", "getTransactionalEndpoint", request.ConfigID, request.Version, request.SecurityPolicyID), logger)
 	return nil
 }
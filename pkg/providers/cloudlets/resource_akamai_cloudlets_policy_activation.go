@@ -18,6 +18,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -406,6 +407,9 @@ func getActiveProperties(policyActivations []cloudlets.PolicyActivation) []strin
 
 // waitForPolicyActivation polls server until the activation has active status or until context is closed (because of timeout, cancellation or context termination)
 func waitForPolicyActivation(ctx context.Context, client cloudlets.Cloudlets, policyID, version int64, network cloudlets.PolicyActivationNetwork, additionalProps, removedProperties []string) ([]cloudlets.PolicyActivation, error) {
+	ctx, span := tracing.StartSpan(ctx, "cloudlets.waitForPolicyActivation")
+	defer span.End()
+
 	activations, err := waitForListPolicyActivations(ctx, client, cloudlets.ListPolicyActivationsRequest{
 		PolicyID: policyID,
 		Network:  network,
@@ -586,6 +590,9 @@ activePropertiesLoop:
 }
 
 func waitForNotPendingPolicyActivation(ctx context.Context, logger log.Interface, client cloudlets.Cloudlets, policyID int64, network cloudlets.PolicyActivationNetwork) error {
+	ctx, span := tracing.StartSpan(ctx, "cloudlets.waitForNotPendingPolicyActivation")
+	defer span.End()
+
 	logger.Debugf("waiting until there none of the policy (ID=%d) activations are in pending state", policyID)
 	activations, err := waitForListPolicyActivations(ctx, client, cloudlets.ListPolicyActivationsRequest{PolicyID: policyID})
 	if err != nil {
@@ -631,6 +638,9 @@ func waitForNotPendingPolicyActivation(ctx context.Context, logger log.Interface
 
 // waitForListPolicyActivations polls server until the ListPolicyActivations returns non-empty list
 func waitForListPolicyActivations(ctx context.Context, client cloudlets.Cloudlets, listPolicyActivationsRequest cloudlets.ListPolicyActivationsRequest) ([]cloudlets.PolicyActivation, error) {
+	ctx, span := tracing.StartSpan(ctx, "cloudlets.waitForListPolicyActivations")
+	defer span.End()
+
 	listActivationsPollRetries := MaxListActivationsPollRetries
 	activations, err := client.ListPolicyActivations(ctx, listPolicyActivationsRequest)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/cloudwrapper"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -216,6 +217,9 @@ func (a *activationResource) readStateFromAPI(ctx context.Context, model activat
 }
 
 func (a *activationResource) waitUntilActivationCompleted(ctx context.Context, configID int, timeout time.Duration) diag.Diagnostics {
+	ctx, span := tracing.StartSpan(ctx, "cloudwrapper.waitForActivation")
+	defer span.End()
+
 	var diags diag.Diagnostics
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
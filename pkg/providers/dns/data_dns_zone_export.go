@@ -0,0 +1,128 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/dns/internal/txtrecord"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/dns/internal/zonefile"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// zoneExport is the JSON representation of the exported zone
+type zoneExport struct {
+	Zone       string          `json:"zone"`
+	RecordSets []dns.RecordSet `json:"recordsets"`
+}
+
+func dataSourceDNSZoneExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDNSZoneExportRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+				Description:      "The name of the zone to export",
+			},
+			"zone_file": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The record sets of the zone in the BIND master file format, with fully qualified names and in canonical order",
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The record sets of the zone as JSON, in the same order and representation as in 'zone_file'",
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("AkamaiDNS", "dataSourceDNSZoneExportRead")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	zone, err := tf.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.Debugf("Reading record sets of zone %s", zone)
+	recordsets, err := getZoneRecordsets(ctx, meta, zone)
+	if err != nil {
+		return diag.Errorf("reading record sets of zone %q: %s", zone, err)
+	}
+	recordsets, err = canonicalRecordsets(ctx, meta, recordsets)
+	if err != nil {
+		return diag.Errorf("exporting zone %q: %s", zone, err)
+	}
+
+	records := make([]zonefile.Record, 0, len(recordsets))
+	for _, rs := range recordsets {
+		for _, rdata := range rs.Rdata {
+			records = append(records, zonefile.Record{Name: rs.Name, Type: rs.Type, TTL: rs.TTL, Rdata: []string{rdata}})
+		}
+	}
+	if err := d.Set("zone_file", zonefile.Format(records)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error()))
+	}
+
+	export, err := json.MarshalIndent(zoneExport{Zone: zone, RecordSets: recordsets}, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("json", string(export)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error()))
+	}
+	d.SetId(zone)
+	return nil
+}
+
+// canonicalRecordsets returns the record sets with their records normalized the way akamai_dns_record reads them
+// and sorted, ordering the record sets by name in canonical order and then by type, with SOA first
+func canonicalRecordsets(ctx context.Context, meta meta.Meta, recordsets []dns.RecordSet) ([]dns.RecordSet, error) {
+	canonical := make([]dns.RecordSet, 0, len(recordsets))
+	for _, rs := range recordsets {
+		rs.Name = strings.ToLower(strings.TrimSuffix(rs.Name, "."))
+		rs.Type = strings.ToUpper(rs.Type)
+		rdata := make([]string, 0, len(rs.Rdata))
+		for _, target := range inst.Client(meta).ProcessRdata(ctx, rs.Rdata, rs.Type) {
+			if rs.Type == RRTypeTxt || rs.Type == RRTypeSpf {
+				normalized, err := txtrecord.NormalizeTarget(target)
+				if err != nil {
+					return nil, fmt.Errorf("record set %s %s: %w", rs.Name, rs.Type, err)
+				}
+				target = normalized
+			}
+			canonicalTarget, err := zonefile.CanonicalRdata(rs.Type, target)
+			if err != nil {
+				return nil, fmt.Errorf("record set %s %s: %w", rs.Name, rs.Type, err)
+			}
+			rdata = append(rdata, canonicalTarget)
+		}
+		sort.Strings(rdata)
+		rs.Rdata = rdata
+		canonical = append(canonical, rs)
+	}
+
+	sort.SliceStable(canonical, func(i, j int) bool {
+		if c := zonefile.CompareNames(canonical[i].Name, canonical[j].Name); c != 0 {
+			return c < 0
+		}
+		if canonical[i].Type == RRTypeSoa || canonical[j].Type == RRTypeSoa {
+			return canonical[i].Type == RRTypeSoa && canonical[j].Type != RRTypeSoa
+		}
+		return canonical[i].Type < canonical[j].Type
+	})
+	return canonical, nil
+}
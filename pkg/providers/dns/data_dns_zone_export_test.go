@@ -0,0 +1,93 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockProcessRdata makes the mocked ProcessRdata process the record data with the edgegrid client
+func mockProcessRdata(client *dns.Mock) {
+	dnsClient := dns.Client(session.Must(session.New()))
+	processCall := client.On("ProcessRdata", mock.Anything, mock.AnythingOfType("[]string"), mock.AnythingOfType("string"))
+	processCall.Run(func(args mock.Arguments) {
+		processCall.ReturnArguments = mock.Arguments{
+			dnsClient.ProcessRdata(context.Background(), args.Get(1).([]string), args.String(2)),
+		}
+	})
+}
+
+func TestDataSourceDNSZoneExport(t *testing.T) {
+	dataSourceName := "data.akamai_dns_zone_export.test"
+
+	t.Run("basic", func(t *testing.T) {
+		client := &dns.Mock{}
+		client.On("GetRecordSets", mock.Anything, "example.com", []dns.RecordSetQueryArgs{{ShowAll: true}}).Return(&dns.RecordSetResponse{
+			RecordSets: []dns.RecordSet{
+				{Name: "www.example.com", Type: "AAAA", TTL: 300, Rdata: []string{"2001:db8::1"}},
+				{Name: "WWW.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.2", "10.0.0.1"}},
+				{Name: "example.com", Type: "NS", TTL: 86400, Rdata: []string{"A1-49.AKAM.NET.", "a7-67.akam.net."}},
+				{Name: "example.com", Type: "SOA", TTL: 86400, Rdata: []string{"a1-49.akam.net. hostmaster.example.com. 2024010101 3600 600 604800 300"}},
+				{Name: "ftp.example.com", Type: "CNAME", TTL: 600, Rdata: []string{"www.example.com"}},
+				{Name: "example.com", Type: "MX", TTL: 3600, Rdata: []string{"10 mail.example.com"}},
+				{Name: "example.com", Type: "TXT", TTL: 3600, Rdata: []string{`"v=spf1 -all"`}},
+			},
+		}, nil)
+		mockProcessRdata(client)
+
+		zoneFile := "example.com.\t86400\tIN\tSOA\ta1-49.akam.net. hostmaster.example.com. 2024010101 3600 600 604800 300\n" +
+			"example.com.\t3600\tIN\tMX\t10 mail.example.com.\n" +
+			"example.com.\t86400\tIN\tNS\ta1-49.akam.net.\n" +
+			"example.com.\t86400\tIN\tNS\ta7-67.akam.net.\n" +
+			"example.com.\t3600\tIN\tTXT\t\"v=spf1 -all\"\n" +
+			"ftp.example.com.\t600\tIN\tCNAME\twww.example.com.\n" +
+			"www.example.com.\t300\tIN\tA\t10.0.0.1\n" +
+			"www.example.com.\t300\tIN\tA\t10.0.0.2\n" +
+			"www.example.com.\t300\tIN\tAAAA\t2001:0db8:0000:0000:0000:0000:0000:0001\n"
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestDataDnsZoneExport/basic.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr(dataSourceName, "id", "example.com"),
+							resource.TestCheckResourceAttr(dataSourceName, "zone_file", zoneFile),
+							resource.TestMatchResourceAttr(dataSourceName, "json", regexp.MustCompile(`^\{\n  "zone": "example.com",\n  "recordsets": \[\n    \{\n      "name": "example.com",\n      "type": "SOA",`)),
+							resource.TestMatchResourceAttr(dataSourceName, "json", regexp.MustCompile(`"name": "www.example.com",\n      "type": "A",\n      "ttl": 300,\n      "rdata": \[\n        "10.0.0.1",\n        "10.0.0.2"\n      \]`)),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := &dns.Mock{}
+		client.On("GetRecordSets", mock.Anything, "example.com", []dns.RecordSetQueryArgs{{ShowAll: true}}).Return(nil, errors.New("zone not found"))
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestDataDnsZoneExport/basic.tf"),
+						ExpectError: regexp.MustCompile(`reading record sets of zone "example.com": zone not found`),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,267 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/dns/internal/zonefile"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// minRdataFields is the minimal number of fields in the record data of a record type
+var minRdataFields = map[string]int{
+	RRTypeAfsdb:      2,
+	RRTypeDnskey:     4,
+	RRTypeDs:         4,
+	RRTypeHinfo:      2,
+	RRTypeMx:         2,
+	RRTypeNaptr:      6,
+	RRTypeNsec3:      6,
+	RRTypeNsec3Param: 4,
+	RRTypeRp:         2,
+	RRTypeRrsig:      9,
+	RRTypeSrv:        4,
+	RRTypeSshfp:      3,
+	RRTypeSoa:        7,
+	RRTypeAkamaiTlc:  2,
+	RRTypeCaa:        3,
+	RRTypeCert:       4,
+	RRTypeTlsa:       4,
+	RRTypeSvcb:       2,
+	RRTypeHTTPS:      2,
+}
+
+func dataSourceDNSZoneFile() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDNSZoneFileRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+				Description:      "The name of the zone, which is the origin of relative names unless changed with $ORIGIN directive",
+			},
+			"content": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+				Description:      "The content of the zone file in the BIND master file format",
+			},
+			"recordsets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The record sets defined in the zone file, in the order of their first record",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The fully qualified name of the record set",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the record set",
+						},
+						"ttl": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The time to live of the record set in seconds, the lowest one of its records",
+						},
+						"rdata": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The records of the record set in the format used by the Edge DNS API",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneFileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("AkamaiDNS", "dataSourceDNSZoneFileRead")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	zone, err := tf.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	content, err := tf.GetStringValue("content", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.Debugf("Parsing zone file of zone %s", zone)
+	recordsets, err := parseZoneFile(ctx, meta, zone, content, logger)
+	if err != nil {
+		return diag.Errorf("parsing zone file of zone %q: %s", zone, err)
+	}
+
+	attrs := make([]interface{}, 0, len(recordsets))
+	for _, rs := range recordsets {
+		attrs = append(attrs, flattenRecordset(rs))
+	}
+	if err := d.Set("recordsets", attrs); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error()))
+	}
+	d.SetId(zone)
+	return nil
+}
+
+// parseZoneFile returns the record sets defined in the zone file, normalized and validated the way akamai_dns_record does it
+func parseZoneFile(ctx context.Context, meta meta.Meta, zone, content string, logger log.Interface) ([]dns.RecordSet, error) {
+	records, err := zonefile.Parse(content, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneName := strings.ToLower(strings.TrimSuffix(zone, "."))
+	recordsets := make([]dns.RecordSet, 0)
+	lines := make([]int, 0)
+	indexes := make(map[string]int)
+	for _, record := range records {
+		if record.Name != zoneName && !strings.HasSuffix(record.Name, "."+zoneName) {
+			return nil, fmt.Errorf("line %d: record %s %s is not within zone %s", record.Line, record.Name, record.Type, zone)
+		}
+		rdata := record.Rdata
+		if record.Type == RRTypeLoc {
+			if rdata, err = expandLocFields(rdata); err != nil {
+				return nil, fmt.Errorf("line %d: %s", record.Line, err)
+			}
+		}
+		if len(rdata) < minRdataFields[record.Type] {
+			return nil, fmt.Errorf("line %d: %s record requires at least %d fields", record.Line, record.Type, minRdataFields[record.Type])
+		}
+
+		rs := dns.RecordSet{Name: record.Name, Type: record.Type, TTL: record.TTL}
+		i, ok := indexes[recordsetKey(rs)]
+		if !ok {
+			i = len(recordsets)
+			indexes[recordsetKey(rs)] = i
+			recordsets = append(recordsets, rs)
+			lines = append(lines, record.Line)
+		}
+		if record.TTL < recordsets[i].TTL {
+			recordsets[i].TTL = record.TTL
+		}
+		recordsets[i].Rdata = append(recordsets[i].Rdata, strings.Join(rdata, " "))
+	}
+
+	for i, rs := range recordsets {
+		if err := validateRecordset(ctx, meta, &recordsets[i], logger); err != nil {
+			return nil, fmt.Errorf("line %d: record set %s %s: %w", lines[i], rs.Name, rs.Type, err)
+		}
+	}
+	return recordsets, nil
+}
+
+// validateRecordset normalizes the records of the record set and validates them
+// with the checks applied to akamai_dns_record resource
+func validateRecordset(ctx context.Context, meta meta.Meta, rs *dns.RecordSet, logger log.Interface) error {
+	supported := false
+	for _, recordType := range recordTypes {
+		supported = supported || recordType == rs.Type
+	}
+	if !supported {
+		return fmt.Errorf("record type %s is not supported", rs.Type)
+	}
+
+	target := make([]interface{}, 0, len(rs.Rdata))
+	for _, rdata := range rs.Rdata {
+		target = append(target, rdata)
+	}
+	rdata, err := buildRecordsList(target, rs.Type, logger)
+	if err != nil {
+		return err
+	}
+	rs.Rdata = rdata
+
+	fields := recordFields{"name": rs.Name, "recordtype": rs.Type, "ttl": rs.TTL}
+	for name, value := range inst.Client(meta).ParseRData(ctx, rs.Type, rs.Rdata) {
+		if list, ok := value.([]string); ok {
+			values := make([]interface{}, 0, len(list))
+			for _, v := range list {
+				values = append(values, v)
+			}
+			value = values
+		}
+		fields[name] = value
+	}
+	if rs.Type == RRTypeMx {
+		priority, err := strconv.Atoi(strings.Fields(rs.Rdata[0])[0])
+		if err != nil {
+			return fmt.Errorf("invalid MX priority: %s", rs.Rdata[0])
+		}
+		fields["priority"] = priority
+	}
+	return validateRecord(fields)
+}
+
+// expandLocFields returns all 12 fields of the LOC record, filling the omitted ones with their default values
+func expandLocFields(fields []string) ([]string, error) {
+	var latitude, longitude []string
+	i := 0
+	for ; i < len(fields) && len(latitude) < 4; i++ {
+		latitude = append(latitude, fields[i])
+		if fields[i] == "N" || fields[i] == "S" {
+			break
+		}
+	}
+	for i++; i < len(fields) && len(longitude) < 4; i++ {
+		longitude = append(longitude, fields[i])
+		if fields[i] == "E" || fields[i] == "W" {
+			break
+		}
+	}
+	rest := fields[min(i+1, len(fields)):]
+	if len(latitude) == 0 || len(longitude) == 0 || len(rest) == 0 || len(rest) > 4 ||
+		!strings.ContainsAny(latitude[len(latitude)-1], "NS") || !strings.ContainsAny(longitude[len(longitude)-1], "EW") {
+		return nil, errors.New("invalid LOC record")
+	}
+
+	expanded := make([]string, 0, 12)
+	for _, coordinate := range [][]string{latitude, longitude} {
+		values := append([]string{}, coordinate[:len(coordinate)-1]...)
+		for len(values) < 3 {
+			values = append(values, "0")
+		}
+		expanded = append(expanded, values...)
+		expanded = append(expanded, coordinate[len(coordinate)-1])
+	}
+	expanded = append(expanded, rest...)
+	expanded = append(expanded, []string{"0m", "1m", "10000m", "10m"}[len(rest):]...)
+	return expanded, nil
+}
+
+// recordFields provides the fields of a record set, named as in akamai_dns_record resource, to the record validation
+type recordFields map[string]interface{}
+
+// GetOk returns the value of the field and whether it is set to a non-zero value, like schema.ResourceData does
+func (f recordFields) GetOk(key string) (interface{}, bool) {
+	value, ok := f[key]
+	if !ok {
+		return nil, false
+	}
+	switch v := value.(type) {
+	case string:
+		return value, v != ""
+	case int:
+		return value, v != 0
+	case []interface{}:
+		return value, len(v) > 0
+	}
+	return value, true
+}
@@ -0,0 +1,143 @@
+package dns
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockParseRData makes the mocked ParseRData parse the record data with the edgegrid client
+func mockParseRData(client *dns.Mock) {
+	dnsClient := dns.Client(session.Must(session.New()))
+	parseCall := client.On("ParseRData", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("[]string"))
+	parseCall.Run(func(args mock.Arguments) {
+		parseCall.ReturnArguments = mock.Arguments{
+			dnsClient.ParseRData(context.Background(), args.String(1), args.Get(2).([]string)),
+		}
+	})
+}
+
+func TestDataSourceDNSZoneFile(t *testing.T) {
+	dataSourceName := "data.akamai_dns_zone_file.test"
+
+	t.Run("basic", func(t *testing.T) {
+		client := &dns.Mock{}
+		mockParseRData(client)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestDataDnsZoneFile/basic.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr(dataSourceName, "id", "example.com"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.#", "8"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.0.name", "example.com"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.0.type", "SOA"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.0.ttl", "3600"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.0.rdata.0", "ns1.example.net. hostmaster.example.com. 2024010101 3600 600 604800 300"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.1.type", "NS"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.1.rdata.0", "ns1.example.net."),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.2.name", "www.example.com"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.2.ttl", "300"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.2.rdata.#", "2"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.2.rdata.1", "10.0.0.2"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.3.rdata.0", "2001:0db8:0000:0000:0000:0000:0000:0001"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.4.name", "example.com"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.4.type", "MX"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.4.rdata.0", "10 mail.example.com."),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.4.rdata.1", "20 mail2.example.net."),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.5.name", "_sip._tcp.example.com"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.5.rdata.0", "10 60 5060 sip.example.com."),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.6.type", "TXT"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.6.rdata.0", `"v=spf1 include:_spf.example.com" "-all"`),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.7.type", "LOC"),
+							resource.TestCheckResourceAttr(dataSourceName, "recordsets.7.rdata.0", "52 22 23 N 4 53 32 E -2.00m 1.00m 10000.00m 10.00m"),
+						),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("invalid record", func(t *testing.T) {
+		client := &dns.Mock{}
+		mockParseRData(client)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestDataDnsZoneFile/invalid_record.tf"),
+						ExpectError: regexp.MustCompile(`line 3: record set _sip._tcp.example.com SRV: configuration argument port must be set for SRV`),
+					},
+				},
+			})
+		})
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		client := &dns.Mock{}
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestDataDnsZoneFile/syntax_error.tf"),
+						ExpectError: regexp.MustCompile(`syntax error: line 3: unbalanced parentheses`),
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
+
+func TestExpandLocFields(t *testing.T) {
+	tests := map[string]struct {
+		fields   []string
+		expected []string
+		withErr  bool
+	}{
+		"all fields": {
+			fields:   []string{"52", "22", "23.000", "N", "4", "53", "32.000", "E", "-2m", "0m", "10000m", "10m"},
+			expected: []string{"52", "22", "23.000", "N", "4", "53", "32.000", "E", "-2m", "0m", "10000m", "10m"},
+		},
+		"omitted minutes, seconds and precision": {
+			fields:   []string{"52", "N", "4", "53", "E", "10m", "2m"},
+			expected: []string{"52", "0", "0", "N", "4", "53", "0", "E", "10m", "2m", "10000m", "10m"},
+		},
+		"missing altitude": {
+			fields:  []string{"52", "22", "23", "N", "4", "53", "32", "E"},
+			withErr: true,
+		},
+		"missing direction": {
+			fields:  []string{"52", "22", "23", "4", "53", "32", "-2m"},
+			withErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fields, err := expandLocFields(test.fields)
+			if test.withErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, fields)
+		})
+	}
+}
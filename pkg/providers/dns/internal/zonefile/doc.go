@@ -0,0 +1,2 @@
+// Package zonefile contains logic used parsing zone files in the BIND master file format.
+package zonefile
@@ -0,0 +1,59 @@
+package zonefile
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format renders the records in the BIND master file format, one record per line with fully qualified names,
+// in the given order
+func Format(records []Record) string {
+	var b strings.Builder
+	for _, r := range records {
+		fmt.Fprintf(&b, "%s\t%d\tIN\t%s\t%s\n", absolute(r.Name), r.TTL, r.Type, strings.Join(r.Rdata, " "))
+	}
+	return b.String()
+}
+
+// CanonicalRdata returns the record data with its fields separated by single spaces
+// and the domain names fully qualified, in lower case and with the trailing dot
+func CanonicalRdata(recordType, rdata string) (string, error) {
+	entries, err := tokenize(rdata)
+	if err != nil {
+		return "", err
+	}
+	var fields []string
+	for _, e := range entries {
+		fields = append(fields, e.tokens...)
+	}
+	for _, i := range domainNameFields[strings.ToUpper(recordType)] {
+		if i < len(fields) {
+			fields[i] = absolute(fields[i])
+		}
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// absolute returns the name in lower case with the trailing dot
+func absolute(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+}
+
+// CompareNames compares the domain names in the canonical order of DNSSEC, i.e. label by label starting from the
+// rightmost one, and returns -1, 0 or +1 if a is less than, equal to or greater than b
+func CompareNames(a, b string) int {
+	aLabels := strings.Split(strings.ToLower(strings.TrimSuffix(a, ".")), ".")
+	bLabels := strings.Split(strings.ToLower(strings.TrimSuffix(b, ".")), ".")
+	for i, j := len(aLabels)-1, len(bLabels)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if c := strings.Compare(aLabels[i], bLabels[j]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(aLabels) < len(bLabels):
+		return -1
+	case len(aLabels) > len(bLabels):
+		return 1
+	}
+	return 0
+}
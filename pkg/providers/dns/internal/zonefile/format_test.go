@@ -0,0 +1,80 @@
+package zonefile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	records := []Record{
+		{Name: "example.com", Type: "SOA", TTL: 3600, Rdata: []string{"ns1.example.net. hostmaster.example.com. 1 3600 600 604800 300"}},
+		{Name: "txt.example.com", Type: "TXT", TTL: 300, Rdata: []string{`"first; chunk"`, `"second"`}},
+	}
+	content := Format(records)
+	assert.Equal(t, "example.com.\t3600\tIN\tSOA\tns1.example.net. hostmaster.example.com. 1 3600 600 604800 300\n"+
+		"txt.example.com.\t300\tIN\tTXT\t\"first; chunk\" \"second\"\n", content)
+
+	// the formatted zone file is read back unchanged
+	parsed, err := Parse(content, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []Record{
+		{Name: "example.com", Type: "SOA", TTL: 3600, Rdata: []string{"ns1.example.net.", "hostmaster.example.com.", "1", "3600", "600", "604800", "300"}, Line: 1},
+		{Name: "txt.example.com", Type: "TXT", TTL: 300, Rdata: []string{`"first; chunk"`, `"second"`}, Line: 2},
+	}, parsed)
+}
+
+func TestCanonicalRdata(t *testing.T) {
+	tests := map[string]struct {
+		recordType string
+		rdata      string
+		expected   string
+		withError  bool
+	}{
+		"domain names qualified": {
+			recordType: "MX",
+			rdata:      "10   Mail.Example.com",
+			expected:   "10 mail.example.com.",
+		},
+		"root target kept": {
+			recordType: "SVCB",
+			rdata:      "1 . alpn=h2",
+			expected:   "1 . alpn=h2",
+		},
+		"quoted strings kept": {
+			recordType: "txt",
+			rdata:      `"a  b"   "c"`,
+			expected:   `"a  b" "c"`,
+		},
+		"other types unchanged": {
+			recordType: "A",
+			rdata:      "10.0.0.1",
+			expected:   "10.0.0.1",
+		},
+		"unterminated quoted string": {
+			recordType: "TXT",
+			rdata:      `"abc`,
+			withError:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rdata, err := CanonicalRdata(test.recordType, test.rdata)
+			if test.withError {
+				assert.ErrorIs(t, err, ErrSyntax)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, rdata)
+		})
+	}
+}
+
+func TestCompareNames(t *testing.T) {
+	assert.Equal(t, 0, CompareNames("Example.com.", "example.com"))
+	assert.Equal(t, -1, CompareNames("example.com", "a.example.com"))
+	assert.Equal(t, -1, CompareNames("z.a.example.com", "b.example.com"))
+	assert.Equal(t, 1, CompareNames("example.org", "z.example.com"))
+}
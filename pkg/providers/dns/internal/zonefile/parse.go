@@ -0,0 +1,289 @@
+package zonefile
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Record is a resource record read from a zone file
+type Record struct {
+	// Name is the fully qualified owner name of the record, without the trailing dot
+	Name string
+	// Type is the record type in upper case
+	Type string
+	TTL  int
+	// Rdata are the fields of the record data. Quoted character strings keep their quotes
+	// and domain names are fully qualified, with the trailing dot
+	Rdata []string
+	// Line is the line of the zone file the record starts at
+	Line int
+}
+
+var (
+	// ErrSyntax is returned when the zone file is malformed
+	ErrSyntax = errors.New("syntax error")
+	// ErrUnsupported is returned when the zone file uses unsupported directives
+	ErrUnsupported = errors.New("unsupported directive")
+)
+
+// domainNameFields are the indexes of the fields holding domain names in the record data of a record type
+var domainNameFields = map[string][]int{
+	"CNAME": {0},
+	"NS":    {0},
+	"PTR":   {0},
+	"MX":    {1},
+	"AFSDB": {1},
+	"RP":    {0, 1},
+	"SRV":   {3},
+	"SOA":   {0, 1},
+	"NAPTR": {5},
+	"RRSIG": {7},
+	"SVCB":  {1},
+	"HTTPS": {1},
+}
+
+var classes = map[string]bool{"IN": true, "CH": true, "HS": true, "CS": true}
+
+// entry is a logical line of the zone file, with the parentheses resolved
+type entry struct {
+	tokens []string
+	line   int
+	// continued is true when the entry starts with a blank, i.e. it has the owner of the previous entry
+	continued bool
+}
+
+// Parse reads the records of the zone file, resolving relative names against the origin,
+// which is the name of the zone unless changed with $ORIGIN directive
+func Parse(content, origin string) ([]Record, error) {
+	entries, err := tokenize(content)
+	if err != nil {
+		return nil, err
+	}
+
+	origin = strings.ToLower(strings.TrimSuffix(origin, "."))
+	var records []Record
+	var owner string
+	defaultTTL, lastTTL := -1, -1
+	for _, e := range entries {
+		if strings.HasPrefix(e.tokens[0], "$") && !e.continued {
+			switch directive := strings.ToUpper(e.tokens[0]); directive {
+			case "$ORIGIN":
+				if len(e.tokens) != 2 {
+					return nil, fmt.Errorf("%w: line %d: $ORIGIN requires one domain name", ErrSyntax, e.line)
+				}
+				if origin, err = qualify(e.tokens[1], origin); err != nil {
+					return nil, fmt.Errorf("%w: line %d: %s", ErrSyntax, e.line, err)
+				}
+			case "$TTL":
+				if len(e.tokens) != 2 {
+					return nil, fmt.Errorf("%w: line %d: $TTL requires one value", ErrSyntax, e.line)
+				}
+				if defaultTTL, err = parseTTL(e.tokens[1]); err != nil {
+					return nil, fmt.Errorf("%w: line %d: %s", ErrSyntax, e.line, err)
+				}
+			default:
+				return nil, fmt.Errorf("%w: line %d: %s", ErrUnsupported, e.line, directive)
+			}
+			continue
+		}
+
+		tokens := e.tokens
+		if !e.continued {
+			if owner, err = qualify(tokens[0], origin); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %s", ErrSyntax, e.line, err)
+			}
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("%w: line %d: record has no owner name", ErrSyntax, e.line)
+		}
+
+		ttl := -1
+		for i := 0; i < 2 && len(tokens) > 0; i++ {
+			if classes[strings.ToUpper(tokens[0])] {
+				tokens = tokens[1:]
+			} else if value, err := parseTTL(tokens[0]); err == nil && ttl < 0 {
+				ttl, tokens = value, tokens[1:]
+			}
+		}
+		if len(tokens) < 2 {
+			return nil, fmt.Errorf("%w: line %d: record requires a type and data", ErrSyntax, e.line)
+		}
+
+		switch {
+		case ttl >= 0:
+			lastTTL = ttl
+		case defaultTTL >= 0:
+			ttl = defaultTTL
+		case lastTTL >= 0:
+			ttl = lastTTL
+		default:
+			return nil, fmt.Errorf("%w: line %d: record has no TTL and no $TTL directive precedes it", ErrSyntax, e.line)
+		}
+
+		record := Record{
+			Name:  owner,
+			Type:  strings.ToUpper(tokens[0]),
+			TTL:   ttl,
+			Rdata: tokens[1:],
+			Line:  e.line,
+		}
+		for _, i := range domainNameFields[record.Type] {
+			if i >= len(record.Rdata) {
+				break
+			}
+			name, err := qualify(record.Rdata[i], origin)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %s", ErrSyntax, e.line, err)
+			}
+			record.Rdata[i] = name + "."
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// qualify returns the fully qualified name, without the trailing dot
+func qualify(name, origin string) (string, error) {
+	switch {
+	case name == "@":
+		if origin == "" {
+			return "", fmt.Errorf("'@' used without origin")
+		}
+		return origin, nil
+	case name == ".":
+		return "", nil
+	case strings.HasSuffix(name, "."):
+		return strings.ToLower(strings.TrimSuffix(name, ".")), nil
+	case origin == "":
+		return "", fmt.Errorf("relative name %q used without origin", name)
+	}
+	return strings.ToLower(name) + "." + origin, nil
+}
+
+// parseTTL parses TTL given in seconds or with units, e.g. '1h30m'
+func parseTTL(value string) (int, error) {
+	if seconds, err := strconv.ParseUint(value, 10, 31); err == nil {
+		return int(seconds), nil
+	}
+	units := map[rune]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var ttl, number int
+	var digits bool
+	for _, ch := range strings.ToLower(value) {
+		switch {
+		case unicode.IsDigit(ch):
+			number, digits = number*10+int(ch-'0'), true
+		case units[ch] > 0 && digits:
+			ttl, number, digits = ttl+number*units[ch], 0, false
+		default:
+			return 0, fmt.Errorf("invalid TTL %q", value)
+		}
+		if number > 1<<31-1 || ttl > 1<<31-1 {
+			return 0, fmt.Errorf("invalid TTL %q", value)
+		}
+	}
+	if digits {
+		return 0, fmt.Errorf("invalid TTL %q", value)
+	}
+	return ttl, nil
+}
+
+// tokenize splits the zone file into entries, removing comments and joining the lines enclosed in parentheses
+func tokenize(content string) ([]entry, error) {
+	var entries []entry
+	var current entry
+	var token strings.Builder
+	var inToken, quoted, escaped bool
+	depth, line := 0, 1
+
+	write := func(ch rune) {
+		if !inToken && len(current.tokens) == 0 {
+			current.line = line
+		}
+		token.WriteRune(ch)
+		inToken = true
+	}
+	endToken := func() {
+		if inToken {
+			current.tokens = append(current.tokens, token.String())
+			token.Reset()
+			inToken = false
+		}
+	}
+	endEntry := func() {
+		endToken()
+		if len(current.tokens) > 0 {
+			entries = append(entries, current)
+		}
+		current = entry{}
+	}
+
+	atLineStart := true
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		ch := runes[i]
+		if atLineStart && depth == 0 {
+			current.continued = ch == ' ' || ch == '\t'
+		}
+		atLineStart = false
+
+		switch {
+		case escaped:
+			write(ch)
+			escaped = false
+			if ch == '\n' {
+				line++
+			}
+		case ch == '\\':
+			write(ch)
+			escaped = true
+		case quoted:
+			write(ch)
+			if ch == '"' {
+				quoted = false
+			}
+			if ch == '\n' {
+				line++
+			}
+		case ch == '"':
+			write(ch)
+			quoted = true
+		case ch == ';':
+			endToken()
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+		case ch == '(':
+			endToken()
+			depth++
+		case ch == ')':
+			endToken()
+			if depth == 0 {
+				return nil, fmt.Errorf("%w: line %d: unbalanced parentheses", ErrSyntax, line)
+			}
+			depth--
+		case ch == '\n':
+			if depth == 0 {
+				endEntry()
+			} else {
+				endToken()
+			}
+			line++
+			atLineStart = true
+		case ch == ' ' || ch == '\t' || ch == '\r':
+			endToken()
+		default:
+			write(ch)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("%w: line %d: unterminated quoted string", ErrSyntax, line)
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("%w: line %d: unbalanced parentheses", ErrSyntax, line)
+	}
+	endEntry()
+	return entries, nil
+}
@@ -0,0 +1,126 @@
+package zonefile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		content   string
+		origin    string
+		expected  []Record
+		withError error
+	}{
+		"directives, multi-line records and owner inheritance": {
+			content: `$TTL 1h
+$ORIGIN example.com.
+@   IN  SOA ns1 hostmaster (
+            2024010101 ; serial
+            3600       ; refresh
+            600 604800 300 )
+        IN  NS  ns1.example.net.
+www 300 IN  A   10.0.0.1
+            A   10.0.0.2 ; same owner
+
+$ORIGIN sub.example.com.
+mail    MX  10 @
+`,
+			origin: "example.com",
+			expected: []Record{
+				{Name: "example.com", Type: "SOA", TTL: 3600, Rdata: []string{"ns1.example.com.", "hostmaster.example.com.", "2024010101", "3600", "600", "604800", "300"}, Line: 3},
+				{Name: "example.com", Type: "NS", TTL: 3600, Rdata: []string{"ns1.example.net."}, Line: 7},
+				{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.1"}, Line: 8},
+				{Name: "www.example.com", Type: "A", TTL: 3600, Rdata: []string{"10.0.0.2"}, Line: 9},
+				{Name: "mail.sub.example.com", Type: "MX", TTL: 3600, Rdata: []string{"10", "sub.example.com."}, Line: 12},
+			},
+		},
+		"quoted TXT chunks": {
+			content: `txt 1d IN TXT "v=spf1 include:_spf.example.com ; -all" ( "second
+chunk" "escaped \" quote" )
+`,
+			origin: "example.com",
+			expected: []Record{
+				{Name: "txt.example.com", Type: "TXT", TTL: 86400, Rdata: []string{`"v=spf1 include:_spf.example.com ; -all"`, "\"second\nchunk\"", `"escaped \" quote"`}, Line: 1},
+			},
+		},
+		"previous TTL used without $TTL": {
+			content: `a 60 A 10.0.0.1
+b A 10.0.0.2
+`,
+			origin: "example.com.",
+			expected: []Record{
+				{Name: "a.example.com", Type: "A", TTL: 60, Rdata: []string{"10.0.0.1"}, Line: 1},
+				{Name: "b.example.com", Type: "A", TTL: 60, Rdata: []string{"10.0.0.2"}, Line: 2},
+			},
+		},
+		"no TTL": {
+			content:   "a A 10.0.0.1\n",
+			origin:    "example.com",
+			withError: ErrSyntax,
+		},
+		"unbalanced parentheses": {
+			content:   "$TTL 60\na A ( 10.0.0.1\n",
+			origin:    "example.com",
+			withError: ErrSyntax,
+		},
+		"unterminated quoted string": {
+			content:   "$TTL 60\na TXT \"abc\n",
+			origin:    "example.com",
+			withError: ErrSyntax,
+		},
+		"missing record data": {
+			content:   "$TTL 60\na A\n",
+			origin:    "example.com",
+			withError: ErrSyntax,
+		},
+		"include directive": {
+			content:   "$INCLUDE other.zone\n",
+			origin:    "example.com",
+			withError: ErrUnsupported,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			records, err := Parse(test.content, test.origin)
+			if test.withError != nil {
+				assert.ErrorIs(t, err, test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, records)
+		})
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected int
+		ok       bool
+	}{
+		"seconds":        {value: "300", expected: 300, ok: true},
+		"units":          {value: "1h30m", expected: 5400, ok: true},
+		"upper case":     {value: "1W", expected: 604800, ok: true},
+		"missing unit":   {value: "1h30", ok: false},
+		"record type":    {value: "A", ok: false},
+		"unit only":      {value: "h", ok: false},
+		"out of range":   {value: "4294967296", ok: false},
+		"negative value": {value: "-1", ok: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ttl, err := parseTTL(test.value)
+			if !test.ok {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ttl)
+		})
+	}
+}
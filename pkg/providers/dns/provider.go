@@ -55,8 +55,9 @@ func (p *Subprovider) Client(meta meta.Meta) dns.DNS {
 // SDKResources returns the DNS resources implemented using terraform-plugin-sdk
 func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"akamai_dns_zone":   resourceDNSv2Zone(),
-		"akamai_dns_record": resourceDNSv2Record(),
+		"akamai_dns_zone":       resourceDNSv2Zone(),
+		"akamai_dns_record":     resourceDNSv2Record(),
+		"akamai_dns_recordsets": resourceDNSRecordsets(),
 	}
 }
 
@@ -65,6 +66,8 @@ func (p *Subprovider) SDKDataSources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
 		"akamai_authorities_set": dataSourceAuthoritiesSet(),
 		"akamai_dns_record_set":  dataSourceDNSRecordSet(),
+		"akamai_dns_zone_export": dataSourceDNSZoneExport(),
+		"akamai_dns_zone_file":   dataSourceDNSZoneFile(),
 	}
 }
 
@@ -59,39 +59,10 @@ func getResourceDNSRecordSchema() map[string]*schema.Schema {
 			ForceNew: true,
 		},
 		"recordtype": {
-			Type:     schema.TypeString,
-			Required: true,
-			ForceNew: true,
-			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
-				RRTypeA,
-				RRTypeAaaa,
-				RRTypeCname,
-				RRTypeLoc,
-				RRTypeNs,
-				RRTypePtr,
-				RRTypeSpf,
-				RRTypeTxt,
-				RRTypeAfsdb,
-				RRTypeDnskey,
-				RRTypeDs,
-				RRTypeHinfo,
-				RRTypeMx,
-				RRTypeNaptr,
-				RRTypeNsec3,
-				RRTypeNsec3Param,
-				RRTypeRp,
-				RRTypeRrsig,
-				RRTypeSrv,
-				RRTypeSshfp,
-				RRTypeSoa,
-				RRTypeAkamaiCdn,
-				RRTypeAkamaiTlc,
-				RRTypeCaa,
-				RRTypeCert,
-				RRTypeTlsa,
-				RRTypeSvcb,
-				RRTypeHTTPS,
-			}, false)),
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(recordTypes, false)),
 		},
 		"ttl": {
 			Type:     schema.TypeInt,
@@ -559,6 +530,38 @@ func diffQuotedDNSRecord(oldTargetList []string, newTargetList []string, old str
 	return false
 }
 
+// recordTypes are the resource record types supported by the Akamai Edge DNS API
+var recordTypes = []string{
+	RRTypeA,
+	RRTypeAaaa,
+	RRTypeCname,
+	RRTypeLoc,
+	RRTypeNs,
+	RRTypePtr,
+	RRTypeSpf,
+	RRTypeTxt,
+	RRTypeAfsdb,
+	RRTypeDnskey,
+	RRTypeDs,
+	RRTypeHinfo,
+	RRTypeMx,
+	RRTypeNaptr,
+	RRTypeNsec3,
+	RRTypeNsec3Param,
+	RRTypeRp,
+	RRTypeRrsig,
+	RRTypeSrv,
+	RRTypeSshfp,
+	RRTypeSoa,
+	RRTypeAkamaiCdn,
+	RRTypeAkamaiTlc,
+	RRTypeCaa,
+	RRTypeCert,
+	RRTypeTlsa,
+	RRTypeSvcb,
+	RRTypeHTTPS,
+}
+
 // Lock per record type
 var recordCreateLock = map[string]*sync.Mutex{
 	"A":          {},
@@ -2101,7 +2104,7 @@ func buildRecordsList(target []interface{}, recordType string, logger log.Interf
 	return records, nil
 }
 
-func validateRecord(d *schema.ResourceData) error {
+func validateRecord(d tf.ResourceDataFetcher) error {
 	recordType, err := tf.GetStringValue("recordtype", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2156,7 +2159,7 @@ func validateRecord(d *schema.ResourceData) error {
 	}
 }
 
-func checkBasicRecordTypes(d *schema.ResourceData) error {
+func checkBasicRecordTypes(d tf.ResourceDataFetcher) error {
 	_, err := tf.GetStringValue("name", d)
 	if err != nil {
 		if !errors.Is(err, tf.ErrNotFound) {
@@ -2181,7 +2184,7 @@ func checkBasicRecordTypes(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkTargets(d *schema.ResourceData) error {
+func checkTargets(d tf.ResourceDataFetcher) error {
 	target, err := tf.GetListValue("target", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2198,7 +2201,7 @@ func checkTargets(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkAsdfRecord(d *schema.ResourceData) error {
+func checkAsdfRecord(d tf.ResourceDataFetcher) error {
 	subtype, err := tf.GetIntValue("subtype", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2210,7 +2213,7 @@ func checkAsdfRecord(d *schema.ResourceData) error {
 	return checkTargets(d)
 }
 
-func checkDnskeyRecord(d *schema.ResourceData) error {
+func checkDnskeyRecord(d tf.ResourceDataFetcher) error {
 	flags, err := tf.GetIntValue("flags", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2256,7 +2259,7 @@ func checkDnskeyRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkDsRecord(d *schema.ResourceData) error {
+func checkDsRecord(d tf.ResourceDataFetcher) error {
 	digestType, err := tf.GetIntValue("digest_type", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2293,7 +2296,7 @@ func checkDsRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkHinfoRecord(d *schema.ResourceData) error {
+func checkHinfoRecord(d tf.ResourceDataFetcher) error {
 	hardware, err := tf.GetStringValue("hardware", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2314,7 +2317,7 @@ func checkHinfoRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkMxRecord(d *schema.ResourceData) error {
+func checkMxRecord(d tf.ResourceDataFetcher) error {
 	priority, err := tf.GetIntValue("priority", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2331,7 +2334,7 @@ func checkMxRecord(d *schema.ResourceData) error {
 	return checkTargets(d)
 }
 
-func checkNaptrRecord(d *schema.ResourceData) error {
+func checkNaptrRecord(d tf.ResourceDataFetcher) error {
 	flagsnaptr, err := tf.GetStringValue("flagsnaptr", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2388,7 +2391,7 @@ func checkNaptrRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkNsec3Record(d *schema.ResourceData) error {
+func checkNsec3Record(d tf.ResourceDataFetcher) error {
 	flags, err := tf.GetIntValue("flags", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2441,7 +2444,7 @@ func checkNsec3Record(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkNsec3ParamRecord(d *schema.ResourceData) error {
+func checkNsec3ParamRecord(d tf.ResourceDataFetcher) error {
 	flags, err := tf.GetIntValue("flags", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2482,7 +2485,7 @@ func checkNsec3ParamRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkRpRecord(d *schema.ResourceData) error {
+func checkRpRecord(d tf.ResourceDataFetcher) error {
 	mailbox, err := tf.GetStringValue("mailbox", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2507,7 +2510,7 @@ func checkRpRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkRrsigRecord(d *schema.ResourceData) error {
+func checkRrsigRecord(d tf.ResourceDataFetcher) error {
 	expiration, err := tf.GetStringValue("expiration", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2588,7 +2591,7 @@ func checkRrsigRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkSrvRecord(d *schema.ResourceData) error {
+func checkSrvRecord(d tf.ResourceDataFetcher) error {
 	priority, err := tf.GetIntValue("priority", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2625,7 +2628,7 @@ func checkSrvRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkSshfpRecord(d *schema.ResourceData) error {
+func checkSshfpRecord(d tf.ResourceDataFetcher) error {
 	algorithm, err := tf.GetIntValue("algorithm", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2658,7 +2661,7 @@ func checkSshfpRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkSoaRecord(d *schema.ResourceData) error {
+func checkSoaRecord(d tf.ResourceDataFetcher) error {
 	nameserver, err := tf.GetStringValue("name_server", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2711,11 +2714,11 @@ func checkSoaRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkAkamaiTlcRecord(*schema.ResourceData) error {
+func checkAkamaiTlcRecord(tf.ResourceDataFetcher) error {
 	return fmt.Errorf("AKAMAITLC is a READ ONLY record")
 }
 
-func checkCaaRecord(d *schema.ResourceData) error {
+func checkCaaRecord(d tf.ResourceDataFetcher) error {
 	if err := checkBasicRecordTypes(d); err != nil {
 		return err
 	}
@@ -2724,7 +2727,10 @@ func checkCaaRecord(d *schema.ResourceData) error {
 		return err
 	}
 
-	caatarget := d.Get("target").([]interface{})
+	caatarget, err := tf.GetListValue("target", d)
+	if err != nil {
+		return err
+	}
 	for _, caa := range caatarget {
 		caaStr, ok := caa.(string)
 		if !ok {
@@ -2749,7 +2755,7 @@ func checkCaaRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkCertRecord(d *schema.ResourceData) error {
+func checkCertRecord(d tf.ResourceDataFetcher) error {
 	typemnemonic, err := tf.GetStringValue("type_mnemonic", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2782,7 +2788,7 @@ func checkCertRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkTlsaRecord(d *schema.ResourceData) error {
+func checkTlsaRecord(d tf.ResourceDataFetcher) error {
 	usage, err := tf.GetIntValue("usage", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -2807,15 +2813,15 @@ func checkTlsaRecord(d *schema.ResourceData) error {
 	return nil
 }
 
-func checkSvcbRecord(d *schema.ResourceData) error {
+func checkSvcbRecord(d tf.ResourceDataFetcher) error {
 	return checkServiceRecord(d, "SVCB")
 }
 
-func checkHTTPSRecord(d *schema.ResourceData) error {
+func checkHTTPSRecord(d tf.ResourceDataFetcher) error {
 	return checkServiceRecord(d, "HTTPS")
 }
 
-func checkServiceRecord(d *schema.ResourceData, rtype string) error {
+func checkServiceRecord(d tf.ResourceDataFetcher, rtype string) error {
 	pri, err := tf.GetIntValue("svc_priority", d)
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
@@ -0,0 +1,544 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/dns"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/dns/internal/txtrecord"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// hostnameRdataTypes are the record types which rdata consists of hostnames and numbers only
+var hostnameRdataTypes = map[string]bool{
+	RRTypeCname:     true,
+	RRTypeNs:        true,
+	RRTypePtr:       true,
+	RRTypeMx:        true,
+	RRTypeSrv:       true,
+	RRTypeAfsdb:     true,
+	RRTypeRp:        true,
+	RRTypeAkamaiCdn: true,
+}
+
+func resourceDNSRecordsets() *schema.Resource {
+	recordsetTypes := make([]string, 0, len(recordTypes))
+	for _, recordType := range recordTypes {
+		if recordType != RRTypeSoa {
+			recordsetTypes = append(recordsetTypes, recordType)
+		}
+	}
+
+	return &schema.Resource{
+		Description: "Manages many record sets of a zone in bulk. The record sets of a zone should not be managed " +
+			"with both this resource and 'akamai_dns_record'",
+		CreateContext: resourceDNSRecordsetsCreate,
+		ReadContext:   resourceDNSRecordsetsRead,
+		UpdateContext: resourceDNSRecordsetsUpdate,
+		DeleteContext: resourceDNSRecordsetsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDNSRecordsetsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+				Description:      "The name of the zone the record sets belong to",
+			},
+			"authoritative": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "When true, record sets of the zone not listed in 'recordset' are deleted, " +
+					"except for the SOA and NS record sets of the zone apex",
+			},
+			"recordset": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The record sets managed in the zone",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: tf.IsNotBlank,
+							Description:      "The fully qualified name of the record set",
+						},
+						"type": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(recordsetTypes, false)),
+							Description:      "The type of the record set",
+						},
+						"ttl": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+							Description:      "The time to live of the record set in seconds",
+						},
+						"rdata": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The records of the record set in the presentation format, e.g. '10 mail.example.com.' for MX",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceDNSRecordsetsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSRecordsetsCreate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	zone, err := tf.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	authoritative, err := tf.GetBoolValue("authoritative", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	managed, err := expandRecordsets(zone, d.Get("recordset").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.Infof("Creating record sets of zone %s", zone)
+	if err := applyRecordsets(ctx, meta, zone, authoritative, managed, nil, logger); err != nil {
+		return diag.Errorf("creating record sets of zone %q: %s", zone, err)
+	}
+
+	d.SetId(zone)
+	return resourceDNSRecordsetsRead(ctx, d, m)
+}
+
+func resourceDNSRecordsetsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSRecordsetsRead")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	zone, err := tf.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	authoritative, err := tf.GetBoolValue("authoritative", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	managed, err := expandRecordsets(zone, d.Get("recordset").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.Infof("Reading record sets of zone %s", zone)
+	live, err := getZoneRecordsets(ctx, meta, zone)
+	if err != nil {
+		return diag.Errorf("reading record sets of zone %q: %s", zone, err)
+	}
+	liveByKey := make(map[string]dns.RecordSet, len(live))
+	for _, rs := range live {
+		liveByKey[recordsetKey(rs)] = rs
+	}
+
+	recordsets := make([]interface{}, 0, len(managed))
+	seen := make(map[string]bool, len(managed))
+	for _, rs := range managed {
+		key := recordsetKey(rs)
+		liveRs, ok := liveByKey[key]
+		if !ok {
+			// removed outside of terraform, planned to be created again
+			logger.Debugf("Record set %s not found in zone %s", key, zone)
+			continue
+		}
+		seen[key] = true
+		if !equivalentRecordsets(rs, liveRs) {
+			rs.TTL, rs.Rdata = liveRs.TTL, liveRs.Rdata
+		}
+		recordsets = append(recordsets, flattenRecordset(rs))
+	}
+	if authoritative {
+		// unmanaged record sets are reported to be deleted on the next apply
+		for _, rs := range live {
+			if !seen[recordsetKey(rs)] && !isZoneApexRecordset(zone, rs) {
+				recordsets = append(recordsets, flattenRecordset(rs))
+			}
+		}
+	}
+
+	if err := d.Set("recordset", recordsets); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceDNSRecordsetsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSRecordsetsUpdate")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	zone, err := tf.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	authoritative, err := tf.GetBoolValue("authoritative", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	oldRecordsets, newRecordsets := d.GetChange("recordset")
+	managed, err := expandRecordsets(zone, newRecordsets.(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	previous, err := expandRecordsets(zone, oldRecordsets.(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	logger.Infof("Updating record sets of zone %s", zone)
+	if err := applyRecordsets(ctx, meta, zone, authoritative, managed, previous, logger); err != nil {
+		return diag.Errorf("updating record sets of zone %q: %s", zone, err)
+	}
+
+	return resourceDNSRecordsetsRead(ctx, d, m)
+}
+
+func resourceDNSRecordsetsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSRecordsetsDelete")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	zone, err := tf.GetStringValue("zone", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	previous, err := expandRecordsets(zone, d.Get("recordset").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// only the managed record sets are deleted, regardless of 'authoritative'
+	logger.Infof("Deleting record sets of zone %s", zone)
+	if err := applyRecordsets(ctx, meta, zone, false, nil, previous, logger); err != nil {
+		return diag.Errorf("deleting record sets of zone %q: %s", zone, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceDNSRecordsetsImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	meta := meta.Must(m)
+	logger := meta.Log("AkamaiDNS", "resourceDNSRecordsetsImport")
+	ctx = session.ContextWithOptions(ctx, session.WithContextLog(logger))
+
+	zone := d.Id()
+	logger.Infof("Importing record sets of zone %s", zone)
+	live, err := getZoneRecordsets(ctx, meta, zone)
+	if err != nil {
+		return nil, fmt.Errorf("reading record sets of zone %q: %s", zone, err)
+	}
+
+	recordsets := make([]interface{}, 0, len(live))
+	for _, rs := range live {
+		if !isZoneApexRecordset(zone, rs) {
+			recordsets = append(recordsets, flattenRecordset(rs))
+		}
+	}
+
+	attrs := map[string]interface{}{
+		"zone":          zone,
+		"authoritative": false,
+		"recordset":     recordsets,
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyRecordsets submits the changes to the record sets of the zone needed to reach the managed ones.
+// Record sets in previous but not in managed are deleted, and so are all unmanaged ones when authoritative.
+// New record sets only are added with a single POST, any other change replaces all record sets of the zone
+// with a single PUT, bumping the SOA serial in the same request. The record type locks shared with
+// akamai_dns_record resource are held from reading the record sets until the changes are submitted, and the
+// changes are computed and submitted again on concurrency conflicts and stale SOA serial numbers.
+func applyRecordsets(ctx context.Context, meta meta.Meta, zone string, authoritative bool, managed, previous []dns.RecordSet, logger log.Interface) error {
+	unlock := lockRecordsetTypes(authoritative, managed, previous)
+	defer unlock()
+
+	for i := range managed {
+		managed[i].Name = strings.TrimSuffix(managed[i].Name, ".")
+	}
+
+	opRetry := opRetryCount
+	e := submitRecordsets(ctx, meta, zone, authoritative, managed, previous, logger)
+	for e != nil && opRetry > 0 {
+		apiError, ok := e.(*dns.Error)
+		if !ok || apiError.StatusCode < http.StatusBadRequest {
+			return e
+		}
+		switch {
+		case apiError.StatusCode == http.StatusConflict:
+			logger.Debug("applyRecordsets - Concurrency Conflict")
+			time.Sleep(100 * time.Millisecond)
+		case strings.Contains(e.Error(), "SOA serial number must be incremented"):
+			logger.Debug("applyRecordsets - SOA Serial Number needs incrementing")
+			time.Sleep(5 * time.Second) // let things quiesce
+		default:
+			return e
+		}
+		opRetry--
+		e = submitRecordsets(ctx, meta, zone, authoritative, managed, previous, logger)
+	}
+	return e
+}
+
+// submitRecordsets reads the record sets of the zone and submits the changes needed to reach the managed ones
+func submitRecordsets(ctx context.Context, meta meta.Meta, zone string, authoritative bool, managed, previous []dns.RecordSet, logger log.Interface) error {
+	live, err := getZoneRecordsets(ctx, meta, zone)
+	if err != nil {
+		return err
+	}
+
+	managedByKey := make(map[string]dns.RecordSet, len(managed))
+	for _, rs := range managed {
+		managedByKey[recordsetKey(rs)] = rs
+	}
+	removed := make(map[string]bool, len(previous))
+	for _, rs := range previous {
+		if _, ok := managedByKey[recordsetKey(rs)]; !ok {
+			removed[recordsetKey(rs)] = true
+		}
+	}
+
+	desired := make([]dns.RecordSet, 0, len(live)+len(managed))
+	found := make(map[string]bool, len(managed))
+	var changed bool
+	for _, rs := range live {
+		key := recordsetKey(rs)
+		if managedRs, ok := managedByKey[key]; ok {
+			found[key] = true
+			if !equivalentRecordsets(managedRs, rs) {
+				logger.Debugf("Record set %s modified", key)
+				changed = true
+			}
+			desired = append(desired, managedRs)
+			continue
+		}
+		if !isZoneApexRecordset(zone, rs) && (authoritative || removed[key]) {
+			logger.Debugf("Record set %s removed", key)
+			changed = true
+			continue
+		}
+		desired = append(desired, rs)
+	}
+	additions := make([]dns.RecordSet, 0)
+	for _, rs := range managed {
+		if !found[recordsetKey(rs)] {
+			logger.Debugf("Record set %s added", recordsetKey(rs))
+			additions = append(additions, rs)
+		}
+	}
+
+	switch {
+	case !changed && len(additions) == 0:
+		logger.Debug("Record sets are up to date")
+		return nil
+	case !changed:
+		logger.Debugf("Adding %d record sets", len(additions))
+		return inst.Client(meta).CreateRecordSets(ctx, &dns.RecordSets{RecordSets: additions}, zone)
+	}
+
+	desired = append(desired, additions...)
+	sort.SliceStable(desired, func(i, j int) bool {
+		return recordsetKey(desired[i]) < recordsetKey(desired[j])
+	})
+	for i := range desired {
+		if desired[i].Type == RRTypeSoa {
+			soa, err := incrementSoaSerial(desired[i])
+			if err != nil {
+				return err
+			}
+			desired[i] = soa
+		}
+	}
+	logger.Debugf("Replacing record sets with %d record sets", len(desired))
+	return inst.Client(meta).UpdateRecordSets(ctx, &dns.RecordSets{RecordSets: desired}, zone)
+}
+
+// lockRecordsetTypes locks the record types of the given record sets, or all record types when authoritative,
+// in a fixed order and returns the function unlocking them
+func lockRecordsetTypes(authoritative bool, managed, previous []dns.RecordSet) func() {
+	types := make(map[string]bool)
+	for _, rs := range append(append([]dns.RecordSet{}, managed...), previous...) {
+		types[strings.ToUpper(rs.Type)] = true
+	}
+
+	var locks []*sync.Mutex
+	for _, recordType := range recordTypes {
+		if lock := getRecordLock(recordType); lock != nil && (authoritative || types[recordType]) {
+			locks = append(locks, lock)
+		}
+	}
+	for _, lock := range locks {
+		lock.Lock()
+	}
+	return func() {
+		for i := len(locks) - 1; i >= 0; i-- {
+			locks[i].Unlock()
+		}
+	}
+}
+
+// getZoneRecordsets returns all record sets of the zone
+func getZoneRecordsets(ctx context.Context, meta meta.Meta, zone string) ([]dns.RecordSet, error) {
+	resp, err := inst.Client(meta).GetRecordSets(ctx, zone, dns.RecordSetQueryArgs{ShowAll: true})
+	if err != nil {
+		return nil, err
+	}
+	return resp.RecordSets, nil
+}
+
+// expandRecordsets converts 'recordset' blocks, validating that the record sets are unique and within the zone
+func expandRecordsets(zone string, list []interface{}) ([]dns.RecordSet, error) {
+	zoneName := strings.ToLower(strings.TrimSuffix(zone, "."))
+	recordsets := make([]dns.RecordSet, 0, len(list))
+	keys := make(map[string]bool, len(list))
+	for _, item := range list {
+		attrs, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: recordset is of invalid type", tf.ErrInvalidType)
+		}
+		rdata := make([]string, 0)
+		for _, r := range attrs["rdata"].([]interface{}) {
+			rdata = append(rdata, r.(string))
+		}
+		rs := dns.RecordSet{
+			Name:  attrs["name"].(string),
+			Type:  attrs["type"].(string),
+			TTL:   attrs["ttl"].(int),
+			Rdata: rdata,
+		}
+
+		name := strings.ToLower(strings.TrimSuffix(rs.Name, "."))
+		if name != zoneName && !strings.HasSuffix(name, "."+zoneName) {
+			return nil, fmt.Errorf("record set %s %s is not within zone %s", rs.Name, rs.Type, zone)
+		}
+		key := recordsetKey(rs)
+		if keys[key] {
+			return nil, fmt.Errorf("record set %s %s is defined more than once", rs.Name, rs.Type)
+		}
+		keys[key] = true
+		recordsets = append(recordsets, rs)
+	}
+	return recordsets, nil
+}
+
+func flattenRecordset(rs dns.RecordSet) map[string]interface{} {
+	return map[string]interface{}{
+		"name":  rs.Name,
+		"type":  rs.Type,
+		"ttl":   rs.TTL,
+		"rdata": rs.Rdata,
+	}
+}
+
+// recordsetKey identifies the record set within a zone by its name and type
+func recordsetKey(rs dns.RecordSet) string {
+	return strings.ToLower(strings.TrimSuffix(rs.Name, ".")) + " " + strings.ToUpper(rs.Type)
+}
+
+// isZoneApexRecordset returns true for the SOA and NS record sets of the zone apex, which are managed with the zone
+func isZoneApexRecordset(zone string, rs dns.RecordSet) bool {
+	switch strings.ToUpper(rs.Type) {
+	case RRTypeSoa:
+		return true
+	case RRTypeNs:
+		return strings.EqualFold(strings.TrimSuffix(rs.Name, "."), strings.TrimSuffix(zone, "."))
+	}
+	return false
+}
+
+// equivalentRecordsets returns true if the record sets have the same ttl and the same records,
+// regardless of their order and representation
+func equivalentRecordsets(a, b dns.RecordSet) bool {
+	if a.TTL != b.TTL || len(a.Rdata) != len(b.Rdata) {
+		return false
+	}
+	normalize := func(rs dns.RecordSet) []string {
+		rdata := make([]string, 0, len(rs.Rdata))
+		for _, r := range rs.Rdata {
+			rdata = append(rdata, normalizeRdata(strings.ToUpper(rs.Type), r))
+		}
+		sort.Strings(rdata)
+		return rdata
+	}
+	aRdata, bRdata := normalize(a), normalize(b)
+	for i := range aRdata {
+		if aRdata[i] != bRdata[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeRdata returns the canonical representation of the record used to compare the records
+func normalizeRdata(recordType, rdata string) string {
+	switch recordType {
+	case RRTypeTxt, RRTypeSpf:
+		if normalized, err := txtrecord.NormalizeTarget(rdata); err == nil {
+			return normalized
+		}
+		return rdata
+	case RRTypeAaaa:
+		if ip := net.ParseIP(strings.TrimSpace(rdata)); ip != nil {
+			return FullIPv6(ip)
+		}
+	}
+
+	fields := strings.Fields(rdata)
+	if hostnameRdataTypes[recordType] {
+		for i, field := range fields {
+			fields[i] = strings.ToLower(strings.TrimSuffix(field, "."))
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// incrementSoaSerial returns the SOA record set with the serial number incremented
+func incrementSoaSerial(soa dns.RecordSet) (dns.RecordSet, error) {
+	if len(soa.Rdata) != 1 {
+		return soa, fmt.Errorf("invalid SOA record set: %v", soa.Rdata)
+	}
+	fields := strings.Fields(soa.Rdata[0])
+	if len(fields) != 7 {
+		return soa, fmt.Errorf("invalid SOA record: %s", soa.Rdata[0])
+	}
+	serial, err := strconv.ParseUint(fields[2], 10, 32)
+	if err != nil {
+		return soa, fmt.Errorf("invalid SOA serial number: %s", fields[2])
+	}
+	fields[2] = strconv.FormatUint((serial+1)%(1<<32), 10)
+	soa.Rdata = []string{strings.Join(fields, " ")}
+	return soa, nil
+}
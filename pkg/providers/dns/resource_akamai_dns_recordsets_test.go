@@ -0,0 +1,401 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/dns"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/dns/internal/txtrecord"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// zoneRecordsetsState holds the record sets of the zone modified by the mocked bulk record sets calls
+type zoneRecordsetsState struct {
+	mu         sync.Mutex
+	recordsets []dns.RecordSet
+	reads      int
+	creates    int
+	updates    int
+}
+
+// store saves the record sets the way the API returns them
+func (s *zoneRecordsetsState) store(recordsets []dns.RecordSet) {
+	for _, rs := range recordsets {
+		rdata := make([]string, 0, len(rs.Rdata))
+		for _, r := range rs.Rdata {
+			switch rs.Type {
+			case RRTypeAaaa:
+				r = FullIPv6(net.ParseIP(r))
+			case RRTypeTxt:
+				r, _ = txtrecord.NormalizeTarget(r)
+			}
+			rdata = append(rdata, r)
+		}
+		rs.Rdata = rdata
+		s.recordsets = append(s.recordsets, rs)
+	}
+}
+
+// get returns the record set with the given name and type, nil if none
+func (s *zoneRecordsetsState) get(name, recordType string) *dns.RecordSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rs := range s.recordsets {
+		if rs.Name == name && rs.Type == recordType {
+			return &rs
+		}
+	}
+	return nil
+}
+
+func (s *zoneRecordsetsState) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.recordsets))
+	for _, rs := range s.recordsets {
+		keys = append(keys, recordsetKey(rs))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// mockZoneRecordsets sets up the record sets calls used by akamai_dns_recordsets resource, operating on the given state
+func mockZoneRecordsets(client *dns.Mock, zone string, state *zoneRecordsetsState) {
+	getRecordSets := client.On("GetRecordSets", mock.Anything, zone, []dns.RecordSetQueryArgs{{ShowAll: true}})
+	getRecordSets.Run(func(mock.Arguments) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		state.reads++
+		recordsets := make([]dns.RecordSet, 0, len(state.recordsets))
+		for _, rs := range state.recordsets {
+			recordsets = append(recordsets, dns.RecordSet{Name: rs.Name, Type: rs.Type, TTL: rs.TTL, Rdata: append([]string{}, rs.Rdata...)})
+		}
+		getRecordSets.Return(&dns.RecordSetResponse{RecordSets: recordsets}, nil)
+	})
+
+	client.On("CreateRecordSets", mock.Anything, mock.AnythingOfType("*dns.RecordSets"), zone).Run(func(args mock.Arguments) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		state.creates++
+		state.store(args.Get(1).(*dns.RecordSets).RecordSets)
+	}).Return(nil).Maybe()
+
+	client.On("UpdateRecordSets", mock.Anything, mock.AnythingOfType("*dns.RecordSets"), zone).Run(func(args mock.Arguments) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		state.updates++
+		state.recordsets = nil
+		state.store(args.Get(1).(*dns.RecordSets).RecordSets)
+	}).Return(nil).Maybe()
+}
+
+// requests returns the numbers of record sets read, creation and replacement requests submitted
+func (s *zoneRecordsetsState) requests() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return []int{s.reads, s.creates, s.updates}
+}
+
+func TestResDnsRecordsets(t *testing.T) {
+	zoneApex := []dns.RecordSet{
+		{Name: "example.com", Type: "SOA", TTL: 86400, Rdata: []string{"a1-1.akam.net. hostmaster.example.com. 1 3600 600 604800 300"}},
+		{Name: "example.com", Type: "NS", TTL: 86400, Rdata: []string{"a1-1.akam.net.", "a2-2.akam.net."}},
+	}
+
+	checkZone := func(state *zoneRecordsetsState, keys ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			assert.Equal(t, keys, state.keys())
+			return nil
+		}
+	}
+	checkRequests := func(state *zoneRecordsetsState, creates, updates int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			requests := state.requests()
+			assert.Equal(t, []int{creates, updates}, requests[1:])
+			return nil
+		}
+	}
+	checkSerial := func(state *zoneRecordsetsState, serial string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			soa := state.get("example.com", "SOA")
+			if assert.NotNil(t, soa) {
+				assert.Equal(t, serial, strings.Fields(soa.Rdata[0])[2])
+			}
+			return nil
+		}
+	}
+
+	t.Run("create, update, make authoritative and delete record sets", func(t *testing.T) {
+		client := &dns.Mock{}
+		state := &zoneRecordsetsState{}
+		state.store(zoneApex)
+		state.store([]dns.RecordSet{{Name: "legacy.example.com", Type: "CNAME", TTL: 300, Rdata: []string{"www.example.com."}}})
+		mockZoneRecordsets(client, "example.com", state)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResDnsRecordsets/create.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "id", "example.com"),
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "authoritative", "false"),
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "recordset.#", "2"),
+							resource.TestCheckTypeSetElemNestedAttrs("akamai_dns_recordsets.test", "recordset.*", map[string]string{
+								"name":    "example.com",
+								"type":    "TXT",
+								"rdata.0": "v=spf1 -all",
+							}),
+							checkZone(state, "example.com NS", "example.com SOA", "example.com TXT", "legacy.example.com CNAME", "www.example.com A"),
+							// additions only are submitted without replacing the zone record sets
+							checkRequests(state, 1, 0),
+							checkSerial(state, "1"),
+						),
+					},
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResDnsRecordsets/update.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "recordset.#", "2"),
+							resource.TestCheckTypeSetElemNestedAttrs("akamai_dns_recordsets.test", "recordset.*", map[string]string{
+								"name":    "v6.example.com",
+								"type":    "AAAA",
+								"rdata.0": "2001:db8::1",
+							}),
+							checkZone(state, "example.com NS", "example.com SOA", "legacy.example.com CNAME", "v6.example.com AAAA", "www.example.com A"),
+							// the modification and the deletion are submitted in one request, leaving the other record sets of the zone intact
+							checkRequests(state, 1, 1),
+							checkSerial(state, "2"),
+							func(*terraform.State) error {
+								assert.Equal(t, []string{"10.0.0.2", "10.0.0.1"}, state.get("www.example.com", "A").Rdata)
+								return nil
+							},
+						),
+					},
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResDnsRecordsets/authoritative.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "authoritative", "true"),
+							resource.TestCheckResourceAttr("akamai_dns_recordsets.test", "recordset.#", "2"),
+							checkZone(state, "example.com NS", "example.com SOA", "v6.example.com AAAA", "www.example.com A"),
+							checkRequests(state, 1, 2),
+							checkSerial(state, "3"),
+						),
+					},
+					{
+						ResourceName:  "akamai_dns_recordsets.test",
+						ImportState:   true,
+						ImportStateId: "example.com",
+						// imported record sets are in the representation returned by the API
+						ImportStateCheck: func(states []*terraform.InstanceState) error {
+							if assert.Len(t, states, 1) {
+								assert.Equal(t, "example.com", states[0].Attributes["zone"])
+								assert.Equal(t, "false", states[0].Attributes["authoritative"])
+								assert.Equal(t, "2", states[0].Attributes["recordset.#"])
+							}
+							return nil
+						},
+					},
+				},
+				CheckDestroy: checkZone(state, "example.com NS", "example.com SOA"),
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("authoritative mode plans the deletion of record sets added outside of terraform", func(t *testing.T) {
+		client := &dns.Mock{}
+		state := &zoneRecordsetsState{}
+		state.store(zoneApex)
+		mockZoneRecordsets(client, "example.com", state)
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResDnsRecordsets/authoritative.tf"),
+						Check:  checkZone(state, "example.com NS", "example.com SOA", "v6.example.com AAAA", "www.example.com A"),
+					},
+					{
+						PreConfig: func() {
+							state.mu.Lock()
+							defer state.mu.Unlock()
+							state.store([]dns.RecordSet{{Name: "extra.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.9"}}})
+						},
+						Config:             testutils.LoadFixtureString(t, "testdata/TestResDnsRecordsets/authoritative.tf"),
+						PlanOnly:           true,
+						ExpectNonEmptyPlan: true,
+					},
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResDnsRecordsets/authoritative.tf"),
+						Check:  checkZone(state, "example.com NS", "example.com SOA", "v6.example.com AAAA", "www.example.com A"),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("record set outside of the zone", func(t *testing.T) {
+		client := &dns.Mock{}
+
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestResDnsRecordsets/outside_zone.tf"),
+						ExpectError: regexp.MustCompile(`record set www.example.org A is not within zone example.com`),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+}
+
+func TestEquivalentRecordsets(t *testing.T) {
+	tests := map[string]struct {
+		a, b     dns.RecordSet
+		expected bool
+	}{
+		"same records in different order": {
+			a:        dns.RecordSet{Type: "A", TTL: 300, Rdata: []string{"10.0.0.1", "10.0.0.2"}},
+			b:        dns.RecordSet{Type: "A", TTL: 300, Rdata: []string{"10.0.0.2", "10.0.0.1"}},
+			expected: true,
+		},
+		"different ttl": {
+			a:        dns.RecordSet{Type: "A", TTL: 300, Rdata: []string{"10.0.0.1"}},
+			b:        dns.RecordSet{Type: "A", TTL: 600, Rdata: []string{"10.0.0.1"}},
+			expected: false,
+		},
+		"short and full IPv6 notation": {
+			a:        dns.RecordSet{Type: "AAAA", TTL: 300, Rdata: []string{"2001:db8::1"}},
+			b:        dns.RecordSet{Type: "AAAA", TTL: 300, Rdata: []string{"2001:0db8:0000:0000:0000:0000:0000:0001"}},
+			expected: true,
+		},
+		"hostname with and without trailing dot": {
+			a:        dns.RecordSet{Type: "MX", TTL: 300, Rdata: []string{"10 Mail.example.com."}},
+			b:        dns.RecordSet{Type: "MX", TTL: 300, Rdata: []string{"10 mail.example.com"}},
+			expected: true,
+		},
+		"quoted and unquoted TXT": {
+			a:        dns.RecordSet{Type: "TXT", TTL: 300, Rdata: []string{"v=spf1 -all"}},
+			b:        dns.RecordSet{Type: "TXT", TTL: 300, Rdata: []string{`"v=spf1" "-all"`}},
+			expected: true,
+		},
+		"TXT with different character strings": {
+			a:        dns.RecordSet{Type: "TXT", TTL: 300, Rdata: []string{"v=spf1 -all"}},
+			b:        dns.RecordSet{Type: "TXT", TTL: 300, Rdata: []string{`"v=spf1 -all"`}},
+			expected: false,
+		},
+		"different records": {
+			a:        dns.RecordSet{Type: "CNAME", TTL: 300, Rdata: []string{"a.example.com."}},
+			b:        dns.RecordSet{Type: "CNAME", TTL: 300, Rdata: []string{"b.example.com."}},
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, equivalentRecordsets(test.a, test.b))
+		})
+	}
+}
+
+func TestLockRecordsetTypes(t *testing.T) {
+	unlock := lockRecordsetTypes(false, []dns.RecordSet{{Type: "A"}}, []dns.RecordSet{{Type: "txt"}})
+	// the locks are shared with akamai_dns_record resource
+	assert.False(t, getRecordLock("A").TryLock())
+	assert.False(t, getRecordLock("TXT").TryLock())
+	if assert.True(t, getRecordLock("MX").TryLock()) {
+		getRecordLock("MX").Unlock()
+	}
+	unlock()
+
+	unlock = lockRecordsetTypes(true, nil, nil)
+	assert.False(t, getRecordLock("MX").TryLock())
+	unlock()
+	for _, recordType := range []string{"A", "TXT", "MX"} {
+		if assert.True(t, getRecordLock(recordType).TryLock()) {
+			getRecordLock(recordType).Unlock()
+		}
+	}
+}
+
+func TestApplyRecordsets(t *testing.T) {
+	zoneApex := []dns.RecordSet{
+		{Name: "example.com", Type: "SOA", TTL: 86400, Rdata: []string{"a1-1.akam.net. hostmaster.example.com. 1 3600 600 604800 300"}},
+		{Name: "example.com", Type: "NS", TTL: 86400, Rdata: []string{"a1-1.akam.net.", "a2-2.akam.net."}},
+	}
+	hosts := func(from, to int, ip string) []dns.RecordSet {
+		var recordsets []dns.RecordSet
+		for i := from; i < to; i++ {
+			recordsets = append(recordsets, dns.RecordSet{Name: fmt.Sprintf("host%d.example.com", i), Type: "A", TTL: 300, Rdata: []string{ip}})
+		}
+		return recordsets
+	}
+
+	t.Run("many record sets changed with one request", func(t *testing.T) {
+		client := &dns.Mock{}
+		state := &zoneRecordsetsState{}
+		state.store(zoneApex)
+		state.store(hosts(0, 100, "10.0.0.1"))
+		mockZoneRecordsets(client, "example.com", state)
+
+		// 50 record sets kept, 25 modified, 25 deleted and 25 added
+		previous := hosts(0, 100, "10.0.0.1")
+		managed := append(append(hosts(0, 50, "10.0.0.1"), hosts(50, 75, "10.0.0.2")...), hosts(100, 125, "10.0.0.1")...)
+		useClient(client, func() {
+			assert.NoError(t, applyRecordsets(context.Background(), nil, "example.com", false, managed, previous, log.Log))
+		})
+
+		// a single read and a single replacement of the zone record sets
+		assert.Equal(t, []int{1, 0, 1}, state.requests())
+		assert.Len(t, state.keys(), 2+50+25+25)
+		assert.Equal(t, []string{"10.0.0.2"}, state.get("host60.example.com", "A").Rdata)
+		assert.Nil(t, state.get("host80.example.com", "A"))
+		assert.NotNil(t, state.get("host110.example.com", "A"))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("changes submitted again on concurrency conflict", func(t *testing.T) {
+		client := &dns.Mock{}
+		state := &zoneRecordsetsState{}
+		state.store(zoneApex)
+		state.store(hosts(0, 2, "10.0.0.1"))
+		client.On("UpdateRecordSets", mock.Anything, mock.AnythingOfType("*dns.RecordSets"), "example.com").
+			Return(&dns.Error{StatusCode: http.StatusConflict}).Once()
+		mockZoneRecordsets(client, "example.com", state)
+
+		useClient(client, func() {
+			assert.NoError(t, applyRecordsets(context.Background(), nil, "example.com", false, hosts(0, 1, "10.0.0.2"), hosts(0, 2, "10.0.0.1"), log.Log))
+		})
+
+		// the record sets are read again before the changes are submitted again
+		assert.Equal(t, []int{2, 0, 1}, state.requests())
+		assert.Equal(t, []string{"example.com NS", "example.com SOA", "host0.example.com A"}, state.keys())
+		client.AssertExpectations(t)
+	})
+}
+
+func TestIncrementSoaSerial(t *testing.T) {
+	soa, err := incrementSoaSerial(dns.RecordSet{Type: "SOA", Rdata: []string{"a1-1.akam.net. hostmaster.example.com. 4294967295 3600 600 604800 300"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a1-1.akam.net. hostmaster.example.com. 0 3600 600 604800 300"}, soa.Rdata)
+
+	_, err = incrementSoaSerial(dns.RecordSet{Type: "SOA", Rdata: []string{"a1-1.akam.net. hostmaster.example.com."}})
+	assert.Error(t, err)
+}
@@ -82,6 +82,17 @@ func resourceDNSv2Zone() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"zone_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+				// the zone file only seeds the records of a new zone
+				DiffSuppressFunc: func(_, _, _ string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
+				Description: "The content of a zone file in the BIND master file format, which the records of a new primary zone are created from. " +
+					"The SOA and NS records of the zone apex are skipped, as they are created along with the zone. " +
+					"Changes made after the zone is created are ignored",
+			},
 			"tsig_key": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -176,6 +187,17 @@ func resourceDNSv2ZoneCreate(ctx context.Context, d *schema.ResourceData, m inte
 		}
 	}
 
+	zoneFile, err := tf.GetStringValue("zone_file", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	if zoneFile != "" {
+		// report invalid records before the zone is created
+		if _, err := parseZoneFile(ctx, meta, hostname, zoneFile, logger); err != nil {
+			return diag.Errorf("invalid zone file of zone %s: %s", hostname, err)
+		}
+	}
+
 	contract := strings.TrimPrefix(contractStr, "ctr_")
 	group = strings.TrimPrefix(group, "grp_")
 	zoneQueryString := dns.ZoneQueryString{Contract: contract, Group: group}
@@ -246,10 +268,41 @@ func resourceDNSv2ZoneCreate(ctx context.Context, d *schema.ResourceData, m inte
 		})
 	}
 	d.SetId(fmt.Sprintf("%s#%s#%s", zone.VersionID, zone.Zone, hostname))
-	return resourceDNSv2ZoneRead(ctx, d, meta)
+
+	if strings.ToUpper(zoneType) == "PRIMARY" && zoneFile != "" {
+		// the zone cannot be deleted, so a seeding failure must not taint it and is reported as a warning only
+		logger.Debugf("Seeding zone %s from zone file", hostname)
+		if e = seedZoneRecordsets(ctx, meta, hostname, zoneFile, logger); e != nil {
+			logger.Warnf("Seeding zone %s failed: %s", hostname, e)
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Zone seeding failure",
+				Detail:   fmt.Sprintf("%s. The zone was created, the missing records can be added with akamai_dns_record or akamai_dns_recordsets", e),
+			})
+		}
+	}
+	return append(diags, resourceDNSv2ZoneRead(ctx, d, meta)...)
 
 }
 
+// seedZoneRecordsets adds the record sets of the zone file to the new zone, except for the SOA and NS
+// record sets of the zone apex, which are created along with the zone
+func seedZoneRecordsets(ctx context.Context, meta meta.Meta, zone, zoneFile string, logger log.Interface) error {
+	recordsets, err := parseZoneFile(ctx, meta, zone, zoneFile, logger)
+	if err != nil {
+		return err
+	}
+	seeded := make([]dns.RecordSet, 0, len(recordsets))
+	for _, rs := range recordsets {
+		if isZoneApexRecordset(zone, rs) {
+			logger.Debugf("Skipping record set %s of the zone apex", recordsetKey(rs))
+			continue
+		}
+		seeded = append(seeded, rs)
+	}
+	return applyRecordsets(ctx, meta, zone, false, seeded, nil, logger)
+}
+
 func resourceDNSv2ZoneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -638,6 +691,10 @@ func checkDNSv2Zone(d tf.ResourceDataFetcher) error {
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
 	}
+	zoneFile, err := tf.GetStringValue("zone_file", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return err
+	}
 	ztype := strings.ToUpper(zoneType)
 	masters := mastersSet.List()
 	if ztype == "SECONDARY" && len(masters) == 0 {
@@ -658,6 +715,9 @@ func checkDNSv2Zone(d tf.ResourceDataFetcher) error {
 	if ztype != "SECONDARY" && len(tsig) > 0 {
 		return fmt.Errorf("tsig_key can not be populated in %s zone %s configuration", ztype, zone)
 	}
+	if ztype != "PRIMARY" && zoneFile != "" {
+		return fmt.Errorf("zone_file can not be populated in %s zone %s configuration", ztype, zone)
+	}
 
 	return nil
 
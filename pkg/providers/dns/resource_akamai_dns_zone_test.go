@@ -268,4 +268,122 @@ func TestResDNSZone(t *testing.T) {
 
 		client.AssertExpectations(t)
 	})
+
+	// mockSeededZoneCreation sets up the creation of the primary zone with its default SOA and NS records,
+	// followed by the addition of the record sets of the zone file
+	mockSeededZoneCreation := func(client *dns.Mock, seedErr error) {
+		seededZone := *zone
+		seededZone.Comment = "This is a test primary zone"
+
+		getCall := client.On("GetZone",
+			mock.Anything,
+			zone.Zone,
+		).Return(nil, &dns.Error{
+			StatusCode: http.StatusNotFound,
+		})
+
+		client.On("CreateZone",
+			mock.Anything,
+			mock.AnythingOfType("*dns.ZoneCreate"),
+			mock.AnythingOfType("dns.ZoneQueryString"),
+			true,
+		).Return(nil).Run(func(args mock.Arguments) {
+			getCall.ReturnArguments = mock.Arguments{&seededZone, nil}
+		})
+
+		client.On("SaveChangelist",
+			mock.Anything,
+			mock.AnythingOfType("*dns.ZoneCreate"),
+		).Return(nil)
+
+		client.On("SubmitChangelist",
+			mock.Anything,
+			mock.AnythingOfType("*dns.ZoneCreate"),
+		).Return(nil)
+
+		client.On("GetRecordSets",
+			mock.Anything,
+			zone.Zone,
+			mock.AnythingOfType("[]dns.RecordSetQueryArgs"),
+		).Return(recordSetsResp, nil)
+
+		// the NS record set of the zone apex is skipped, as it is created with the zone
+		client.On("CreateRecordSets",
+			mock.Anything,
+			&dns.RecordSets{RecordSets: []dns.RecordSet{
+				{Name: "www.primaryexampleterraform.io", Type: "A", TTL: 300, Rdata: []string{"10.0.0.1"}},
+			}},
+			zone.Zone,
+		).Return(seedErr).Once()
+
+		mockParseRData(client)
+	}
+
+	t.Run("primary zone seeded from zone file", func(t *testing.T) {
+		client := &dns.Mock{}
+		mockSeededZoneCreation(client, nil)
+
+		dataSourceName := "akamai_dns_zone.primary_test_zone"
+
+		// work around to skip Delete which fails intentionally
+		err := os.Setenv("DNS_ZONE_SKIP_DELETE", "")
+		require.NoError(t, err)
+		defer func() {
+			err = os.Unsetenv("DNS_ZONE_SKIP_DELETE")
+			require.NoError(t, err)
+		}()
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResDnsZone/create_primary_from_zone_file.tf"),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(dataSourceName, "zone", "primaryexampleterraform.io"),
+							resource.TestCheckResourceAttr(dataSourceName, "zone_file", "$TTL 1h\n@ 3600 IN NS a1-1.akam.net.\nwww 300 IN A 10.0.0.1\n"),
+						),
+					},
+					{
+						// changes of the zone file made after the zone is created are ignored
+						Config:   testutils.LoadFixtureString(t, "testdata/TestResDnsZone/update_primary_zone_file.tf"),
+						PlanOnly: true,
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("zone is not tainted when seeding fails", func(t *testing.T) {
+		client := &dns.Mock{}
+		mockSeededZoneCreation(client, &dns.Error{StatusCode: http.StatusBadRequest, Title: "Invalid record set"})
+
+		// work around to skip Delete which fails intentionally
+		err := os.Setenv("DNS_ZONE_SKIP_DELETE", "")
+		require.NoError(t, err)
+		defer func() {
+			err = os.Unsetenv("DNS_ZONE_SKIP_DELETE")
+			require.NoError(t, err)
+		}()
+		useClient(client, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						// the seeding failure is reported as a warning
+						Config: testutils.LoadFixtureString(t, "testdata/TestResDnsZone/create_primary_from_zone_file.tf"),
+						Check:  resource.TestCheckResourceAttr("akamai_dns_zone.primary_test_zone", "zone", "primaryexampleterraform.io"),
+					},
+					{
+						// the created zone is not replaced, as it cannot be deleted
+						Config:   testutils.LoadFixtureString(t, "testdata/TestResDnsZone/create_primary_from_zone_file.tf"),
+						PlanOnly: true,
+					},
+				},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
 }
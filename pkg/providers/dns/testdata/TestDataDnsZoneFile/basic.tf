@@ -0,0 +1,22 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_dns_zone_file" "test" {
+  zone    = "example.com"
+  content = <<-EOT
+    $TTL 1h
+    @   IN  SOA ns1.example.net. hostmaster (
+                2024010101 ; serial
+                3600 600 604800 300 )
+            IN  NS  ns1.example.net.
+    www 300 IN  A   10.0.0.1
+            IN  A   10.0.0.2
+    v6      AAAA    2001:db8::1
+    @       MX  10 mail
+            MX  20 mail2.example.net.
+    _sip._tcp SRV 10 60 5060 sip
+    txt     TXT "v=spf1 include:_spf.example.com" "-all"
+    loc     LOC 52 22 23 N 4 53 32 E -2m
+  EOT
+}
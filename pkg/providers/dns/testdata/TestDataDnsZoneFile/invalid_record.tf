@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_dns_zone_file" "test" {
+  zone    = "example.com"
+  content = <<-EOT
+    $TTL 1h
+    www       A   10.0.0.1
+    _sip._tcp SRV 10 60 0 sip
+  EOT
+}
@@ -0,0 +1,22 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_recordsets" "test" {
+  zone          = "example.com"
+  authoritative = true
+
+  recordset {
+    name  = "www.example.com"
+    type  = "A"
+    ttl   = 300
+    rdata = ["10.0.0.2", "10.0.0.1"]
+  }
+
+  recordset {
+    name  = "v6.example.com"
+    type  = "AAAA"
+    ttl   = 600
+    rdata = ["2001:db8::1"]
+  }
+}
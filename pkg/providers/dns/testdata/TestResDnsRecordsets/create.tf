@@ -0,0 +1,21 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_recordsets" "test" {
+  zone = "example.com"
+
+  recordset {
+    name  = "www.example.com"
+    type  = "A"
+    ttl   = 300
+    rdata = ["10.0.0.1"]
+  }
+
+  recordset {
+    name  = "example.com"
+    type  = "TXT"
+    ttl   = 300
+    rdata = ["v=spf1 -all"]
+  }
+}
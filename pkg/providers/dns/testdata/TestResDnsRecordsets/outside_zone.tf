@@ -0,0 +1,14 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_recordsets" "test" {
+  zone = "example.com"
+
+  recordset {
+    name  = "www.example.org"
+    type  = "A"
+    ttl   = 300
+    rdata = ["10.0.0.1"]
+  }
+}
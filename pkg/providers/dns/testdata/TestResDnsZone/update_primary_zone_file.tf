@@ -0,0 +1,16 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_dns_zone" "primary_test_zone" {
+  contract       = "ctr1"
+  zone           = "primaryexampleterraform.io"
+  type           = "primary"
+  comment        = "This is a test primary zone"
+  sign_and_serve = false
+  group          = "grp1"
+  zone_file      = <<-EOT
+    $TTL 1h
+    www 300 IN A 10.0.0.2
+  EOT
+}
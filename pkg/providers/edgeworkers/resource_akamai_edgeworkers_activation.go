@@ -15,6 +15,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -455,6 +456,9 @@ func versionExists(version string, versions []edgeworkers.EdgeWorkerVersion) boo
 }
 
 func waitForEdgeworkerActivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID, activationID int) (*edgeworkers.Activation, error) {
+	ctx, span := tracing.StartSpan(ctx, "edgeworkers.waitForActivation")
+	defer span.End()
+
 	activation, err := client.GetActivation(ctx, edgeworkers.GetActivationRequest{
 		EdgeWorkerID: edgeworkerID,
 		ActivationID: activationID,
@@ -489,6 +493,9 @@ func waitForEdgeworkerActivation(ctx context.Context, client edgeworkers.Edgewor
 }
 
 func waitForEdgeworkerDeactivation(ctx context.Context, client edgeworkers.Edgeworkers, edgeworkerID, deactivationID int) (*edgeworkers.Deactivation, error) {
+	ctx, span := tracing.StartSpan(ctx, "edgeworkers.waitForDeactivation")
+	defer span.End()
+
 	deactivation, err := client.GetDeactivation(ctx, edgeworkers.GetDeactivationRequest{
 		EdgeWorkerID:   edgeworkerID,
 		DeactivationID: deactivationID,
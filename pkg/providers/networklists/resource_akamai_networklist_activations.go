@@ -11,6 +11,7 @@ import (
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/networklists"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -288,6 +289,9 @@ func suppressNoteFieldForNetworkListActivation(_, oldValue, newValue string, d *
 }
 
 func pollActivation(ctx context.Context, client networklists.NTWRKLISTS, activationStatus string, activationID int) error {
+	ctx, span := tracing.StartSpan(ctx, "networklists.pollActivation")
+	defer span.End()
+
 	retriesMax := 5
 	retries5xx := 0
 
@@ -45,4 +45,23 @@ func TestDataContracts(t *testing.T) {
 
 		client.AssertExpectations(t)
 	})
+	t.Run("list contracts replayed from cassette", func(t *testing.T) {
+		client := papi.Client(testutils.ReplaySession(t, "testdata/TestDataContracts/cassette"))
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{{
+					Config: testutils.LoadFixtureString(t, "testdata/TestDataContracts/contracts.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.akamai_contracts.akacontracts", "id", "act_test"),
+						resource.TestCheckOutput("aka_contract_id1", "ctr_test1"),
+						resource.TestCheckOutput("aka_contract_id2", "ctr_test2"),
+						resource.TestCheckOutput("aka_contract_typ_name1", "ctr_typ_name_test1"),
+						resource.TestCheckOutput("aka_contract_typ_name2", "ctr_typ_name_test2"),
+					),
+				}},
+			})
+		})
+	})
 }
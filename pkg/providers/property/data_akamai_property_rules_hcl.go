@@ -0,0 +1,68 @@
+package property
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/property/ruleformats"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePropertyRulesHCL() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePropertyRulesHCLRead,
+		Schema: map[string]*schema.Schema{
+			"rule_format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Frozen rule format in which the rules builder data sources are generated, e.g. 'v2024-02-12'",
+				ValidateDiagFunc: tf.ValidateRuleFormat,
+			},
+			"rules": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Property or include rules as JSON, e.g. from akamai_property_rules data source",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+			},
+			"hcl": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "akamai_property_rules_builder data sources, one for every rule, which build the provided rules",
+			},
+		},
+	}
+}
+
+func dataSourcePropertyRulesHCLRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("PAPI", "dataSourcePropertyRulesHCLRead")
+	logger.Debug("exporting property rules to HCL")
+
+	ruleFormat := d.Get("rule_format").(string)
+	rulesJSON := d.Get("rules").(string)
+
+	var rulesUpdate papi.RulesUpdate
+	if err := json.Unmarshal([]byte(rulesJSON), &rulesUpdate); err != nil {
+		return diag.Errorf("cannot parse rules JSON: %s", err)
+	}
+
+	hcl, err := ruleformats.ExportHCL(ruleFormat, rulesUpdate.Rules)
+	if err != nil {
+		return diag.Errorf("exporting rules: %s", err)
+	}
+
+	if err := d.Set("hcl", string(hcl)); err != nil {
+		return diag.Errorf("setting hcl in schema: %s", err)
+	}
+
+	sum := md5.Sum([]byte(ruleFormat + rulesJSON))
+	d.SetId(hex.EncodeToString(sum[:]))
+	return nil
+}
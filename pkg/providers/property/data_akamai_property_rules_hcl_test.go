@@ -0,0 +1,76 @@
+package property
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/property/ruleformats"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataPropertyRulesHCL(t *testing.T) {
+	t.Run("rules exported to rules builder data sources", func(t *testing.T) {
+		useClient(nil, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{{
+					Config: testutils.LoadFixtureString(t, "testdata/TestDSPropertyRulesHCL/rules.tf"),
+					Check: resource.TestCheckResourceAttr("data.akamai_property_rules_hcl.test", "hcl",
+						testutils.LoadFixtureString(t, "testdata/TestDSPropertyRulesHCL/expected.tf")),
+				}},
+			})
+		})
+	})
+	t.Run("fails on rules not matching rule format", func(t *testing.T) {
+		useClient(nil, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestDSPropertyRulesHCL/invalid_rules.tf"),
+					ExpectError: regexp.MustCompile(`rules do not match the rule format:\s+#/rules/behaviors/0/name: unknown behavior "teleport"`),
+				}},
+			})
+		})
+	})
+}
+
+func TestExportedHCLBuildsSameRules(t *testing.T) {
+	fixtures := []string{
+		"default.json",
+		"default_v2023_05_30.json",
+		"default_v2023_09_20.json",
+		"default_v2023_10_30.json",
+		"default_v2024_01_09.json",
+		"default_v2024_01_09_with_empty_options.json",
+		"default_v2024_02_12.json",
+		"default_variables.json",
+	}
+
+	for _, fixture := range fixtures {
+		t.Run(fixture, func(t *testing.T) {
+			rulesJSON := testutils.LoadFixtureString(t, "testdata/TestDSPropertyRulesBuilder/%s", fixture)
+			var rules ruleformats.RulesUpdate
+			require.NoError(t, json.Unmarshal([]byte(rulesJSON), &rules))
+
+			hcl, err := ruleformats.ExportHCL(rules.RuleFormat, rules.Rules)
+			require.NoError(t, err)
+
+			useClient(nil, nil, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{{
+						Config: `provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+` + string(hcl),
+						Check: testCheckResourceAttrJSON("data.akamai_property_rules_builder.default", "json", rulesJSON),
+					}},
+				})
+			})
+		})
+	}
+}
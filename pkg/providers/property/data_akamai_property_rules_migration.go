@@ -0,0 +1,142 @@
+package property
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/property/ruleformats"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePropertyRulesMigration() *schema.Resource {
+	return &schema.Resource{
+		Description: "Migrates property or include rules between frozen rule formats. Behaviors, criteria and options are matched " +
+			"between the rule formats by their names, and those renamed by the API are renamed according to the renames known for the pair of rule formats. " +
+			"Other renamed ones are reported in issues as unknown in the target rule format.",
+		ReadContext: dataSourcePropertyRulesMigrationRead,
+		Schema: map[string]*schema.Schema{
+			"source_rule_format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Frozen rule format of the provided rules, e.g. 'v2023-01-05'",
+				ValidateDiagFunc: tf.ValidateRuleFormat,
+			},
+			"target_rule_format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Frozen rule format to which the rules are migrated, e.g. 'v2024-02-12'",
+				ValidateDiagFunc: tf.ValidateRuleFormat,
+			},
+			"rules": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Property or include rules as JSON",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+			},
+			"migrated_rules": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Rules migrated to the target rule format as JSON",
+			},
+			"changes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Changes applied to the rules during the migration",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON path to the changed value in the provided rules, e.g. '#/rules/behaviors/1/options/logStreamName'",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the change",
+						},
+					},
+				},
+			},
+			"issues": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Violations of the target rule format which could not be migrated automatically",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON path to the invalid value in the migrated rules, e.g. '#/rules/children/0/behaviors/1/name'",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the violation",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePropertyRulesMigrationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("PAPI", "dataSourcePropertyRulesMigrationRead")
+	logger.Debug("migrating property rules")
+
+	sourceRuleFormat := d.Get("source_rule_format").(string)
+	targetRuleFormat := d.Get("target_rule_format").(string)
+	rulesJSON := d.Get("rules").(string)
+
+	var rulesUpdate papi.RulesUpdate
+	if err := json.Unmarshal([]byte(rulesJSON), &rulesUpdate); err != nil {
+		return diag.Errorf("cannot parse rules JSON: %s", err)
+	}
+
+	result, err := ruleformats.MigrateRules(sourceRuleFormat, targetRuleFormat, rulesUpdate.Rules)
+	if err != nil {
+		return diag.Errorf("migrating rules: %s", err)
+	}
+
+	rulesUpdate.Rules = result.Rules
+	migratedJSON, err := json.MarshalIndent(rulesUpdate, "", "  ")
+	if err != nil {
+		return diag.Errorf("cannot encode migrated rules: %s", err)
+	}
+
+	changes := make([]map[string]interface{}, 0, len(result.Changes))
+	for _, c := range result.Changes {
+		changes = append(changes, map[string]interface{}{
+			"path":    c.Path,
+			"message": c.Message,
+		})
+	}
+	issues := make([]map[string]interface{}, 0, len(result.Issues))
+	for _, e := range result.Issues {
+		issues = append(issues, map[string]interface{}{
+			"path":    e.Path,
+			"message": e.Message,
+		})
+	}
+
+	attrs := map[string]interface{}{
+		"migrated_rules": string(migratedJSON),
+		"changes":        changes,
+		"issues":         issues,
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	sum := md5.Sum([]byte(sourceRuleFormat + targetRuleFormat + rulesJSON))
+	d.SetId(hex.EncodeToString(sum[:]))
+	return nil
+}
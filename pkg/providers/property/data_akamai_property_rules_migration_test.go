@@ -0,0 +1,54 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataPropertyRulesMigration(t *testing.T) {
+	tests := map[string]struct {
+		configPath  string
+		checks      resource.TestCheckFunc
+		expectError *regexp.Regexp
+	}{
+		"migrate rules": {
+			configPath: "testdata/TestDSPropertyRulesMigration/migration.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				testCheckResourceAttrJSON("data.akamai_property_rules_migration.test", "migrated_rules",
+					testutils.LoadFixtureString(t, "testdata/TestDSPropertyRulesMigration/expected.json")),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_migration.test", "changes.#", "2"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_migration.test", "changes.0.path", "#/rules/behaviors/0/options/logStreamName"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_migration.test", "changes.0.message", "value 1234 converted to a list"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_migration.test", "changes.1.path", "#/rules/children/0/behaviors/0/options/startIndex"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_migration.test", "changes.1.message", "value 2 converted to int"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_migration.test", "issues.#", "2"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_migration.test", "issues.0.path", "#/rules/children/0/behaviors/0/options/endIndex"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_migration.test", "issues.0.message", "expected integer, got string"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_migration.test", "issues.1.path", "#/rules/children/1/behaviors/0/name"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_migration.test", "issues.1.message", `unknown behavior "shutr"`),
+			),
+		},
+		"unsupported rule format": {
+			configPath:  "testdata/TestDSPropertyRulesMigration/unsupported_rule_format.tf",
+			expectError: regexp.MustCompile("unsupported rule format: v2015-08-17"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			useClient(nil, nil, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{{
+						Config:      testutils.LoadFixtureString(t, test.configPath),
+						Check:       test.checks,
+						ExpectError: test.expectError,
+					}},
+				})
+			})
+		})
+	}
+}
@@ -3,6 +3,7 @@ package property
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"crypto/sha1"
 	"encoding/hex"
 	"encoding/json"
@@ -208,7 +209,7 @@ func dataPropertyRulesTemplateRead(_ context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	tmpl, err := template.New("main").Delims(leftDelim, rightDelim).Option("missingkey=error").Parse(templateStr)
+	tmpl, err := template.New("main").Delims(leftDelim, rightDelim).Funcs(templateFuncs).Option("missingkey=error").Parse(templateStr)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -262,7 +263,7 @@ func dataPropertyRulesTemplateRead(_ context.Context, d *schema.ResourceData, m
 	d.SetId(shaHash)
 
 	formatted := bytes.Buffer{}
-	result := wr.Bytes()
+	result := removeOmittedElements(wr.Bytes())
 	err = json.Indent(&formatted, result, "", "  ")
 	if err != nil {
 		logger.Debugf("Creating rule tree resulted in invalid JSON: %s\nError: %s", result, err)
@@ -343,10 +344,61 @@ func checkCircularDependency(input string, seenVariables []string, varsMap map[s
 
 var (
 	includeRegexp         = regexp.MustCompile(`"#include:.+?"`)
+	includeIfRegexp       = regexp.MustCompile(`"#includeIf:([^":]*):([^"]*)"`)
+	includeEachRegexp     = regexp.MustCompile(`"#includeEach:([^":]*):([^"]*)"`)
 	partialVariableRegexp = regexp.MustCompile(`\${env\.([^$}]+?)}`)
+	quotedEachRegexp      = regexp.MustCompile(`"\${(each\.(?:index|value(?:\.[A-Za-z_][A-Za-z0-9_]*)*))}"`)
+	partialEachRegexp     = regexp.MustCompile(`\${(each\.(?:index|value(?:\.[A-Za-z_][A-Za-z0-9_]*)*))}`)
+	omittedElementRegexp  = regexp.MustCompile(`"` + omittedElement + `"\s*,\s*|\s*,\s*"` + omittedElement + `"|"` + omittedElement + `"`)
 	jsonFileRegexp        = regexp.MustCompile(`\.json+$`)
 )
 
+// omittedElement is a placeholder of the array element, which is removed from the result,
+// e.g. when the condition of '#includeIf' directive is false. It contains a random suffix,
+// so that it never matches values of the rules or variables.
+var omittedElement = newOmittedElement()
+
+// eachVariable is the name of the variable holding the current element of '#includeEach' loop
+const eachVariable = "each"
+
+func newOmittedElement() string {
+	suffix := make([]byte, 16)
+	if _, err := rand.Read(suffix); err != nil {
+		panic(fmt.Sprintf("cannot generate omitted element placeholder: %s", err))
+	}
+	return "#omitted:" + hex.EncodeToString(suffix)
+}
+
+// templateFuncs are the functions used by the templates generated from '#includeEach' directive and '${each.*}' references
+var templateFuncs = template.FuncMap{
+	"each": func(data map[string]interface{}, name string, index int) (map[string]interface{}, error) {
+		list, err := listVariable(data, name)
+		if err != nil {
+			return nil, err
+		}
+		if index >= len(list) {
+			return nil, fmt.Errorf("index %d out of range of variable %q", index, name)
+		}
+		eachData := make(map[string]interface{}, len(data)+1)
+		for k, v := range data {
+			eachData[k] = v
+		}
+		eachData[eachVariable] = map[string]interface{}{"index": index, "value": list[index]}
+		return eachData, nil
+	},
+	"toJSON": func(value interface{}) (string, error) {
+		b, err := json.Marshal(value)
+		return string(b), err
+	},
+	"toJSONContent": func(value interface{}) (string, error) {
+		b, err := json.Marshal(value)
+		if _, ok := value.(string); ok {
+			b = bytes.TrimSuffix(bytes.TrimPrefix(b, []byte(`"`)), []byte(`"`))
+		}
+		return string(b), err
+	},
+}
+
 var (
 	// ErrReadFile is used to specify error while reading a file.
 	ErrReadFile = errors.New("reading file")
@@ -355,7 +407,9 @@ var (
 	// ErrFormatValue is used to specify formatting error.
 	ErrFormatValue = errors.New("formatting value")
 	// ErrUnknownType is used to specify unknown error.
-	ErrUnknownType       = errors.New("unknown 'type' value")
+	ErrUnknownType = errors.New("unknown 'type' value")
+	// ErrTemplateDirective is used to specify invalid conditional or loop statement in the template.
+	ErrTemplateDirective = errors.New("invalid template directive")
 	matchingErrorMessage = "there was a problem matching %q"
 )
 
@@ -394,11 +448,19 @@ func flattenTemplate(templateList []interface{}) (string, string, error) {
 
 // stringToTemplate takes a large string (templateDataStr) and formats include/variable statements.
 func stringToTemplate(templateDataStr string, varsMap map[string]interface{}, templatePath string) (string, error) {
-	templateDataStr, err := evaluateVariables(templateDataStr, varsMap, templatePath)
+	templateDataStr, err := evaluateDirectives(templateDataStr, varsMap, templatePath)
+	if err != nil {
+		return "", err
+	}
+
+	templateDataStr, err = evaluateVariables(templateDataStr, varsMap, templatePath)
 	if err != nil {
 		return "", err
 	}
 
+	templateDataStr = quotedEachRegexp.ReplaceAllString(templateDataStr, fmt.Sprintf("%stoJSON .$1%s", leftDelim, rightDelim))
+	templateDataStr = partialEachRegexp.ReplaceAllString(templateDataStr, fmt.Sprintf("%stoJSONContent .$1%s", leftDelim, rightDelim))
+
 	includeStatement := includeRegexp.FindString(templateDataStr)
 	for len(includeStatement) > 0 {
 		templateName := strings.TrimPrefix(strings.TrimSuffix(includeStatement, `"`), `"#include:`)
@@ -437,6 +499,90 @@ func evaluateVariables(template string, varsMap map[string]interface{}, template
 	return template, nil
 }
 
+// evaluateDirectives formats conditional and loop statements:
+//   - "#includeIf:<variable>:<snippet>" includes the snippet only if the bool variable is true
+//   - "#includeEach:<variable>:<snippet>" includes the snippet once for every element of the list variable, with the element available
+//     in the snippet as ${each.value} (or ${each.value.<field>} for objects) and its index as ${each.index}
+//
+// Both statements are meant to be elements of an array, e.g. children of a rule. Errors are reported with the template path and line.
+func evaluateDirectives(template string, varsMap map[string]interface{}, templatePath string) (string, error) {
+	var err error
+	replaceDirective := func(regex *regexp.Regexp, replace func(variable, snippet string) (string, error)) string {
+		var result strings.Builder
+		last := 0
+		for _, match := range regex.FindAllStringSubmatchIndex(template, -1) {
+			replacement, replaceErr := replace(template[match[2]:match[3]], template[match[4]:match[5]])
+			if replaceErr != nil && err == nil {
+				err = fmt.Errorf("%s:%d: %w", templatePath, strings.Count(template[:match[0]], "\n")+1, replaceErr)
+			}
+			result.WriteString(template[last:match[0]])
+			result.WriteString(replacement)
+			last = match[1]
+		}
+		result.WriteString(template[last:])
+		return result.String()
+	}
+
+	template = replaceDirective(includeIfRegexp, func(variable, snippet string) (string, error) {
+		value, ok := varsMap[variable]
+		if !ok {
+			return "", fmt.Errorf("%w: variable %q used in '#includeIf' is not defined", ErrTemplateDirective, variable)
+		}
+		condition, ok := value.(bool)
+		if !ok {
+			return "", fmt.Errorf("%w: variable %q used in '#includeIf' should be of bool type, got: %v", ErrTemplateDirective, variable, value)
+		}
+		if !condition {
+			return fmt.Sprintf(`"%s"`, omittedElement), nil
+		}
+		return fmt.Sprintf(`%stemplate "%s" .%s`, leftDelim, snippet, rightDelim), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	template = replaceDirective(includeEachRegexp, func(variable, snippet string) (string, error) {
+		if _, ok := varsMap[eachVariable]; ok {
+			return "", fmt.Errorf("%w: variable %q cannot be defined, as it holds the element of '#includeEach' loop", ErrTemplateDirective, eachVariable)
+		}
+		list, listErr := listVariable(varsMap, variable)
+		if listErr != nil {
+			return "", fmt.Errorf("%w: '#includeEach': %s", ErrTemplateDirective, listErr)
+		}
+		if len(list) == 0 {
+			return fmt.Sprintf(`"%s"`, omittedElement), nil
+		}
+		elements := make([]string, 0, len(list))
+		for i := range list {
+			elements = append(elements, fmt.Sprintf(`%stemplate "%s" (each . "%s" %d)%s`, leftDelim, snippet, variable, i, rightDelim))
+		}
+		return strings.Join(elements, ","), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return template, nil
+}
+
+// listVariable returns the elements of the list variable, defined as jsonBlock
+func listVariable(varsMap map[string]interface{}, name string) ([]interface{}, error) {
+	value, ok := varsMap[name]
+	if !ok {
+		return nil, fmt.Errorf("variable %q is not defined", name)
+	}
+	var list []interface{}
+	if err := json.Unmarshal([]byte(fmt.Sprintf("%v", value)), &list); err != nil {
+		return nil, fmt.Errorf("variable %q should be a list, got: %v", name, value)
+	}
+	return list, nil
+}
+
+// removeOmittedElements removes the placeholders of omitted array elements together with their separators
+func removeOmittedElements(result []byte) []byte {
+	return omittedElementRegexp.ReplaceAll(result, nil)
+}
+
 // convertToTemplate passes the string data to stringToTemplate after reading it from given path.
 func convertToTemplate(path string, varsMap map[string]interface{}) (string, error) {
 	b, err := ioutil.ReadFile(path)
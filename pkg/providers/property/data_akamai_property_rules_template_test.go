@@ -740,3 +740,117 @@ func TestMultipleTemplates(t *testing.T) {
 		})
 	})
 }
+
+func TestTemplateDirectives(t *testing.T) {
+	tests := map[string]struct {
+		configPath   string
+		expectedPath string
+		withError    string
+	}{
+		"conditional snippet omitted and snippet included for every list element": {
+			configPath:   "testdata/TestDSRulesTemplate/template_conditional_rules.tf",
+			expectedPath: "testdata/TestDSRulesTemplate/output/template_conditional_rules.json",
+		},
+		"conditional snippet included and empty list": {
+			configPath:   "testdata/TestDSRulesTemplate/template_conditional_rules_included.tf",
+			expectedPath: "testdata/TestDSRulesTemplate/output/template_conditional_rules_included.json",
+		},
+		"condition is not bool": {
+			configPath: "testdata/TestDSRulesTemplate/template_conditional_invalid.tf",
+			withError:  `conditional-rules/main.json:15: invalid template directive: variable "compression" used in '#includeIf'\s+should be of bool type`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := papi.Mock{}
+			useClient(&client, nil, func() {
+				step := resource.TestStep{
+					Config: testutils.LoadFixtureString(t, test.configPath),
+				}
+				if test.withError != "" {
+					step.ExpectError = regexp.MustCompile(test.withError)
+				} else {
+					step.Check = resource.TestCheckResourceAttr("data.akamai_property_rules_template.test", "json", testutils.LoadFixtureString(t, test.expectedPath))
+				}
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps:                    []resource.TestStep{step},
+				})
+			})
+		})
+	}
+}
+
+func TestEvaluateDirectives(t *testing.T) {
+	tests := map[string]struct {
+		template  string
+		varsMap   map[string]interface{}
+		expected  string
+		withError string
+	}{
+		"condition true": {
+			template: `["#includeIf:enabled:snippet.json"]`,
+			varsMap:  map[string]interface{}{"enabled": true},
+			expected: `[@+#template "snippet.json" .#+@]`,
+		},
+		"condition false": {
+			template: `["#include:a.json", "#includeIf:enabled:snippet.json"]`,
+			varsMap:  map[string]interface{}{"enabled": false},
+			expected: `["#include:a.json", "` + omittedElement + `"]`,
+		},
+		"list": {
+			template: `["#includeEach:hosts:host.json"]`,
+			varsMap:  map[string]interface{}{"hosts": `["a", "b"]`},
+			expected: `[@+#template "host.json" (each . "hosts" 0)#+@,@+#template "host.json" (each . "hosts" 1)#+@]`,
+		},
+		"empty list": {
+			template: `["#includeEach:hosts:host.json"]`,
+			varsMap:  map[string]interface{}{"hosts": `[]`},
+			expected: `["` + omittedElement + `"]`,
+		},
+		"condition not defined": {
+			template:  "[\n\"#includeIf:enabled:snippet.json\"]",
+			withError: `main:2: invalid template directive: variable "enabled" used in '#includeIf' is not defined`,
+		},
+		"not a list": {
+			template:  `["#includeEach:hosts:host.json"]`,
+			varsMap:   map[string]interface{}{"hosts": `"a"`},
+			withError: `main:1: invalid template directive: '#includeEach': variable "hosts" should be a list, got: "a"`,
+		},
+		"each variable defined": {
+			template:  `["#includeEach:hosts:host.json"]`,
+			varsMap:   map[string]interface{}{"hosts": `["a"]`, "each": "b"},
+			withError: `main:1: invalid template directive: variable "each" cannot be defined, as it holds the element of '#includeEach' loop`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := evaluateDirectives(test.template, test.varsMap, "main")
+			if test.withError != "" {
+				assert.EqualError(t, err, test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestRemoveOmittedElements(t *testing.T) {
+	tests := map[string]struct {
+		given    string
+		expected string
+	}{
+		"first element":   {given: `["` + omittedElement + `", {"a": 1}]`, expected: `[{"a": 1}]`},
+		"last element":    {given: `[{"a": 1}, "` + omittedElement + `"]`, expected: `[{"a": 1}]`},
+		"middle element":  {given: `[1, "` + omittedElement + `", 2]`, expected: `[1, 2]`},
+		"only elements":   {given: "[\n  \"" + omittedElement + "\",\n  \"" + omittedElement + "\"\n]", expected: "[\n  \n]"},
+		"nothing omitted": {given: `["a", "b"]`, expected: `["a", "b"]`},
+		"user values":     {given: `["#omitted", "a"]`, expected: `["#omitted", "a"]`},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, string(removeOmittedElements([]byte(test.given))))
+		})
+	}
+}
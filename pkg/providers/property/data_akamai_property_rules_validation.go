@@ -0,0 +1,99 @@
+package property
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/property/ruleformats"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePropertyRulesValidation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePropertyRulesValidationRead,
+		Schema: map[string]*schema.Schema{
+			"rule_format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Frozen rule format against which the rules are validated, e.g. 'v2024-02-12'",
+				ValidateDiagFunc: tf.ValidateRuleFormat,
+			},
+			"rules": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Property or include rules as JSON",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+			},
+			"valid": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "States whether the rules match the rule format",
+			},
+			"errors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Violations of the rule format found in the rules",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON path to the invalid value, e.g. '#/rules/children/0/behaviors/1/options/ttl'",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the violation",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePropertyRulesValidationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	logger := meta.Log("PAPI", "dataSourcePropertyRulesValidationRead")
+	logger.Debug("validating property rules")
+
+	ruleFormat := d.Get("rule_format").(string)
+	rulesJSON := d.Get("rules").(string)
+
+	var rulesUpdate papi.RulesUpdate
+	if err := json.Unmarshal([]byte(rulesJSON), &rulesUpdate); err != nil {
+		return diag.Errorf("cannot parse rules JSON: %s", err)
+	}
+
+	ruleErrors, err := ruleformats.ValidateRules(ruleFormat, rulesUpdate.Rules)
+	if err != nil {
+		return diag.Errorf("validating rules: %s", err)
+	}
+
+	errorsList := make([]map[string]interface{}, 0, len(ruleErrors))
+	for _, e := range ruleErrors {
+		errorsList = append(errorsList, map[string]interface{}{
+			"path":    e.Path,
+			"message": e.Message,
+		})
+	}
+
+	attrs := map[string]interface{}{
+		"valid":  len(ruleErrors) == 0,
+		"errors": errorsList,
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	sum := md5.Sum([]byte(ruleFormat + rulesJSON))
+	d.SetId(hex.EncodeToString(sum[:]))
+	return nil
+}
@@ -0,0 +1,56 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataPropertyRulesValidation(t *testing.T) {
+	tests := map[string]struct {
+		configPath  string
+		checks      resource.TestCheckFunc
+		expectError *regexp.Regexp
+	}{
+		"valid rules": {
+			configPath: "testdata/TestDSPropertyRulesValidation/valid.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_property_rules_validation.test", "valid", "true"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_validation.test", "errors.#", "0"),
+			),
+		},
+		"invalid rules": {
+			configPath: "testdata/TestDSPropertyRulesValidation/invalid.tf",
+			checks: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("data.akamai_property_rules_validation.test", "valid", "false"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_validation.test", "errors.#", "3"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_validation.test", "errors.0.path", "#/rules/behaviors/0/options/mustRevalidate"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_validation.test", "errors.0.message", "expected boolean, got string"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_validation.test", "errors.1.path", "#/rules/behaviors/0/options/ttl"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_validation.test", "errors.2.path", "#/rules/children/0/behaviors/0/name"),
+				resource.TestCheckResourceAttr("data.akamai_property_rules_validation.test", "errors.2.message", `unknown behavior "teleport"`),
+			),
+		},
+		"unsupported rule format": {
+			configPath:  "testdata/TestDSPropertyRulesValidation/unsupported_rule_format.tf",
+			expectError: regexp.MustCompile("unsupported rule format: v2015-08-17"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			useClient(nil, nil, func() {
+				resource.UnitTest(t, resource.TestCase{
+					ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+					Steps: []resource.TestStep{{
+						Config:      testutils.LoadFixtureString(t, test.configPath),
+						Check:       test.checks,
+						ExpectError: test.expectError,
+					}},
+				})
+			})
+		})
+	}
+}
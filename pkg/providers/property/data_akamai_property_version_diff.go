@@ -0,0 +1,264 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePropertyVersionDiff() *schema.Resource {
+	versionValidation := validation.ToDiagFunc(validation.Any(
+		validation.StringMatch(regexp.MustCompile(`^(ver_)?\d+$`), "must be a version number"),
+		validation.StringInSlice([]string{"staging", "production", "latest"}, true),
+	))
+	return &schema.Resource{
+		ReadContext: dataPropertyVersionDiffRead,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+			},
+			"contract_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+			},
+			"property_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        addPrefixToState("prp_"),
+				ValidateDiagFunc: tf.IsNotBlank,
+			},
+			"from_version": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: versionValidation,
+				Description:      "The version compared against, either a version number, 'staging', 'production' or 'latest'",
+			},
+			"to_version": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: versionValidation,
+				Description:      "The version compared, either a version number, 'staging', 'production' or 'latest'",
+			},
+			"from_version_number": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of the version compared against",
+			},
+			"to_version_number": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of the version compared",
+			},
+			"has_changes": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "States whether the versions differ in their rules or hostnames",
+			},
+			"rule_changes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Differences between the rule trees of the versions",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rule": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The path of the changed rule built from the names of the rule and its parents, e.g. '/default/Offload origin'",
+						},
+						"change": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the change, either 'added', 'removed' or 'modified'",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the change, e.g. 'behavior \"caching\" option \"ttl\": \"1d\" -> \"7d\"'",
+						},
+					},
+				},
+			},
+			"hostname_changes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Differences between the hostnames of the versions",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cname_from": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The hostname of the edge hostname mapping",
+						},
+						"change": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the change, either 'added', 'removed' or 'modified'",
+						},
+						"old_cname_to": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The edge hostname the hostname is mapped to in 'from_version'",
+						},
+						"new_cname_to": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The edge hostname the hostname is mapped to in 'to_version'",
+						},
+						"old_cert_provisioning_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The certificate provisioning type of the hostname in 'from_version'",
+						},
+						"new_cert_provisioning_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The certificate provisioning type of the hostname in 'to_version'",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataPropertyVersionDiffRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	client := Client(meta)
+	logger := meta.Log("PAPI", "dataPropertyVersionDiffRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(logger),
+	)
+	ctx = log.NewContext(ctx, logger)
+	logger.Debug("Comparing Property Versions")
+
+	groupID := str.AddPrefix(d.Get("group_id").(string), "grp_")
+	contractID := str.AddPrefix(d.Get("contract_id").(string), "ctr_")
+	propertyID := str.AddPrefix(d.Get("property_id").(string), "prp_")
+	fromVersion := d.Get("from_version").(string)
+	toVersion := d.Get("to_version").(string)
+
+	property, fromNumber, err := resolvePropertyVersion(ctx, client, propertyID, groupID, contractID, fromVersion)
+	if err != nil {
+		return diag.Errorf("resolving version %q: %s", fromVersion, err)
+	}
+	_, toNumber, err := resolvePropertyVersion(ctx, client, propertyID, groupID, contractID, toVersion)
+	if err != nil {
+		return diag.Errorf("resolving version %q: %s", toVersion, err)
+	}
+
+	fromRules, _, _, _, err := fetchPropertyVersionRules(ctx, client, *property, fromNumber)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	toRules, _, _, _, err := fetchPropertyVersionRules(ctx, client, *property, toNumber)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fromHostnames, err := fetchPropertyVersionHostnames(ctx, client, *property, fromNumber)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	toHostnames, err := fetchPropertyVersionHostnames(ctx, client, *property, toNumber)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ruleChanges := make([]interface{}, 0)
+	for _, change := range compareRuleTrees(fromRules.Rules, toRules.Rules) {
+		ruleChanges = append(ruleChanges, map[string]interface{}{
+			"rule":        change.path,
+			"change":      change.kind,
+			"description": change.description,
+		})
+	}
+	hostnameChanges := diffHostnames(fromHostnames, toHostnames)
+
+	attrs := map[string]interface{}{
+		"from_version_number": fromNumber,
+		"to_version_number":   toNumber,
+		"has_changes":         len(ruleChanges) > 0 || len(hostnameChanges) > 0,
+		"rule_changes":        ruleChanges,
+		"hostname_changes":    hostnameChanges,
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d:%d", propertyID, fromNumber, toNumber))
+	return nil
+}
+
+// resolvePropertyVersion returns the property and the number of the version, given as a number, 'staging', 'production' or 'latest'
+func resolvePropertyVersion(ctx context.Context, client papi.PAPI, propertyID, groupID, contractID, version string) (*papi.Property, int, error) {
+	if strings.EqualFold(version, "latest") {
+		property, err := fetchLatestProperty(ctx, client, propertyID, groupID, contractID)
+		if err != nil {
+			return nil, 0, err
+		}
+		return property, property.LatestVersion, nil
+	}
+	return fetchProperty(ctx, client, propertyID, groupID, contractID, version)
+}
+
+// diffHostnames returns the differences between the hostnames, matched by 'cname_from', ordered by 'cname_from'
+func diffHostnames(from, to []papi.Hostname) []interface{} {
+	hostnames := make(map[string][2]*papi.Hostname, len(from)+len(to))
+	for i := range from {
+		key := strings.ToLower(from[i].CnameFrom)
+		pair := hostnames[key]
+		pair[0] = &from[i]
+		hostnames[key] = pair
+	}
+	for i := range to {
+		key := strings.ToLower(to[i].CnameFrom)
+		pair := hostnames[key]
+		pair[1] = &to[i]
+		hostnames[key] = pair
+	}
+	keys := make([]string, 0, len(hostnames))
+	for key := range hostnames {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	changes := make([]interface{}, 0)
+	for _, key := range keys {
+		oldHostname, newHostname := hostnames[key][0], hostnames[key][1]
+		change := map[string]interface{}{}
+		switch {
+		case newHostname == nil:
+			change["cname_from"], change["change"] = oldHostname.CnameFrom, changeRemoved
+		case oldHostname == nil:
+			change["cname_from"], change["change"] = newHostname.CnameFrom, changeAdded
+		case oldHostname.CnameTo != newHostname.CnameTo || oldHostname.CertProvisioningType != newHostname.CertProvisioningType:
+			change["cname_from"], change["change"] = newHostname.CnameFrom, changeModified
+		default:
+			continue
+		}
+		if oldHostname != nil {
+			change["old_cname_to"], change["old_cert_provisioning_type"] = oldHostname.CnameTo, oldHostname.CertProvisioningType
+		}
+		if newHostname != nil {
+			change["new_cname_to"], change["new_cert_provisioning_type"] = newHostname.CnameTo, newHostname.CertProvisioningType
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
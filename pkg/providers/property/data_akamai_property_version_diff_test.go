@@ -0,0 +1,168 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDataPropertyVersionDiff(t *testing.T) {
+	rules := map[int]papi.Rules{
+		1: {
+			Name:      "default",
+			Behaviors: []papi.RuleBehavior{{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "origin.example.com"}}},
+			Children: []papi.Rules{
+				{Name: "Performance", Behaviors: []papi.RuleBehavior{{Name: "gzipResponse", Options: papi.RuleOptionsMap{"behavior": "ALWAYS"}}}},
+			},
+		},
+		2: {
+			Name:      "default",
+			Behaviors: []papi.RuleBehavior{{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "new.example.com"}}},
+			Children: []papi.Rules{
+				{Name: "Performance", Behaviors: []papi.RuleBehavior{{Name: "gzipResponse", Options: papi.RuleOptionsMap{"behavior": "ALWAYS"}}}},
+				{Name: "Images", Behaviors: []papi.RuleBehavior{{Name: "caching", Options: papi.RuleOptionsMap{"ttl": "7d"}}}},
+			},
+		},
+	}
+	hostnames := map[int][]papi.Hostname{
+		1: {
+			{CnameFrom: "www.example.com", CnameTo: "www.example.com.edgesuite.net", CertProvisioningType: "CPS_MANAGED"},
+			{CnameFrom: "old.example.com", CnameTo: "old.example.com.edgesuite.net", CertProvisioningType: "CPS_MANAGED"},
+		},
+		2: {
+			{CnameFrom: "www.example.com", CnameTo: "www.example.com.edgekey.net", CertProvisioningType: "DEFAULT"},
+			{CnameFrom: "new.example.com", CnameTo: "new.example.com.edgekey.net", CertProvisioningType: "DEFAULT"},
+		},
+	}
+
+	mockVersions := func(client *papi.Mock) {
+		client.On("GetPropertyVersions", AnyCTX, papi.GetPropertyVersionsRequest{
+			PropertyID: "prp_test",
+			ContractID: "ctr_test",
+			GroupID:    "grp_test",
+		}).Return(&papi.GetPropertyVersionsResponse{
+			PropertyID: "prp_test",
+			ContractID: "ctr_test",
+			GroupID:    "grp_test",
+			Versions: papi.PropertyVersionItems{Items: []papi.PropertyVersionGetItem{
+				{PropertyVersion: 1, StagingStatus: papi.VersionStatusInactive, ProductionStatus: papi.VersionStatusActive},
+				{PropertyVersion: 2, StagingStatus: papi.VersionStatusActive, ProductionStatus: papi.VersionStatusInactive},
+			}},
+		}, nil)
+	}
+	mockVersionContent := func(client *papi.Mock, version int) {
+		client.On("GetRuleTree", AnyCTX, papi.GetRuleTreeRequest{
+			PropertyID:      "prp_test",
+			ContractID:      "ctr_test",
+			GroupID:         "grp_test",
+			PropertyVersion: version,
+			ValidateRules:   true,
+			ValidateMode:    papi.RuleValidateModeFull,
+		}).Return(&papi.GetRuleTreeResponse{Rules: rules[version]}, nil)
+		client.On("GetPropertyVersionHostnames", AnyCTX, papi.GetPropertyVersionHostnamesRequest{
+			PropertyID:        "prp_test",
+			ContractID:        "ctr_test",
+			GroupID:           "grp_test",
+			PropertyVersion:   version,
+			IncludeCertStatus: true,
+		}).Return(&papi.GetPropertyVersionHostnamesResponse{Hostnames: papi.HostnameResponseItems{Items: hostnames[version]}}, nil)
+	}
+
+	t.Run("production version compared with the latest one", func(t *testing.T) {
+		client := &papi.Mock{}
+		mockVersions(client)
+		client.On("GetProperty", AnyCTX, papi.GetPropertyRequest{
+			PropertyID: "prp_test",
+			ContractID: "ctr_test",
+			GroupID:    "grp_test",
+		}).Return(&papi.GetPropertyResponse{Property: &papi.Property{
+			PropertyID:    "prp_test",
+			ContractID:    "ctr_test",
+			GroupID:       "grp_test",
+			LatestVersion: 2,
+		}}, nil)
+		mockVersionContent(client, 1)
+		mockVersionContent(client, 2)
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{{
+					Config: testutils.LoadFixtureString(t, "testdata/TestDataPropertyVersionDiff/production_to_latest.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "id", "prp_test:1:2"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "from_version_number", "1"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "to_version_number", "2"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "has_changes", "true"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "rule_changes.#", "2"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "rule_changes.0.rule", "/default"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "rule_changes.0.change", "modified"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "rule_changes.0.description",
+							`behavior "origin" option "hostname": "origin.example.com" -> "new.example.com"`),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "rule_changes.1.rule", "/default/Images"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "rule_changes.1.change", "added"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "rule_changes.1.description", "rule added"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.#", "3"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.0.cname_from", "new.example.com"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.0.change", "added"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.0.old_cname_to", ""),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.0.new_cname_to", "new.example.com.edgekey.net"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.1.cname_from", "old.example.com"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.1.change", "removed"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.1.old_cname_to", "old.example.com.edgesuite.net"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.2.cname_from", "www.example.com"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.2.change", "modified"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.2.old_cname_to", "www.example.com.edgesuite.net"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.2.new_cname_to", "www.example.com.edgekey.net"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.2.old_cert_provisioning_type", "CPS_MANAGED"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.2.new_cert_provisioning_type", "DEFAULT"),
+					),
+				}},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("version compared with itself", func(t *testing.T) {
+		client := &papi.Mock{}
+		mockVersions(client)
+		mockVersionContent(client, 2)
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{{
+					Config: testutils.LoadFixtureString(t, "testdata/TestDataPropertyVersionDiff/same_version.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "id", "prp_test:2:2"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "has_changes", "false"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "rule_changes.#", "0"),
+						resource.TestCheckResourceAttr("data.akamai_property_version_diff.diff", "hostname_changes.#", "0"),
+					),
+				}},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		client := &papi.Mock{}
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestDataPropertyVersionDiff/invalid_version.tf"),
+					ExpectError: regexp.MustCompile(`must be a version number`),
+				}},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
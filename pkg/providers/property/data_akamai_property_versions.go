@@ -0,0 +1,191 @@
+package property
+
+import (
+	"context"
+	"sort"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePropertyVersions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataPropertyVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+			},
+			"contract_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+			},
+			"property_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        addPrefixToState("prp_"),
+				ValidateDiagFunc: tf.IsNotBlank,
+			},
+			"latest_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Property's current latest version",
+			},
+			"staging_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Property's version currently activated in staging (zero when not active in staging)",
+			},
+			"production_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Property's version currently activated in production (zero when not active in production)",
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of property versions, from the latest one",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version number",
+						},
+						"note": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The note assigned to the version",
+						},
+						"updated_by_user": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The user who last modified the version",
+						},
+						"updated_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date of the last modification of the version",
+						},
+						"staging_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The activation status of the version on staging network, either 'ACTIVE', 'INACTIVE', 'PENDING' or 'DEACTIVATED'",
+						},
+						"production_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The activation status of the version on production network, either 'ACTIVE', 'INACTIVE', 'PENDING' or 'DEACTIVATED'",
+						},
+						"rule_format": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The rule format of the version",
+						},
+						"product_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The product assigned to the version",
+						},
+						"etag": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The digest of the version",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataPropertyVersionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := meta.Must(m)
+	client := Client(meta)
+	log := meta.Log("PAPI", "dataPropertyVersionsRead")
+	// create a context with logging for api calls
+	ctx = session.ContextWithOptions(
+		ctx,
+		session.WithContextLog(log),
+	)
+	log.Debug("Listing Property Versions")
+
+	// groupID / contractID is string as per schema.
+	groupID, err := tf.GetStringValue("group_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	groupID = str.AddPrefix(groupID, "grp_")
+	contractID, err := tf.GetStringValue("contract_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	contractID = str.AddPrefix(contractID, "ctr_")
+
+	propertyID, err := tf.GetStringValue("property_id", d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	propertyID = str.AddPrefix(propertyID, "prp_")
+
+	log.Debug("fetching property versions")
+	versionsResponse, err := client.GetPropertyVersions(ctx, papi.GetPropertyVersionsRequest{
+		PropertyID: propertyID,
+		ContractID: contractID,
+		GroupID:    groupID,
+	})
+	if err != nil {
+		log.WithError(err).Error("could not fetch property versions")
+		return diag.FromErr(err)
+	}
+
+	versions := versionsResponse.Versions.Items
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].PropertyVersion > versions[j].PropertyVersion
+	})
+
+	var latestVersion, stagingVersion, productionVersion int
+	versionsList := make([]interface{}, 0, len(versions))
+	for _, v := range versions {
+		if v.PropertyVersion > latestVersion {
+			latestVersion = v.PropertyVersion
+		}
+		if v.StagingStatus == papi.VersionStatusActive {
+			stagingVersion = v.PropertyVersion
+		}
+		if v.ProductionStatus == papi.VersionStatusActive {
+			productionVersion = v.PropertyVersion
+		}
+		versionsList = append(versionsList, map[string]interface{}{
+			"version":           v.PropertyVersion,
+			"note":              v.Note,
+			"updated_by_user":   v.UpdatedByUser,
+			"updated_date":      v.UpdatedDate,
+			"staging_status":    string(v.StagingStatus),
+			"production_status": string(v.ProductionStatus),
+			"rule_format":       v.RuleFormat,
+			"product_id":        v.ProductID,
+			"etag":              v.Etag,
+		})
+	}
+
+	attrs := map[string]interface{}{
+		"latest_version":     latestVersion,
+		"staging_version":    stagingVersion,
+		"production_version": productionVersion,
+		"versions":           versionsList,
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(propertyID)
+	return nil
+}
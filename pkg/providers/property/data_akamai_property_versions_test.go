@@ -0,0 +1,114 @@
+package property
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataPropertyVersions(t *testing.T) {
+	request := papi.GetPropertyVersionsRequest{
+		PropertyID: "prp_test",
+		ContractID: "ctr_test",
+		GroupID:    "grp_test",
+	}
+
+	t.Run("list versions", func(t *testing.T) {
+		client := &papi.Mock{}
+		client.On("GetPropertyVersions", mock.Anything, request).Return(&papi.GetPropertyVersionsResponse{
+			PropertyID: "prp_test",
+			ContractID: "ctr_test",
+			GroupID:    "grp_test",
+			Versions: papi.PropertyVersionItems{Items: []papi.PropertyVersionGetItem{
+				{
+					PropertyVersion:  1,
+					Note:             "initial version",
+					UpdatedByUser:    "jsmith",
+					UpdatedDate:      "2024-01-10T08:00:00Z",
+					StagingStatus:    papi.VersionStatusDeactivated,
+					ProductionStatus: papi.VersionStatusActive,
+					RuleFormat:       "v2023-01-05",
+					ProductID:        "prd_Fresca",
+					Etag:             "etag1",
+				},
+				{
+					PropertyVersion:  3,
+					Note:             "new origin",
+					UpdatedByUser:    "jdoe",
+					UpdatedDate:      "2024-02-12T10:00:00Z",
+					StagingStatus:    papi.VersionStatusInactive,
+					ProductionStatus: papi.VersionStatusInactive,
+					RuleFormat:       "v2024-02-12",
+					ProductID:        "prd_Fresca",
+					Etag:             "etag3",
+				},
+				{
+					PropertyVersion:  2,
+					Note:             "caching",
+					UpdatedByUser:    "jsmith",
+					UpdatedDate:      "2024-01-20T09:00:00Z",
+					StagingStatus:    papi.VersionStatusActive,
+					ProductionStatus: papi.VersionStatusInactive,
+					RuleFormat:       "v2023-01-05",
+					ProductID:        "prd_Fresca",
+					Etag:             "etag2",
+				},
+			}},
+		}, nil)
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{{
+					Config: testutils.LoadFixtureString(t, "testdata/TestDataPropertyVersions/property_versions.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "id", "prp_test"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "property_id", "prp_test"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "latest_version", "3"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "staging_version", "2"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "production_version", "1"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.#", "3"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.0.version", "3"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.0.note", "new origin"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.0.updated_by_user", "jdoe"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.0.updated_date", "2024-02-12T10:00:00Z"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.0.staging_status", "INACTIVE"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.0.production_status", "INACTIVE"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.0.rule_format", "v2024-02-12"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.0.product_id", "prd_Fresca"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.0.etag", "etag3"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.1.version", "2"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.1.staging_status", "ACTIVE"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.2.version", "1"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.2.staging_status", "DEACTIVATED"),
+						resource.TestCheckResourceAttr("data.akamai_property_versions.versions", "versions.2.production_status", "ACTIVE"),
+					),
+				}},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("error fetching versions", func(t *testing.T) {
+		client := &papi.Mock{}
+		client.On("GetPropertyVersions", mock.Anything, request).Return(nil, errors.New("oops"))
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{{
+					Config:      testutils.LoadFixtureString(t, "testdata/TestDataPropertyVersions/property_versions.tf"),
+					ExpectError: regexp.MustCompile("oops"),
+				}},
+			})
+		})
+
+		client.AssertExpectations(t)
+	})
+}
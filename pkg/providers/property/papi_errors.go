@@ -101,6 +101,27 @@ var (
 	// ErrPropertyInclude is returned when operation on property include fails
 	ErrPropertyInclude = errors.New("property include")
 
+	// Property hostname errors
+
+	// ErrPropertyHostname is returned when operation on property hostname fails
+	ErrPropertyHostname = errors.New("property hostname")
+
+	// ErrHostnameAlreadyExists is returned when the hostname to be added is already present in the property version
+	ErrHostnameAlreadyExists = errors.New("hostname already exists in the property version")
+
+	// ErrHostnamesManagedByProperty is returned when hostnames of the property are managed by both akamai_property
+	// and akamai_property_hostname resources
+	ErrHostnamesManagedByProperty = errors.New("hostnames of the property are managed by both akamai_property and akamai_property_hostname resources")
+
+	// Property activations errors
+
+	// ErrPropertyActivations is returned when operation on property activations fails
+	ErrPropertyActivations = errors.New("property activations")
+	// ErrIncludeParentsActivation is returned when activation of include parents fails
+	ErrIncludeParentsActivation = errors.New("include parents activation")
+	// ErrPropertyAdoption is returned when existing property cannot be adopted by akamai_property_bootstrap
+	ErrPropertyAdoption = errors.New("cannot adopt existing property")
+
 	// DiagWarnActivationTimeout returned on activation poll timeout
 	DiagWarnActivationTimeout = diag.Diagnostic{
 		Severity: diag.Warning,
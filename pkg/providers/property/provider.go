@@ -58,6 +58,8 @@ func (p *Subprovider) SDKResources() map[string]*schema.Resource {
 		"akamai_edge_hostname":               resourceSecureEdgeHostName(),
 		"akamai_property":                    resourceProperty(),
 		"akamai_property_activation":         resourcePropertyActivation(),
+		"akamai_property_activations":        resourcePropertyActivations(),
+		"akamai_property_hostname":           resourcePropertyHostname(),
 		"akamai_property_include":            resourcePropertyInclude(),
 		"akamai_property_include_activation": resourcePropertyIncludeActivation(),
 	}
@@ -84,7 +86,12 @@ func (p *Subprovider) SDKDataSources() map[string]*schema.Resource {
 		"akamai_property_rule_formats":       dataSourcePropertyRuleFormats(),
 		"akamai_property_rules":              dataSourcePropertyRules(),
 		"akamai_property_rules_builder":      dataSourcePropertyRulesBuilder(),
+		"akamai_property_rules_hcl":          dataSourcePropertyRulesHCL(),
+		"akamai_property_rules_migration":    dataSourcePropertyRulesMigration(),
 		"akamai_property_rules_template":     dataSourcePropertyRulesTemplate(),
+		"akamai_property_rules_validation":   dataSourcePropertyRulesValidation(),
+		"akamai_property_version_diff":       dataSourcePropertyVersionDiff(),
+		"akamai_property_versions":           dataSourcePropertyVersions(),
 	}
 }
 
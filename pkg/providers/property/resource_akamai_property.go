@@ -55,8 +55,11 @@ func resourceProperty() *schema.Resource {
 		UpdateContext: resourcePropertyUpdate,
 		DeleteContext: resourcePropertyDelete,
 		CustomizeDiff: customdiff.Sequence(
+			propertyHostnamesManagersCustomDiff,
 			hostNamesCustomDiff,
+			validateRulesCustomDiff,
 			propertyRulesCustomDiff,
+			propertyRulesDiffCustomDiff,
 			setPropertyVersionsComputed,
 		),
 		Importer: &schema.ResourceImporter{
@@ -106,6 +109,11 @@ func resourceProperty() *schema.Resource {
 				Description:      "Specify the rule format version (defaults to latest version available when created)",
 				ValidateDiagFunc: tf.ValidateRuleFormatAcceptLatest,
 			},
+			"skip_rules_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Disables the plan time validation of rules against the schema of the rule format bundled with the provider, e.g. when the bundled schema does not match the one of the API",
+			},
 			"rules": {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -115,6 +123,11 @@ func resourceProperty() *schema.Resource {
 				DiffSuppressFunc: diffSuppressPropertyRules,
 				StateFunc:        rulesStateFunc,
 			},
+			"rules_diff": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Structural changes of the rule tree planned by the update of rules: rules added, removed or moved by path and changes of behaviors, criteria and their options. It is kept in the state until the next refresh, which empties it",
+			},
 			"version_notes": {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -318,6 +331,48 @@ func propertyRulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ int
 	return nil
 }
 
+// propertyRulesDiffCustomDiff sets rules_diff to the structural changes of the rule tree planned by the update,
+// so that they can be reviewed in the plan instead of the change of the whole rules JSON.
+// Without changes of the rule tree, the value from the state is kept, which is emptied by the refresh:
+// setting it to empty string in the plan would make it unknown.
+func propertyRulesDiffCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("rules") {
+		return nil
+	}
+	if !diff.NewValueKnown("rules") {
+		if err := diff.SetNewComputed("rules_diff"); err != nil {
+			return fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+		}
+		return nil
+	}
+
+	o, n := diff.GetChange("rules")
+	oldValue, newValue := o.(string), n.(string)
+	if oldValue == "" || newValue == "" {
+		return nil
+	}
+
+	var oldRulesUpdate, newRulesUpdate papi.RulesUpdate
+	if err := json.Unmarshal([]byte(oldValue), &oldRulesUpdate); err != nil {
+		return fmt.Errorf("cannot parse rules JSON from state: %s", err)
+	}
+	if err := json.Unmarshal([]byte(newValue), &newRulesUpdate); err != nil {
+		return fmt.Errorf("cannot parse rules JSON from config: %s", err)
+	}
+
+	normalizeFields(&oldRulesUpdate, &newRulesUpdate)
+	removeNilRuleTreeOptions(&oldRulesUpdate.Rules)
+	removeNilRuleTreeOptions(&newRulesUpdate.Rules)
+	changes := diffRules(oldRulesUpdate.Rules, newRulesUpdate.Rules)
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := diff.SetNew("rules_diff", strings.Join(changes, "\n")); err != nil {
+		return fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error())
+	}
+	return nil
+}
+
 // unifyRulesDiff is invoked on first planning for property creation
 // Its main purpose is to unify the rules JSON with what we expect will be created by PAPI
 // It is used in order to prevent diffs on output on subsequent terraform applies
@@ -339,6 +394,15 @@ func normalizeFields(oldRules, newRules *papi.RulesUpdate) {
 	}
 }
 
+// propertyHostnamesManagersCustomDiff rejects akamai_property resources of properties which hostnames are managed
+// by akamai_property_hostname resources
+func propertyHostnamesManagersCustomDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	return checkHostnamesManagers(m, d.Id(), true)
+}
+
 func hostNamesCustomDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
 	meta := meta.Must(m)
 	logger := meta.Log("PAPI", "hostNamesCustomDiff")
@@ -557,6 +621,10 @@ func resourcePropertyRead(ctx context.Context, d *schema.ResourceData, m interfa
 	groupID := str.AddPrefix(d.Get("group_id").(string), "grp_")
 	readVersionID := d.Get("read_version").(int)
 
+	if err := checkHostnamesManagers(m, propertyID, true); err != nil {
+		logger.Warn(err.Error())
+	}
+
 	var property *papi.Property
 	var err error
 	var v int
@@ -643,6 +711,8 @@ func resourcePropertyRead(ctx context.Context, d *schema.ResourceData, m interfa
 		"rule_errors":        papiErrorsToList(ruleErrors),
 		"read_version":       readVersionID,
 		"version_notes":      res.Version.Note,
+		// rules_diff describes only the changes planned for the update, which are applied at this point
+		"rules_diff": "",
 	}
 	if property.ProductID != "" {
 		attrs["product_id"] = property.ProductID
@@ -661,6 +731,9 @@ func resourcePropertyUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 	diags := diag.Diagnostics{}
 
+	// the planned rules_diff is kept in the state returned by the update, so that the result matches the plan
+	rulesDiff := d.Get("rules_diff").(string)
+
 	immutable := []string{
 		"group_id",
 		"contract_id",
@@ -679,6 +752,12 @@ func resourcePropertyUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		return diags
 	}
 
+	if d.HasChange("hostnames") {
+		if err := checkHostnamesManagers(m, d.Id(), true); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	// We only update if these attributes change.
 	if !d.HasChanges("hostnames", "rules", "rule_format") {
 		logger.Debug("No changes to hostnames, rules, or rule_format (no update required)")
@@ -754,7 +833,7 @@ func resourcePropertyUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	if !shouldUpdateRuleTree(d) {
-		return resourcePropertyRead(ctx, d, m)
+		return readUpdatedProperty(ctx, d, m, rulesDiff)
 	}
 
 	ruleFormat, err := tf.GetStringValue("rule_format", d)
@@ -783,7 +862,20 @@ func resourcePropertyUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 
-	return resourcePropertyRead(ctx, d, m)
+	return readUpdatedProperty(ctx, d, m, rulesDiff)
+}
+
+// readUpdatedProperty reads the property after the update, restoring rules_diff planned for the update,
+// which is cleared by the next refresh only
+func readUpdatedProperty(ctx context.Context, d *schema.ResourceData, m interface{}, rulesDiff string) diag.Diagnostics {
+	diags := resourcePropertyRead(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+	if err := d.Set("rules_diff", rulesDiff); err != nil {
+		return append(diags, diag.FromErr(fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error()))...)
+	}
+	return diags
 }
 
 func resourcePropertyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -16,6 +16,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
 	"github.com/apex/log"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -25,10 +26,12 @@ import (
 
 func resourcePropertyActivation() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourcePropertyActivationCreate,
-		ReadContext:   resourcePropertyActivationRead,
-		UpdateContext: resourcePropertyActivationUpdate,
-		DeleteContext: resourcePropertyActivationDelete,
+		// the operation timeout is applied by create and update, so that the rollback
+		// can have its own budget after the activation timed out, see rollbackActivation
+		CreateWithoutTimeout: resourcePropertyActivationCreate,
+		ReadContext:          resourcePropertyActivationRead,
+		UpdateWithoutTimeout: resourcePropertyActivationUpdate,
+		DeleteContext:        resourcePropertyActivationDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcePropertyActivationImport,
 		},
@@ -57,6 +60,9 @@ var (
 	// PropertyResourceTimeout is the default timeout for the resource operations
 	PropertyResourceTimeout = time.Minute * 90
 
+	// RollbackTimeout is the default timeout for the rollback of a failed activation
+	RollbackTimeout = time.Minute * 30
+
 	// CreateActivationRetry poll wait time code waits between retries for activation creation
 	CreateActivationRetry = 10 * time.Second
 )
@@ -124,6 +130,43 @@ var akamaiPropertyActivationSchema = map[string]*schema.Schema{
 		Description: "Provides an audit record when activating on a production network",
 		Elem:        complianceRecordSchema,
 	},
+	"rollback_on_failure": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Reactivates the previously active version when the activation fails or times out. Default is false",
+	},
+	"rollback_timeout": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "The time allowed for the rollback, in addition to the resource operation timeout. Default is 30m",
+		ValidateDiagFunc: timeouts.ValidateDurationFormat,
+	},
+	"rollback": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The activation of the previously active version, which rolled back the failed activation. The version rolled back to after a failed creation is not deactivated when the resource is destroyed or replaced",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"version": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"activation_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"status": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"on_create": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether the rollback happened when the resource was created",
+				},
+			},
+		},
+	},
 	"timeouts": {
 		Type:        schema.TypeList,
 		Optional:    true,
@@ -166,6 +209,11 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 		session.WithContextLog(logger),
 	)
 
+	// the rollback is not limited by the operation timeout, see rollbackActivation
+	rollbackCtx := ctx
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
 	if dead, ok := ctx.Deadline(); ok {
 		logger.Debugf("activation create with deadline in %s", time.Until(dead).String())
 	}
@@ -213,6 +261,11 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
+	previous, err := findRollbackVersion(ctx, client, d, propertyID, version, network)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	activation, err := lookupActivation(ctx, client, lookupActivationRequest{
 		propertyID: propertyID,
 		version:    version,
@@ -276,15 +329,26 @@ func resourcePropertyActivationCreate(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
-	activation, diagErr := pollActivation(ctx, client, activation, propertyID)
+	polled, diagErr := pollActivation(ctx, client, activation, propertyID)
 	if diagErr != nil {
-		return diagErr
+		if previous == nil || !isActivationFailure(polled.Status, diagErr) {
+			return diagErr
+		}
+		diags := rollbackActivation(rollbackCtx, client, d, propertyID, polled, previous, diagErr)
+		if len(d.Get("rollback").([]interface{})) > 0 {
+			// the failed activation and the rollback are recorded in the state of the tainted resource,
+			// which is replaced on the next apply without deactivating the version rolled back to
+			d.SetId(propertyID + ":" + string(network))
+		}
+		return diags
 	}
+	activation = polled
 
 	attrs := map[string]interface{}{
 		"status":        string(activation.Status),
 		"activation_id": activation.ActivationID,
 		"version":       version,
+		"rollback":      []interface{}{},
 	}
 	if err := tf.SetAttrs(d, attrs); err != nil {
 		return diag.FromErr(err)
@@ -302,6 +366,17 @@ func resourcePropertyActivationDelete(ctx context.Context, d *schema.ResourceDat
 
 	logger.Debug("resourcePropertyActivationDelete call")
 
+	if rollback := d.Get("rollback").([]interface{}); len(rollback) > 0 && rollback[0].(map[string]interface{})["on_create"].(bool) {
+		// the creation failed and the version active before was reactivated, the resource never managed it,
+		// so it is not taken off the network when the tainted resource is replaced or destroyed
+		version := rollback[0].(map[string]interface{})["version"]
+		logger.Warnf("version %v was reactivated after the failed activation, it is not deactivated", version)
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("version %v reactivated after the failed activation was not deactivated", version),
+		}}
+	}
+
 	// create a context with logging for api calls
 	ctx = session.ContextWithOptions(
 		ctx,
@@ -400,6 +475,9 @@ func resourcePropertyActivationDelete(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
+	ctx, span := tracing.StartSpan(ctx, "property.pollDeactivation")
+	defer span.End()
+
 	// deactivations also use status Active for when they are fully processed
 	for activation.Status != papi.ActivationStatusActive {
 		if activation.Status == papi.ActivationStatusAborted {
@@ -563,11 +641,16 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 		session.WithContextLog(logger),
 	)
 
-	if !d.HasChangeExcept("timeouts") {
-		logger.Debug("Only timeouts were updated, skipping")
+	if !d.HasChangesExcept("timeouts", "rollback_on_failure", "rollback_timeout") {
+		logger.Debug("Only timeouts or rollback settings were updated, skipping")
 		return nil
 	}
 
+	// the rollback is not limited by the operation timeout, see rollbackActivation
+	rollbackCtx := ctx
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
 	propertyID, err := resolvePropertyID(d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -635,6 +718,11 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
+	previous, err := findRollbackVersion(ctx, client, d, propertyID, version, network)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	if propertyActivation == nil || versionStatus == papi.VersionStatusDeactivated {
 		notifySet, err := tf.GetSetValue("contact", d)
 		if err != nil {
@@ -687,15 +775,20 @@ func resourcePropertyActivationUpdate(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
-	propertyActivation, diagErr := pollActivation(ctx, client, propertyActivation, propertyID)
+	polled, diagErr := pollActivation(ctx, client, propertyActivation, propertyID)
 	if diagErr != nil {
-		return diagErr
+		if previous == nil || !isActivationFailure(polled.Status, diagErr) {
+			return diagErr
+		}
+		return rollbackActivation(rollbackCtx, client, d, propertyID, polled, previous, diagErr)
 	}
+	propertyActivation = polled
 
 	attrs := map[string]interface{}{
 		"status":        string(propertyActivation.Status),
 		"activation_id": propertyActivation.ActivationID,
 		"version":       version,
+		"rollback":      []interface{}{},
 	}
 	if err := tf.SetAttrs(d, attrs); err != nil {
 		return diag.FromErr(err)
@@ -914,17 +1007,21 @@ func networkAlias(d *schema.ResourceData) (papi.ActivationNetwork, error) {
 	return papi.ActivationNetwork(alias), nil
 }
 
+// pollActivation polls the activation until it is active. On errors, it returns the last polled activation
+// together with the diagnostics.
 func pollActivation(ctx context.Context, client papi.PAPI, activation *papi.Activation, propertyID string) (*papi.Activation, diag.Diagnostics) {
+	ctx, span := tracing.StartSpan(ctx, "property.pollActivation")
+	defer span.End()
 
 	retriesMax := 5
 	retries5xx := 0
 
 	for activation.Status != papi.ActivationStatusActive {
 		if activation.Status == papi.ActivationStatusAborted {
-			return nil, diag.FromErr(fmt.Errorf("activation request aborted"))
+			return activation, diag.FromErr(fmt.Errorf("activation request aborted"))
 		}
 		if activation.Status == papi.ActivationStatusFailed {
-			return nil, diag.FromErr(fmt.Errorf("activation request failed in downstream system"))
+			return activation, diag.FromErr(fmt.Errorf("activation request failed in downstream system"))
 		}
 		select {
 		case <-time.After(tf.MaxDuration(ActivationPollInterval, ActivationPollMinimum)):
@@ -935,33 +1032,174 @@ func pollActivation(ctx context.Context, client papi.PAPI, activation *papi.Acti
 			if err != nil {
 				var target = &papi.Error{}
 				if !errors.As(err, &target) {
-					return nil, diag.Errorf("error has unexpected type: %T", err)
+					return activation, diag.Errorf("error has unexpected type: %T", err)
 				}
 				if target.StatusCode >= 500 {
 					retries5xx = retries5xx + 1
 					if retries5xx > retriesMax {
-						return nil, diag.Errorf("reached max number of 5xx retries: %d", retries5xx)
+						return activation, diag.Errorf("reached max number of 5xx retries: %d", retries5xx)
 					}
 					continue
 				}
 
-				return nil, diag.FromErr(err)
+				return activation, diag.FromErr(err)
 			}
 			retries5xx = 0
 			activation = act.Activation
 
 		case <-ctx.Done():
 			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-				return nil, diag.Diagnostics{DiagWarnActivationTimeout}
+				return activation, diag.Diagnostics{DiagWarnActivationTimeout}
 			} else if errors.Is(ctx.Err(), context.Canceled) {
-				return nil, diag.Diagnostics{DiagWarnActivationCanceled}
+				return activation, diag.Diagnostics{DiagWarnActivationCanceled}
 			}
-			return nil, diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
+			return activation, diag.FromErr(fmt.Errorf("activation context terminated: %w", ctx.Err()))
 		}
 	}
 	return activation, nil
 }
 
+// findRollbackVersion returns the activation of the version active on the network, which is reactivated
+// when the activation of the given version fails. It returns nil if rollback_on_failure is not enabled,
+// or there is no other version active.
+func findRollbackVersion(ctx context.Context, client papi.PAPI, d *schema.ResourceData, propertyID string, version int, network papi.ActivationNetwork) (*papi.Activation, error) {
+	if !d.Get("rollback_on_failure").(bool) {
+		return nil, nil
+	}
+	activations, err := client.GetActivations(ctx, papi.GetActivationsRequest{
+		PropertyID: propertyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	active, err := findLatestActive(activations.Activations.Items, network)
+	if errors.Is(err, errNoActiveVersionFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if active.PropertyVersion == version {
+		return nil, nil
+	}
+	return active, nil
+}
+
+// isActivationFailure states whether the activation, which polling ended with the given diagnostics
+// and the given last polled status, failed, was aborted or timed out. Errors of the polling itself,
+// e.g. of the API requests, and the cancellation by the user do not mean that the activation failed.
+func isActivationFailure(status papi.ActivationStatus, diags diag.Diagnostics) bool {
+	if status == papi.ActivationStatusFailed || status == papi.ActivationStatusAborted {
+		return true
+	}
+	for _, d := range diags {
+		if d.Summary == DiagWarnActivationTimeout.Summary {
+			return true
+		}
+	}
+	return false
+}
+
+// rollbackActivation reactivates the previously active version after the activation failed or timed out,
+// and records both the failed activation and the rollback in the state. The given context is not limited
+// by the operation timeout, which could have been reached already, so the rollback has its own, 'rollback_timeout'.
+// It is still canceled together with the operation.
+func rollbackActivation(ctx context.Context, client papi.PAPI, d *schema.ResourceData, propertyID string, failed, previous *papi.Activation, cause diag.Diagnostics) diag.Diagnostics {
+	logger := hclog.FromContext(ctx)
+	logger.Info(fmt.Sprintf("activation of version %d failed, rolling back to version %d", failed.PropertyVersion, previous.PropertyVersion))
+
+	timeout := RollbackTimeout
+	if v := d.Get("rollback_timeout").(string); v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return append(cause, diag.FromErr(err)...)
+		}
+		timeout = parsed
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if act, err := client.GetActivation(ctx, papi.GetActivationRequest{
+		ActivationID: failed.ActivationID,
+		PropertyID:   propertyID,
+	}); err == nil {
+		failed = act.Activation
+	}
+	if failed.Status == papi.ActivationStatusPending || failed.Status == papi.ActivationStatusNew {
+		// the timed out activation has to be canceled, otherwise it would override the rollback
+		resp, err := client.CancelActivation(ctx, papi.CancelActivationRequest{
+			PropertyID:   propertyID,
+			ActivationID: failed.ActivationID,
+		})
+		if err != nil {
+			logger.Warn(fmt.Sprintf("canceling activation %s: %s", failed.ActivationID, err))
+		} else if len(resp.Activations.Items) > 0 {
+			failed = resp.Activations.Items[0]
+		}
+	}
+
+	attrs := map[string]interface{}{
+		"status":        string(failed.Status),
+		"activation_id": failed.ActivationID,
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return append(cause, diag.FromErr(err)...)
+	}
+
+	var notify []string
+	for _, contact := range d.Get("contact").(*schema.Set).List() {
+		notify = append(notify, cast.ToString(contact))
+	}
+	complianceRecord, err := tf.GetListValue("compliance_record", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return append(cause, diag.FromErr(err)...)
+	}
+	rollbackRequest := papi.CreateActivationRequest{
+		PropertyID: propertyID,
+		Activation: papi.Activation{
+			ActivationType:         papi.ActivationTypeActivate,
+			Network:                previous.Network,
+			PropertyVersion:        previous.PropertyVersion,
+			NotifyEmails:           notify,
+			AcknowledgeAllWarnings: d.Get("auto_acknowledge_rule_warnings").(bool),
+			Note:                   fmt.Sprintf("rollback of failed activation of version %d", failed.PropertyVersion),
+		},
+	}
+	activationID, diags := createActivation(ctx, client, addPropertyComplianceRecord(complianceRecord, rollbackRequest))
+	if diags.HasError() {
+		return append(cause, diags...)
+	}
+	act, err := client.GetActivation(ctx, papi.GetActivationRequest{
+		ActivationID: activationID,
+		PropertyID:   propertyID,
+	})
+	if err != nil {
+		return append(cause, diag.FromErr(err)...)
+	}
+	rollback, diags := pollActivation(ctx, client, act.Activation, propertyID)
+	if diags != nil {
+		return append(cause, diag.Errorf("rollback to version %d: %s", previous.PropertyVersion, diagsToError(diags))...)
+	}
+
+	// the version in the state is the one active on the network
+	attrs = map[string]interface{}{
+		"version": rollback.PropertyVersion,
+		"rollback": []interface{}{map[string]interface{}{
+			"version":       rollback.PropertyVersion,
+			"activation_id": rollback.ActivationID,
+			"status":        string(rollback.Status),
+			"on_create":     d.IsNewResource(),
+		}},
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return append(cause, diag.FromErr(err)...)
+	}
+	return append(cause, diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("activation of version %d failed and version %d was reactivated", failed.PropertyVersion, previous.PropertyVersion),
+	})
+}
+
 func suppressNoteFieldForPropertyActivation(_, oldValue, newValue string, d *schema.ResourceData) bool {
 	if oldValue != newValue && d.HasChanges("property_id", "version", "network") {
 		return false
@@ -648,6 +648,80 @@ func TestResourcePAPIPropertyActivation(t *testing.T) {
 				},
 			},
 		},
+		"failed update rolled back to previous version": {
+			init: func(m *papi.Mock) {
+				note := "property activation note"
+				emails := []string{"user@example.com"}
+				firstActive := generateActivationResponseMock("atv_activation1", note, 1, papi.ActivationTypeActivate, "2020-10-28T14:04:05Z", emails)
+				// create
+				expectGetRuleTree(m, "prp_test", 1, ruleTreeResponseValid, nil).Once()
+				expectGetActivations(m, "prp_test", papi.GetActivationsResponse{}, nil).Twice()
+				expectCreateActivation(m, "prp_test", papi.ActivationTypeActivate, 1, "STAGING", emails, note, "atv_activation1", true, nil).Once()
+				expectGetActivation(m, "prp_test", "atv_activation1", 1, "STAGING", papi.ActivationStatusActive, papi.ActivationTypeActivate, note, emails, nil).Once()
+				// read, read, update
+				expectGetActivations(m, "prp_test", firstActive, nil).Times(4)
+				expectGetRuleTree(m, "prp_test", 2, ruleTreeResponseValid, nil).Once()
+				ExpectGetPropertyVersion(m, "prp_test", "", "", 2, papi.VersionStatusInactive, "").Once()
+				expectCreateActivation(m, "prp_test", papi.ActivationTypeActivate, 2, "STAGING", emails, note, "atv_update", true, nil).Once()
+				expectGetActivation(m, "prp_test", "atv_update", 2, "STAGING", papi.ActivationStatusFailed, papi.ActivationTypeActivate, note, emails, nil).Twice()
+				// rollback
+				expectCreateActivation(m, "prp_test", papi.ActivationTypeActivate, 1, "STAGING", emails, "rollback of failed activation of version 2", "atv_rollback", true, nil).Once()
+				expectGetActivation(m, "prp_test", "atv_rollback", 1, "STAGING", papi.ActivationStatusActive, papi.ActivationTypeActivate, "rollback of failed activation of version 2", emails, nil).Once()
+				// read, delete deactivates the version rolled back to
+				expectGetActivations(m, "prp_test", generateActivationResponseMock("atv_rollback", "rollback of failed activation of version 2", 1, papi.ActivationTypeActivate, "2020-10-28T16:04:05Z", emails), nil)
+				expectCreateActivation(m, "prp_test", papi.ActivationTypeDeactivate, 1, "STAGING", emails, note, "atv_deactivation", true, nil).Once()
+				expectGetActivation(m, "prp_test", "atv_deactivation", 1, "STAGING", papi.ActivationStatusActive, papi.ActivationTypeDeactivate, note, emails, nil).Once()
+			},
+			steps: []resource.TestStep{
+				{
+					Config: testutils.LoadFixtureString(t, "./testdata/TestPropertyActivation/rollback/resource_property_activation.tf"),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "version", "1"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "rollback.#", "0"),
+					),
+				},
+				{
+					Config:      testutils.LoadFixtureString(t, "./testdata/TestPropertyActivation/rollback/resource_property_activation_update.tf"),
+					ExpectError: regexp.MustCompile("activation of version 2 failed and version 1 was reactivated"),
+				},
+			},
+		},
+		"failed creation rolled back to previous version": {
+			init: func(m *papi.Mock) {
+				note := "property activation note"
+				emails := []string{"user@example.com"}
+				// create
+				expectGetRuleTree(m, "prp_test", 2, ruleTreeResponseValid, nil).Once()
+				expectGetActivations(m, "prp_test", generateActivationResponseMock("atv_activation1", note, 1, papi.ActivationTypeActivate, "2020-10-28T14:04:05Z", emails), nil).Twice()
+				expectCreateActivation(m, "prp_test", papi.ActivationTypeActivate, 2, "STAGING", emails, note, "atv_update", true, nil).Once()
+				expectGetActivation(m, "prp_test", "atv_update", 2, "STAGING", papi.ActivationStatusFailed, papi.ActivationTypeActivate, note, emails, nil).Twice()
+				// rollback
+				expectCreateActivation(m, "prp_test", papi.ActivationTypeActivate, 1, "STAGING", emails, "rollback of failed activation of version 2", "atv_rollback", true, nil).Once()
+				expectGetActivation(m, "prp_test", "atv_rollback", 1, "STAGING", papi.ActivationStatusActive, papi.ActivationTypeActivate, "rollback of failed activation of version 2", emails, nil).Once()
+				// read, delete does not deactivate the version rolled back to
+				expectGetActivations(m, "prp_test", generateActivationResponseMock("atv_rollback", "rollback of failed activation of version 2", 1, papi.ActivationTypeActivate, "2020-10-28T16:04:05Z", emails), nil)
+			},
+			steps: []resource.TestStep{
+				{
+					Config:      testutils.LoadFixtureString(t, "./testdata/TestPropertyActivation/rollback/resource_property_activation_update.tf"),
+					ExpectError: regexp.MustCompile("activation of version 2 failed and version 1 was reactivated"),
+				},
+				{
+					RefreshState: true,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "id", "prp_test:STAGING"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "version", "1"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "rollback.#", "1"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "rollback.0.version", "1"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "rollback.0.activation_id", "atv_rollback"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "rollback.0.status", "ACTIVE"),
+						resource.TestCheckResourceAttr("akamai_property_activation.test", "rollback.0.on_create", "true"),
+					),
+					// the tainted resource is replaced
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
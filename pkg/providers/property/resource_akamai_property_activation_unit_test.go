@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/date"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -851,3 +853,48 @@ func TestCreateActivation(t *testing.T) {
 		assert.Equal(t, "atv_123", actID)
 	})
 }
+
+func TestIsActivationFailure(t *testing.T) {
+	tests := map[string]struct {
+		status   papi.ActivationStatus
+		diags    diag.Diagnostics
+		expected bool
+	}{
+		"activation failed": {
+			status:   papi.ActivationStatusFailed,
+			diags:    diag.Errorf("activation request failed in downstream system"),
+			expected: true,
+		},
+		"activation aborted": {
+			status:   papi.ActivationStatusAborted,
+			diags:    diag.Errorf("activation request aborted"),
+			expected: true,
+		},
+		"activation timed out": {
+			status:   papi.ActivationStatusPending,
+			diags:    diag.Diagnostics{DiagWarnActivationTimeout},
+			expected: true,
+		},
+		"activation canceled": {
+			status:   papi.ActivationStatusPending,
+			diags:    diag.Diagnostics{DiagWarnActivationCanceled},
+			expected: false,
+		},
+		"polling error of pending activation": {
+			status:   papi.ActivationStatusPending,
+			diags:    diag.FromErr(&papi.Error{StatusCode: http.StatusForbidden}),
+			expected: false,
+		},
+		"5xx retries of polling exhausted": {
+			status:   papi.ActivationStatusNew,
+			diags:    diag.Errorf("reached max number of 5xx retries: %d", 6),
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, isActivationFailure(test.status, test.diags))
+		})
+	}
+}
@@ -0,0 +1,766 @@
+package property
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/spf13/cast"
+	"golang.org/x/time/rate"
+)
+
+func resourcePropertyActivations() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyActivationsCreate,
+		ReadContext:   resourcePropertyActivationsRead,
+		UpdateContext: resourcePropertyActivationsUpdate,
+		DeleteContext: resourcePropertyActivationsDelete,
+		Schema: map[string]*schema.Schema{
+			"property": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Properties activated together, each in the given version",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: tf.IsNotBlank,
+							StateFunc:        addPrefixToState("prp_"),
+						},
+						"version": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+						},
+						"activation_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the latest activation of the property",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the latest activation of the property",
+						},
+						"previous_version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version which was active on the network before the activation, 0 if none",
+						},
+					},
+				},
+			},
+			"network": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     papi.ActivationNetworkStaging,
+				Description: "The network on which the properties are activated, either 'STAGING' or 'PRODUCTION'",
+			},
+			"contact": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"note": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Assigns a log message to the activation requests",
+			},
+			"auto_acknowledge_rule_warnings": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Automatically acknowledge all rule warnings for activations to continue. Default is false",
+			},
+			"compliance_record": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Provides an audit record when activating on a production network",
+				Elem:        complianceRecordSchema,
+			},
+			"all_or_nothing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "When any of the activations fails or times out, the successful ones are rolled back by reactivating " +
+					"the previously active versions, or deactivating the properties which were not active before. " +
+					"The pending activations are canceled. Default is false",
+			},
+			"rollback_timeout": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The time allowed for the all or nothing rollback, in addition to the resource operation timeout. Default is 30m",
+				ValidateDiagFunc: timeouts.ValidateDurationFormat,
+			},
+			"create_failed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: "Whether any activation failed when the resource was created. The activations of such a resource " +
+					"are not deactivated when the tainted resource is destroyed or replaced, so that the replacement retries the failed activations only",
+			},
+			"requests_per_second": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          5,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The rate limit of the API requests shared by all the activations of the resource. Default is 5",
+			},
+			"timeouts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Enables to set timeout for processing",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"default": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: timeouts.ValidateDurationFormat,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: &PropertyResourceTimeout,
+		},
+	}
+}
+
+type (
+	// batchActivation is the activation or deactivation of one of the properties of akamai_property_activations
+	batchActivation struct {
+		propertyID      string
+		version         int
+		previousVersion int
+		activation      *papi.Activation
+		err             error
+	}
+
+	// batchActivationRequest contains the settings shared by all the activations of akamai_property_activations
+	batchActivationRequest struct {
+		activationType          papi.ActivationType
+		network                 papi.ActivationNetwork
+		notify                  []string
+		note                    string
+		acknowledgeRuleWarnings bool
+		complianceRecord        []interface{}
+		rollbackTimeout         time.Duration
+	}
+
+	// rateLimitedClient shares the rate limit among the API requests of all the activations of akamai_property_activations
+	rateLimitedClient struct {
+		papi.PAPI
+		limiter *rate.Limiter
+	}
+)
+
+func (c rateLimitedClient) GetActivations(ctx context.Context, params papi.GetActivationsRequest) (*papi.GetActivationsResponse, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.PAPI.GetActivations(ctx, params)
+}
+
+func (c rateLimitedClient) GetActivation(ctx context.Context, params papi.GetActivationRequest) (*papi.GetActivationResponse, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.PAPI.GetActivation(ctx, params)
+}
+
+func (c rateLimitedClient) CreateActivation(ctx context.Context, params papi.CreateActivationRequest) (*papi.CreateActivationResponse, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.PAPI.CreateActivation(ctx, params)
+}
+
+func (c rateLimitedClient) CancelActivation(ctx context.Context, params papi.CancelActivationRequest) (*papi.CancelActivationResponse, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.PAPI.CancelActivation(ctx, params)
+}
+
+func (c rateLimitedClient) GetRuleTree(ctx context.Context, params papi.GetRuleTreeRequest) (*papi.GetRuleTreeResponse, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.PAPI.GetRuleTree(ctx, params)
+}
+
+func batchActivationsClient(d *schema.ResourceData, m interface{}) papi.PAPI {
+	requestsPerSecond := d.Get("requests_per_second").(int)
+	return rateLimitedClient{
+		PAPI:    Client(meta.Must(m)),
+		limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), 1),
+	}
+}
+
+func resourcePropertyActivationsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyActivationsCreate"))
+	client := batchActivationsClient(d, m)
+
+	activations, err := expandBatchActivations(d.Get("property").([]interface{}))
+	if err != nil {
+		return diag.Errorf("%s create: %s", ErrPropertyActivations, err)
+	}
+	request, err := newBatchActivationRequest(d, papi.ActivationTypeActivate)
+	if err != nil {
+		return diag.Errorf("%s create: %s", ErrPropertyActivations, err)
+	}
+
+	allOrNothing := d.Get("all_or_nothing").(bool)
+	diags := activateBatch(ctx, client, request, allOrNothing, activations)
+	if diags.HasError() && (allOrNothing || !anyBatchActivationSucceeded(activations)) {
+		// nothing is stored in the state, so that the next apply retries all the activations
+		return diags
+	}
+	if diags.HasError() {
+		// the successful activations are stored in the state of the tainted resource, while the failed ones
+		// are stored with version 0. The replacement retries the failed activations only, as the successful ones
+		// are not deactivated when the resource is replaced, see resourcePropertyActivationsDelete.
+		// d.Partial is not used, as it would keep the empty prior state on creation
+		for _, a := range activations {
+			if a.err != nil {
+				a.version = 0
+			}
+		}
+	}
+
+	attrs := map[string]interface{}{
+		"property":      flattenBatchActivations(activations),
+		"create_failed": diags.HasError(),
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	d.SetId(batchActivationsID(request.network, activations))
+	return diags
+}
+
+// anyBatchActivationSucceeded checks whether at least one activation of the batch succeeded
+func anyBatchActivationSucceeded(activations []*batchActivation) bool {
+	for _, a := range activations {
+		if a.err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func resourcePropertyActivationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyActivationsRead"))
+	client := batchActivationsClient(d, m)
+
+	network, err := networkAlias(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	activations, err := expandBatchActivations(d.Get("property").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	runConcurrently(activations, func(a *batchActivation) {
+		resp, err := client.GetActivations(ctx, papi.GetActivationsRequest{PropertyID: a.propertyID})
+		if err != nil {
+			a.err = err
+			return
+		}
+		active, err := findLatestActive(resp.Activations.Items, network)
+		if err != nil {
+			if !errors.Is(err, errNoActiveVersionFound) {
+				a.err = err
+			}
+			// the version is not active any more, which is planned as a change of the version
+			a.version, a.activation = 0, nil
+			return
+		}
+		a.version, a.activation = active.PropertyVersion, active
+	})
+	if err := batchActivationsErrors(activations); err != nil {
+		return diag.Errorf("%s read: %s", ErrPropertyActivations, err)
+	}
+	if err := d.Set("property", flattenBatchActivations(activations)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error()))
+	}
+	return nil
+}
+
+func resourcePropertyActivationsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyActivationsUpdate"))
+	client := batchActivationsClient(d, m)
+
+	if !d.HasChange("property") {
+		return nil
+	}
+
+	oldProperties, newProperties := d.GetChange("property")
+	oldActivations, err := expandBatchActivations(oldProperties.([]interface{}))
+	if err != nil {
+		return diag.Errorf("%s update: %s", ErrPropertyActivations, err)
+	}
+	activations, err := expandBatchActivations(newProperties.([]interface{}))
+	if err != nil {
+		return diag.Errorf("%s update: %s", ErrPropertyActivations, err)
+	}
+
+	// only the properties added or activated in a different version are activated,
+	// and the ones removed from the resource are deactivated
+	current := make(map[string]*batchActivation, len(oldActivations))
+	for _, a := range oldActivations {
+		current[a.propertyID] = a
+	}
+	var changed []*batchActivation
+	for _, a := range activations {
+		old, ok := current[a.propertyID]
+		delete(current, a.propertyID)
+		if ok && old.version == a.version {
+			a.previousVersion, a.activation = old.previousVersion, old.activation
+			continue
+		}
+		changed = append(changed, a)
+	}
+	var removed []*batchActivation
+	for _, a := range oldActivations {
+		if _, ok := current[a.propertyID]; ok && a.version > 0 {
+			removed = append(removed, &batchActivation{propertyID: a.propertyID, version: a.version})
+		}
+	}
+
+	network, err := networkAlias(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(changed) > 0 {
+		request, err := newBatchActivationRequest(d, papi.ActivationTypeActivate)
+		if err != nil {
+			return diag.Errorf("%s update: %s", ErrPropertyActivations, err)
+		}
+		if diags := activateBatch(ctx, client, request, d.Get("all_or_nothing").(bool), changed); diags.HasError() {
+			d.Partial(true)
+			return diags
+		}
+	}
+	if len(removed) > 0 {
+		request, err := newBatchActivationRequest(d, papi.ActivationTypeDeactivate)
+		if err != nil {
+			return diag.Errorf("%s update: %s", ErrPropertyActivations, err)
+		}
+		submitBatch(ctx, client, request, removed)
+		pollBatch(ctx, client, removed)
+		if err := batchActivationsErrors(removed); err != nil {
+			d.Partial(true)
+			return diag.Errorf("%s update: %s", ErrPropertyActivations, err)
+		}
+	}
+
+	attrs := map[string]interface{}{
+		"property":      flattenBatchActivations(activations),
+		"create_failed": false,
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(batchActivationsID(network, activations))
+	return nil
+}
+
+func resourcePropertyActivationsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyActivationsDelete"))
+	client := batchActivationsClient(d, m)
+
+	activations, err := expandBatchActivations(d.Get("property").([]interface{}))
+	if err != nil {
+		return diag.Errorf("%s delete: %s", ErrPropertyActivations, err)
+	}
+	if d.Get("create_failed").(bool) {
+		// the creation failed and the tainted resource is most likely replaced, which would take the successful
+		// activations off the network only to activate them again, so they are not deactivated
+		log.FromContext(ctx).Warn("activations of the resource which creation failed are not deactivated")
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "activations of the resource which creation failed were not deactivated",
+		}}
+	}
+	request, err := newBatchActivationRequest(d, papi.ActivationTypeDeactivate)
+	if err != nil {
+		return diag.Errorf("%s delete: %s", ErrPropertyActivations, err)
+	}
+
+	var active []*batchActivation
+	for _, a := range activations {
+		// properties which are not active any more have version 0 in the state
+		if a.version > 0 {
+			active = append(active, a)
+		}
+	}
+	submitBatch(ctx, client, request, active)
+	pollBatch(ctx, client, active)
+	if err := batchActivationsErrors(active); err != nil {
+		return diag.Errorf("%s delete: %s", ErrPropertyActivations, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// activateBatch submits the activations of all the properties, then polls them until they are complete.
+// In the all or nothing mode, the activations which took effect are rolled back when any of the activations fails
+// or times out, and the pending ones are canceled. The rollback is not limited by the operation timeout,
+// which could have been reached already, so it has its own, 'rollback_timeout'.
+func activateBatch(ctx context.Context, client papi.PAPI, request batchActivationRequest, allOrNothing bool, activations []*batchActivation) diag.Diagnostics {
+	submitBatch(ctx, client, request, activations)
+	pollBatch(ctx, client, activations)
+	err := batchActivationsErrors(activations)
+	if err == nil {
+		return nil
+	}
+	diags := diag.Errorf("%s: %s", ErrPropertyActivations, err)
+	if !allOrNothing {
+		return diags
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), request.rollbackTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var rollbacks []*batchActivation
+	var unsettled []string
+	runConcurrently(activations, func(a *batchActivation) {
+		rollback, err := rollbackBatchActivation(ctx, client, request, a)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			unsettled = append(unsettled, fmt.Sprintf("property %s version %d: %s", a.propertyID, a.version, err))
+		}
+		if rollback != nil {
+			rollbacks = append(rollbacks, rollback)
+		}
+	})
+	sort.Slice(rollbacks, func(i, j int) bool { return rollbacks[i].propertyID < rollbacks[j].propertyID })
+
+	if len(unsettled) > 0 {
+		sort.Strings(unsettled)
+		diags = append(diags, diag.Errorf("%s: rollback: activations still in progress: %s", ErrPropertyActivations, strings.Join(unsettled, "\n"))...)
+	}
+	if err := batchActivationsErrors(rollbacks); err != nil {
+		return append(diags, diag.Errorf("%s: rollback: %s", ErrPropertyActivations, err)...)
+	}
+	if len(rollbacks) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("rolled back the activations of %d properties", len(rollbacks)),
+		})
+	}
+	return diags
+}
+
+// rollbackBatchActivation reactivates the previously active version of the property, or deactivates the property
+// which was not active before, once the activation is complete. The rollback is submitted and polled on its own,
+// so that it is not held back by the other activations still in progress
+func rollbackBatchActivation(ctx context.Context, client papi.PAPI, request batchActivationRequest, a *batchActivation) (*batchActivation, error) {
+	tookEffect, err := settleBatchActivation(ctx, client, a)
+	if err != nil || !tookEffect || a.previousVersion == a.version {
+		return nil, err
+	}
+
+	log.FromContext(ctx).Infof("rolling back activation of property %s version %d", a.propertyID, a.version)
+	rollback := &batchActivation{propertyID: a.propertyID, version: a.previousVersion}
+	if a.previousVersion == 0 {
+		rollback.version = a.version
+		request.activationType = papi.ActivationTypeDeactivate
+	}
+	rollback.activation, rollback.err = submitBatchActivation(ctx, client, request, rollback)
+	pollBatch(ctx, client, []*batchActivation{rollback})
+	return rollback, nil
+}
+
+// settleBatchActivation waits until the activation is complete, after its polling failed or timed out.
+// A pending activation is canceled, as it would otherwise override the rollback. It returns whether
+// the activation took effect, so that it has to be rolled back
+func settleBatchActivation(ctx context.Context, client papi.PAPI, a *batchActivation) (bool, error) {
+	if a.activation == nil {
+		// the activation was not submitted
+		return false, nil
+	}
+	if a.err == nil {
+		return true, nil
+	}
+	logger := log.FromContext(ctx)
+
+	switch a.activation.Status {
+	case papi.ActivationStatusFailed, papi.ActivationStatusAborted:
+		return false, nil
+	case papi.ActivationStatusPending, papi.ActivationStatusNew:
+		_, err := client.CancelActivation(ctx, papi.CancelActivationRequest{
+			PropertyID:   a.propertyID,
+			ActivationID: a.activation.ActivationID,
+		})
+		if err == nil {
+			logger.Infof("canceled activation of property %s version %d", a.propertyID, a.version)
+			return false, nil
+		}
+		// the activation could have started in the meantime
+		logger.Warnf("canceling activation of property %s version %d: %s", a.propertyID, a.version, err)
+	}
+
+	activation, diags := pollActivation(ctx, client, a.activation, a.propertyID)
+	a.activation = activation
+	switch {
+	case diags == nil:
+		return true, nil
+	case activation.Status == papi.ActivationStatusFailed, activation.Status == papi.ActivationStatusAborted:
+		return false, nil
+	}
+	return false, diagsToError(diags)
+}
+
+// submitBatch submits the activation or deactivation of every property, unless it is already in progress,
+// and records the version active on the network before
+func submitBatch(ctx context.Context, client papi.PAPI, request batchActivationRequest, activations []*batchActivation) {
+	runConcurrently(activations, func(a *batchActivation) {
+		a.activation, a.err = submitBatchActivation(ctx, client, request, a)
+	})
+}
+
+func submitBatchActivation(ctx context.Context, client papi.PAPI, request batchActivationRequest, a *batchActivation) (*papi.Activation, error) {
+	resp, err := client.GetActivations(ctx, papi.GetActivationsRequest{PropertyID: a.propertyID})
+	if err != nil {
+		return nil, err
+	}
+	previous, err := findLatestActive(resp.Activations.Items, request.network)
+	switch {
+	case err == nil:
+		a.previousVersion = previous.PropertyVersion
+	case !errors.Is(err, errNoActiveVersionFound):
+		return nil, err
+	}
+
+	if request.activationType == papi.ActivationTypeActivate {
+		rules, err := client.GetRuleTree(ctx, papi.GetRuleTreeRequest{
+			PropertyID:      a.propertyID,
+			PropertyVersion: a.version,
+			ValidateRules:   true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(rules.Errors) > 0 {
+			return nil, fmt.Errorf("activation cannot continue due to rule errors: %s", flattenErrorArray(rules.Errors))
+		}
+	}
+
+	activation, err := lookupActivation(ctx, client, lookupActivationRequest{
+		propertyID: a.propertyID,
+		version:    a.version,
+		network:    request.network,
+		activationType: map[papi.ActivationType]struct{}{
+			papi.ActivationTypeActivate:   {},
+			papi.ActivationTypeDeactivate: {},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if activation != nil && activation.ActivationType == request.activationType {
+		// completed requests are reused only when still in effect, unlike e.g. the activation of a version being rolled back to
+		inEffect := activation.Status != papi.ActivationStatusActive ||
+			activation.ActivationType == papi.ActivationTypeActivate && previous != nil && previous.ActivationID == activation.ActivationID ||
+			activation.ActivationType == papi.ActivationTypeDeactivate && previous == nil
+		if inEffect {
+			return activation, nil
+		}
+	}
+
+	activationID, diags := createActivation(ctx, client, addPropertyComplianceRecord(request.complianceRecord, papi.CreateActivationRequest{
+		PropertyID: a.propertyID,
+		Activation: papi.Activation{
+			ActivationType:         request.activationType,
+			Network:                request.network,
+			PropertyVersion:        a.version,
+			NotifyEmails:           request.notify,
+			AcknowledgeAllWarnings: request.acknowledgeRuleWarnings,
+			Note:                   request.note,
+		},
+	}))
+	if diags.HasError() {
+		return nil, diagsToError(diags)
+	}
+
+	// query the activation to retrieve the initial status
+	act, err := client.GetActivation(ctx, papi.GetActivationRequest{
+		ActivationID: activationID,
+		PropertyID:   a.propertyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return act.Activation, nil
+}
+
+// pollBatch polls the submitted activations concurrently, until all of them are complete
+func pollBatch(ctx context.Context, client papi.PAPI, activations []*batchActivation) {
+	runConcurrently(activations, func(a *batchActivation) {
+		if a.err != nil {
+			return
+		}
+		// the status of the activation is the last one polled, also when the polling failed or timed out
+		activation, diags := pollActivation(ctx, client, a.activation, a.propertyID)
+		a.activation = activation
+		if diags != nil {
+			a.err = diagsToError(diags)
+		}
+	})
+}
+
+func runConcurrently(activations []*batchActivation, f func(*batchActivation)) {
+	var wg sync.WaitGroup
+	for _, a := range activations {
+		wg.Add(1)
+		go func(a *batchActivation) {
+			defer wg.Done()
+			f(a)
+		}(a)
+	}
+	wg.Wait()
+}
+
+func newBatchActivationRequest(d *schema.ResourceData, activationType papi.ActivationType) (batchActivationRequest, error) {
+	network, err := networkAlias(d)
+	if err != nil {
+		return batchActivationRequest{}, err
+	}
+	var notify []string
+	for _, contact := range d.Get("contact").(*schema.Set).List() {
+		notify = append(notify, cast.ToString(contact))
+	}
+	complianceRecord, err := tf.GetListValue("compliance_record", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return batchActivationRequest{}, err
+	}
+	rollbackTimeout := RollbackTimeout
+	if v := d.Get("rollback_timeout").(string); v != "" {
+		if rollbackTimeout, err = time.ParseDuration(v); err != nil {
+			return batchActivationRequest{}, err
+		}
+	}
+	return batchActivationRequest{
+		activationType:          activationType,
+		network:                 network,
+		notify:                  notify,
+		note:                    d.Get("note").(string),
+		acknowledgeRuleWarnings: d.Get("auto_acknowledge_rule_warnings").(bool),
+		complianceRecord:        complianceRecord,
+		rollbackTimeout:         rollbackTimeout,
+	}, nil
+}
+
+func expandBatchActivations(properties []interface{}) ([]*batchActivation, error) {
+	activations := make([]*batchActivation, 0, len(properties))
+	seen := make(map[string]bool, len(properties))
+	for _, p := range properties {
+		property := p.(map[string]interface{})
+		propertyID := str.AddPrefix(property["property_id"].(string), "prp_")
+		if seen[propertyID] {
+			return nil, fmt.Errorf("property %s is activated more than once", propertyID)
+		}
+		seen[propertyID] = true
+
+		a := &batchActivation{
+			propertyID:      propertyID,
+			version:         property["version"].(int),
+			previousVersion: property["previous_version"].(int),
+		}
+		if activationID := property["activation_id"].(string); activationID != "" {
+			a.activation = &papi.Activation{
+				ActivationID: activationID,
+				Status:       papi.ActivationStatus(property["status"].(string)),
+			}
+		}
+		activations = append(activations, a)
+	}
+	return activations, nil
+}
+
+func flattenBatchActivations(activations []*batchActivation) []interface{} {
+	properties := make([]interface{}, 0, len(activations))
+	for _, a := range activations {
+		properties = append(properties, map[string]interface{}{
+			"property_id":      a.propertyID,
+			"version":          a.version,
+			"activation_id":    a.activationID(),
+			"status":           string(a.activationStatus()),
+			"previous_version": a.previousVersion,
+		})
+	}
+	return properties
+}
+
+func (a *batchActivation) activationID() string {
+	if a.activation == nil {
+		return ""
+	}
+	return a.activation.ActivationID
+}
+
+func (a *batchActivation) activationStatus() papi.ActivationStatus {
+	if a.activation == nil {
+		return ""
+	}
+	return a.activation.Status
+}
+
+// batchActivationsErrors returns the errors of the activations which failed, one per property
+func batchActivationsErrors(activations []*batchActivation) error {
+	var messages []string
+	for _, a := range activations {
+		if a.err != nil {
+			messages = append(messages, fmt.Sprintf("property %s version %d: %s", a.propertyID, a.version, a.err))
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
+
+func diagsToError(diags diag.Diagnostics) error {
+	messages := make([]string, 0, len(diags))
+	for _, d := range diags {
+		messages = append(messages, d.Summary)
+	}
+	return errors.New(strings.Join(messages, ": "))
+}
+
+// batchActivationsID returns the ID of akamai_property_activations, derived from the network and the activated properties
+func batchActivationsID(network papi.ActivationNetwork, activations []*batchActivation) string {
+	propertyIDs := make([]string, 0, len(activations))
+	for _, a := range activations {
+		propertyIDs = append(propertyIDs, a.propertyID)
+	}
+	sort.Strings(propertyIDs)
+	sum := sha1.Sum([]byte(strings.Join(propertyIDs, ",")))
+	return fmt.Sprintf("%s:%s", network, hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,434 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// propertyActivationsState holds the activations of the properties created by the mocked PAPI calls
+type propertyActivationsState struct {
+	mu          sync.Mutex
+	submitted   int
+	activations map[string][]*papi.Activation
+	// failing contains the property versions which activation fails, e.g. 'prp_2:1'
+	failing map[string]bool
+	// progress contains the statuses which the activation of a property version goes through, one per request,
+	// before it is complete, e.g. 'prp_2:1' pending
+	progress map[string][]papi.ActivationStatus
+}
+
+func newPropertyActivationsState() *propertyActivationsState {
+	return &propertyActivationsState{
+		activations: map[string][]*papi.Activation{},
+		failing:     map[string]bool{},
+		progress:    map[string][]papi.ActivationStatus{},
+	}
+}
+
+func (s *propertyActivationsState) addActivation(propertyID string, activationType papi.ActivationType, version int) *papi.Activation {
+	s.submitted++
+	status := s.completeStatus(propertyID, version)
+	if progress := s.progress[fmt.Sprintf("%s:%d", propertyID, version)]; len(progress) > 0 {
+		status = progress[0]
+	}
+	activation := &papi.Activation{
+		ActivationID:    fmt.Sprintf("atv_%s_%d", propertyID, len(s.activations[propertyID])+1),
+		ActivationType:  activationType,
+		PropertyID:      propertyID,
+		PropertyVersion: version,
+		Network:         papi.ActivationNetworkStaging,
+		Status:          status,
+		SubmitDate:      fmt.Sprintf("2024-01-01T00:00:%02dZ", s.submitted),
+		UpdateDate:      fmt.Sprintf("2024-01-01T00:00:%02dZ", s.submitted),
+	}
+	s.activations[propertyID] = append(s.activations[propertyID], activation)
+	return activation
+}
+
+func (s *propertyActivationsState) completeStatus(propertyID string, version int) papi.ActivationStatus {
+	if s.failing[fmt.Sprintf("%s:%d", propertyID, version)] {
+		return papi.ActivationStatusFailed
+	}
+	return papi.ActivationStatusActive
+}
+
+// advance moves the activation in progress to its next status
+func (s *propertyActivationsState) advance(a *papi.Activation) {
+	key := fmt.Sprintf("%s:%d", a.PropertyID, a.PropertyVersion)
+	if a.Status != papi.ActivationStatusActive && a.Status != papi.ActivationStatusFailed && a.Status != papi.ActivationStatusAborted {
+		s.progress[key] = s.progress[key][1:]
+		if len(s.progress[key]) == 0 {
+			a.Status = s.completeStatus(a.PropertyID, a.PropertyVersion)
+			return
+		}
+		a.Status = s.progress[key][0]
+	}
+}
+
+// activeVersion returns the version active on staging, 0 if none
+func (s *propertyActivationsState) activeVersion(propertyID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	active, err := findLatestActive(append([]*papi.Activation{}, s.activations[propertyID]...), papi.ActivationNetworkStaging)
+	if err != nil {
+		return 0
+	}
+	return active.PropertyVersion
+}
+
+func (s *propertyActivationsState) count(propertyID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.activations[propertyID])
+}
+
+// mockPropertyActivations sets up the PAPI calls used by akamai_property_activations resource, operating on the given state
+func mockPropertyActivations(client *papi.Mock, state *propertyActivationsState) {
+	getActivations := client.On("GetActivations", AnyCTX, mock.Anything).Maybe()
+	getActivations.Run(func(args mock.Arguments) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		req := args.Get(1).(papi.GetActivationsRequest)
+		items := make([]*papi.Activation, 0, len(state.activations[req.PropertyID]))
+		for _, a := range state.activations[req.PropertyID] {
+			activation := *a
+			items = append(items, &activation)
+		}
+		getActivations.Return(&papi.GetActivationsResponse{Activations: papi.ActivationsItems{Items: items}}, nil)
+	})
+
+	getActivation := client.On("GetActivation", AnyCTX, mock.Anything).Maybe()
+	getActivation.Run(func(args mock.Arguments) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		req := args.Get(1).(papi.GetActivationRequest)
+		for _, a := range state.activations[req.PropertyID] {
+			if a.ActivationID == req.ActivationID {
+				activation := *a
+				state.advance(a)
+				getActivation.Return(&papi.GetActivationResponse{Activation: &activation}, nil)
+				return
+			}
+		}
+		panic("activation not found: " + req.ActivationID)
+	})
+
+	cancelActivation := client.On("CancelActivation", AnyCTX, mock.Anything).Maybe()
+	cancelActivation.Run(func(args mock.Arguments) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		req := args.Get(1).(papi.CancelActivationRequest)
+		for _, a := range state.activations[req.PropertyID] {
+			if a.ActivationID != req.ActivationID {
+				continue
+			}
+			if a.Status != papi.ActivationStatusPending {
+				cancelActivation.Return(nil, fmt.Errorf("%w: activation is %s", papi.ErrCancelActivation, a.Status))
+				return
+			}
+			a.Status = papi.ActivationStatusAborted
+			activation := *a
+			cancelActivation.Return(&papi.CancelActivationResponse{Activations: papi.ActivationsItems{Items: []*papi.Activation{&activation}}}, nil)
+			return
+		}
+		panic("activation not found: " + req.ActivationID)
+	})
+
+	createActivation := client.On("CreateActivation", AnyCTX, mock.Anything).Maybe()
+	createActivation.Run(func(args mock.Arguments) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		req := args.Get(1).(papi.CreateActivationRequest)
+		activation := state.addActivation(req.PropertyID, req.Activation.ActivationType, req.Activation.PropertyVersion)
+		activation.ComplianceRecord = req.Activation.ComplianceRecord
+		createActivation.Return(&papi.CreateActivationResponse{ActivationID: activation.ActivationID}, nil)
+	})
+
+	client.On("GetRuleTree", AnyCTX, mock.Anything).Return(&papi.GetRuleTreeResponse{}, nil).Maybe()
+}
+
+func TestResourcePropertyActivations(t *testing.T) {
+	checkActiveVersion := func(state *propertyActivationsState, propertyID string, version int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			assert.Equal(t, version, state.activeVersion(propertyID), "active version of %s", propertyID)
+			return nil
+		}
+	}
+
+	t.Run("activate, update and deactivate properties", func(t *testing.T) {
+		client := &papi.Mock{}
+		state := newPropertyActivationsState()
+		state.addActivation("prp_1", papi.ActivationTypeActivate, 1)
+		mockPropertyActivations(client, state)
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResPropertyActivations/create.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "network", "STAGING"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.#", "2"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.0.property_id", "prp_1"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.0.version", "2"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.0.previous_version", "1"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.0.status", "ACTIVE"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.0.activation_id", "atv_prp_1_2"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.1.property_id", "prp_2"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.1.version", "1"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.1.previous_version", "0"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.1.status", "ACTIVE"),
+							checkActiveVersion(state, "prp_1", 2),
+							checkActiveVersion(state, "prp_2", 1),
+						),
+					},
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResPropertyActivations/update.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.0.version", "3"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.0.previous_version", "2"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.1.version", "1"),
+							checkActiveVersion(state, "prp_1", 3),
+							// the property which version did not change is not activated again
+							func(*terraform.State) error {
+								assert.Equal(t, 1, state.count("prp_2"))
+								return nil
+							},
+						),
+					},
+				},
+				CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+					checkActiveVersion(state, "prp_1", 0),
+					checkActiveVersion(state, "prp_2", 0),
+				),
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("failed activation is reported without rolling back the others", func(t *testing.T) {
+		client := &papi.Mock{}
+		state := newPropertyActivationsState()
+		state.addActivation("prp_1", papi.ActivationTypeActivate, 1)
+		state.failing["prp_2:1"] = true
+		mockPropertyActivations(client, state)
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestResPropertyActivations/create.tf"),
+						ExpectError: regexp.MustCompile("activation request failed in downstream system"),
+					},
+					{
+						// the successful activation is kept in the state of the tainted resource and the failed one is stored with version 0
+						RefreshState: true,
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "create_failed", "true"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.0.version", "2"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.0.status", "ACTIVE"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.1.version", "0"),
+							checkActiveVersion(state, "prp_1", 2),
+							checkActiveVersion(state, "prp_2", 0),
+						),
+						ExpectNonEmptyPlan: true,
+					},
+					{
+						// the replacement of the tainted resource retries the failed activation only,
+						// without deactivating the successful one
+						PreConfig: func() {
+							state.mu.Lock()
+							defer state.mu.Unlock()
+							delete(state.failing, "prp_2:1")
+						},
+						Config: testutils.LoadFixtureString(t, "testdata/TestResPropertyActivations/create.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "create_failed", "false"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.1.version", "1"),
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "property.1.status", "ACTIVE"),
+							checkActiveVersion(state, "prp_1", 2),
+							checkActiveVersion(state, "prp_2", 1),
+							func(*terraform.State) error {
+								assert.Equal(t, 2, state.count("prp_1"))
+								return nil
+							},
+						),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("activations with compliance record", func(t *testing.T) {
+		client := &papi.Mock{}
+		state := newPropertyActivationsState()
+		mockPropertyActivations(client, state)
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResPropertyActivations/compliance_record.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_activations.test", "compliance_record.0.noncompliance_reason_emergency.0.ticket_id", "JIRA-1"),
+							func(*terraform.State) error {
+								state.mu.Lock()
+								defer state.mu.Unlock()
+								assert.Equal(t, &papi.ComplianceRecordEmergency{TicketID: "JIRA-1"}, state.activations["prp_1"][0].ComplianceRecord)
+								return nil
+							},
+						),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("all or nothing rolls back successful activations", func(t *testing.T) {
+		client := &papi.Mock{}
+		state := newPropertyActivationsState()
+		state.addActivation("prp_1", papi.ActivationTypeActivate, 1)
+		state.failing["prp_2:1"] = true
+		mockPropertyActivations(client, state)
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestResPropertyActivations/all_or_nothing.tf"),
+						ExpectError: regexp.MustCompile(`property prp_2 version 1: activation request failed in downstream system`),
+					},
+				},
+			})
+		})
+		// version 2 was activated and then the previously active version 1 reactivated
+		assert.Equal(t, 3, state.count("prp_1"))
+		assert.Equal(t, 1, state.activeVersion("prp_1"))
+		assert.Equal(t, 0, state.activeVersion("prp_2"))
+	})
+
+	t.Run("all or nothing deactivates properties not active before", func(t *testing.T) {
+		client := &papi.Mock{}
+		state := newPropertyActivationsState()
+		state.failing["prp_2:1"] = true
+		mockPropertyActivations(client, state)
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestResPropertyActivations/all_or_nothing.tf"),
+						ExpectError: regexp.MustCompile(`property prp_2 version 1: activation request failed in downstream system`),
+					},
+				},
+			})
+		})
+		assert.Equal(t, 2, state.count("prp_1"))
+		assert.Equal(t, 0, state.activeVersion("prp_1"))
+	})
+
+	t.Run("property activated more than once", func(t *testing.T) {
+		client := &papi.Mock{}
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestResPropertyActivations/duplicate_property.tf"),
+						ExpectError: regexp.MustCompile(`property prp_1 is activated more than once`),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+}
+
+func TestActivateBatchAllOrNothingTimeout(t *testing.T) {
+	pending := func(status papi.ActivationStatus, polls int) []papi.ActivationStatus {
+		progress := make([]papi.ActivationStatus, polls)
+		for i := range progress {
+			progress[i] = status
+		}
+		return progress
+	}
+	activations := func() []*batchActivation {
+		return []*batchActivation{
+			{propertyID: "prp_1", version: 2},
+			{propertyID: "prp_2", version: 1},
+			{propertyID: "prp_3", version: 1},
+		}
+	}
+
+	t.Run("pending activations are canceled and the others rolled back", func(t *testing.T) {
+		client := &papi.Mock{}
+		state := newPropertyActivationsState()
+		state.addActivation("prp_1", papi.ActivationTypeActivate, 1)
+		state.progress["prp_2:1"] = pending(papi.ActivationStatusPending, 1000)
+		mockPropertyActivations(client, state)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*30)
+		defer cancel()
+		request := batchActivationRequest{
+			activationType:  papi.ActivationTypeActivate,
+			network:         papi.ActivationNetworkStaging,
+			rollbackTimeout: time.Second * 10,
+		}
+		// the rate limiter fails at once when the context is done
+		limited := rateLimitedClient{PAPI: client, limiter: rate.NewLimiter(rate.Inf, 1)}
+		diags := activateBatch(ctx, limited, request, true, activations())
+
+		require.True(t, diags.HasError())
+		assert.Equal(t, fmt.Sprintf("%s: property prp_2 version 1: %s", ErrPropertyActivations, DiagWarnActivationTimeout.Summary), diags[0].Summary)
+		assert.Len(t, diags, 2)
+		assert.Equal(t, "rolled back the activations of 2 properties", diags[1].Summary)
+		assert.Equal(t, 1, state.activeVersion("prp_1"))
+		assert.Equal(t, papi.ActivationStatusAborted, state.activations["prp_2"][0].Status)
+		assert.Equal(t, 0, state.activeVersion("prp_2"))
+		assert.Equal(t, 2, state.count("prp_3"))
+		assert.Equal(t, 0, state.activeVersion("prp_3"))
+	})
+
+	t.Run("activation which cannot be canceled still in progress after the rollback timeout", func(t *testing.T) {
+		client := &papi.Mock{}
+		state := newPropertyActivationsState()
+		state.progress["prp_3:1"] = pending(papi.ActivationStatusZone1, 1000)
+		mockPropertyActivations(client, state)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*30)
+		defer cancel()
+		request := batchActivationRequest{
+			activationType:  papi.ActivationTypeActivate,
+			network:         papi.ActivationNetworkStaging,
+			rollbackTimeout: time.Millisecond * 30,
+		}
+		limited := rateLimitedClient{PAPI: client, limiter: rate.NewLimiter(rate.Inf, 1)}
+		diags := activateBatch(ctx, limited, request, true, activations())
+
+		require.True(t, diags.HasError())
+		assert.Equal(t, fmt.Sprintf("%s: rollback: activations still in progress: property prp_3 version 1: %s", ErrPropertyActivations, DiagWarnActivationTimeout.Summary), diags[1].Summary)
+		assert.Equal(t, "rolled back the activations of 2 properties", diags[len(diags)-1].Summary)
+		assert.Equal(t, 0, state.activeVersion("prp_1"))
+		assert.Equal(t, 0, state.activeVersion("prp_2"))
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -34,11 +35,12 @@ type BootstrapResource struct {
 
 // BootstrapResourceModel is a model for akamai_property_bootstrap resource
 type BootstrapResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	GroupID    types.String `tfsdk:"group_id"`
-	ContractID types.String `tfsdk:"contract_id"`
-	ProductID  types.String `tfsdk:"product_id"`
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	GroupID       types.String `tfsdk:"group_id"`
+	ContractID    types.String `tfsdk:"contract_id"`
+	ProductID     types.String `tfsdk:"product_id"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
 }
 
 // NewBootstrapResource returns new property bootstrap resource
@@ -91,6 +93,13 @@ func (r *BootstrapResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					modifiers.PreventStringUpdate(),
 				},
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Description: "Whether to adopt the existing property with the same name, instead of failing to create it. " +
+					"The property must belong to the same contract and group and use the same product",
+			},
 			"id": schema.StringAttribute{
 				Computed:    true,
 				Description: "ID of the Property",
@@ -137,6 +146,20 @@ func (r *BootstrapResource) Create(ctx context.Context, req resource.CreateReque
 	productID := str.AddPrefix(data.ProductID.ValueString(), "prd_")
 
 	client := Client(r.meta)
+	if data.AdoptExisting.ValueBool() {
+		propertyID, err := findPropertyToAdopt(ctx, client, data.Name.ValueString(), groupID, contractID, productID)
+		if err != nil {
+			resp.Diagnostics.AddError(err.Error(), "")
+			return
+		}
+		if propertyID != "" {
+			tflog.Debug(ctx, fmt.Sprintf("adopting existing property %q", propertyID))
+			data.ID = types.StringValue(propertyID)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
 	propertyID, err := createProperty(ctx, client, data.Name.ValueString(), groupID, contractID, productID, "")
 	if err != nil {
 		err = interpretCreatePropertyErrorFramework(ctx, err, client, groupID, contractID, productID)
@@ -150,6 +173,44 @@ func (r *BootstrapResource) Create(ctx context.Context, req resource.CreateReque
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// findPropertyToAdopt returns the ID of the existing property with the given name, empty if there is none.
+// The property has to belong to the given contract and group and its latest version has to use the given product.
+func findPropertyToAdopt(ctx context.Context, client papi.PAPI, name, groupID, contractID, productID string) (string, error) {
+	results, err := client.SearchProperties(ctx, papi.SearchRequest{Key: papi.SearchKeyPropertyName, Value: name})
+	if err != nil {
+		return "", err
+	}
+
+	var found *papi.SearchItem
+	for i, item := range results.Versions.Items {
+		if item.PropertyName == name {
+			found = &results.Versions.Items[i]
+			break
+		}
+	}
+	if found == nil {
+		return "", nil
+	}
+	if found.ContractID != contractID || found.GroupID != groupID {
+		return "", fmt.Errorf("%w: property %q (%s) belongs to contract %q and group %q", ErrPropertyAdoption,
+			name, found.PropertyID, found.ContractID, found.GroupID)
+	}
+
+	property, err := fetchLatestProperty(ctx, client, found.PropertyID, groupID, contractID)
+	if err != nil {
+		return "", err
+	}
+	version, err := fetchPropertyVersion(ctx, client, property.PropertyID, groupID, contractID, property.LatestVersion)
+	if err != nil {
+		return "", err
+	}
+	if version.Version.ProductID != productID {
+		return "", fmt.Errorf("%w: property %q (%s) uses product %q", ErrPropertyAdoption,
+			name, found.PropertyID, version.Version.ProductID)
+	}
+	return found.PropertyID, nil
+}
+
 func interpretCreatePropertyErrorFramework(ctx context.Context, err error, client papi.PAPI, groupID string, contractID string, productID string) error {
 	if errors.Is(err, papi.ErrNotFound) {
 		if _, err = getGroup(ctx, client, groupID); err != nil {
@@ -201,8 +262,17 @@ func (r *BootstrapResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 }
 
-// Update of group, contract, product is noop, it will return an error before invoking Update. Updating name will result in resource replacement
-func (r *BootstrapResource) Update(_ context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse) {
+// Update of group, contract, product is noop, it will return an error before invoking Update. Updating name will result in resource replacement.
+// Only adopt_existing can be updated, which is stored in the state as it matters on creation only
+func (r *BootstrapResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *BootstrapResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 // Delete implements resource's Delete method
@@ -267,6 +337,8 @@ func (r *BootstrapResource) ImportState(ctx context.Context, req resource.Import
 		GroupID:    types.StringValue(property.GroupID),
 		Name:       types.StringValue(property.PropertyName),
 		ID:         types.StringValue(propertyID),
+		// it is impossible to fetch adopt_existing from server
+		AdoptExisting: types.BoolValue(false),
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
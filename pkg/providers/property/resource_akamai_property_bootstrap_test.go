@@ -10,6 +10,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/mock"
 )
 
 type testDataForPropertyBootstrap struct {
@@ -103,6 +104,86 @@ func TestBootstrapResourceCreate(t *testing.T) {
 			},
 			error: regexp.MustCompile(`Error: group not found: grp_1`),
 		},
+		"adopt existing property": {
+			configPath: "testdata/TestResPropertyBootstrap/adopt_existing.tf",
+			init: func(t *testing.T, m *papi.Mock, data testDataForPropertyBootstrap) {
+				expectSearchPropertyByName(m, data.name, papi.SearchItem{
+					ContractID:      data.contractID,
+					GroupID:         data.groupID,
+					PropertyID:      data.propertyID,
+					PropertyName:    data.name,
+					PropertyVersion: 2,
+				})
+				prp := &papi.Property{
+					ContractID:    "ctr_2",
+					GroupID:       "grp_1",
+					ProductID:     "prd_3",
+					PropertyID:    "prp_123",
+					PropertyName:  "property_name",
+					LatestVersion: 2,
+				}
+				ExpectGetProperty(m, data.propertyID, data.groupID, data.contractID, prp)
+				m.On("GetPropertyVersion", AnyCTX, papi.GetPropertyVersionRequest{
+					PropertyID:      data.propertyID,
+					GroupID:         data.groupID,
+					ContractID:      data.contractID,
+					PropertyVersion: 2,
+				}).Return(&papi.GetPropertyVersionsResponse{
+					PropertyID: data.propertyID,
+					Version:    papi.PropertyVersionGetItem{PropertyVersion: 2, ProductID: data.productID},
+				}, nil).Once()
+				ExpectRemoveProperty(m, data.propertyID, data.contractID, data.groupID)
+			},
+			mockData: testDataForPropertyBootstrap{
+				propertyID: "prp_123",
+				name:       "property_name",
+				groupID:    "grp_1",
+				contractID: "ctr_2",
+				productID:  "prd_3",
+			},
+		},
+		"adopt existing property - property not found is created": {
+			configPath: "testdata/TestResPropertyBootstrap/adopt_existing.tf",
+			init: func(t *testing.T, m *papi.Mock, data testDataForPropertyBootstrap) {
+				expectSearchPropertyByName(m, data.name)
+				ExpectCreateProperty(m, data.name, data.groupID, data.contractID, data.productID, data.propertyID)
+				prp := &papi.Property{
+					ContractID:   "ctr_2",
+					GroupID:      "grp_1",
+					ProductID:    "prd_3",
+					PropertyID:   "prp_123",
+					PropertyName: "property_name",
+				}
+				ExpectGetProperty(m, data.propertyID, data.groupID, data.contractID, prp)
+				ExpectRemoveProperty(m, data.propertyID, data.contractID, data.groupID)
+			},
+			mockData: testDataForPropertyBootstrap{
+				propertyID: "prp_123",
+				name:       "property_name",
+				groupID:    "grp_1",
+				contractID: "ctr_2",
+				productID:  "prd_3",
+			},
+		},
+		"adopt existing property - error property in another group": {
+			configPath: "testdata/TestResPropertyBootstrap/adopt_existing.tf",
+			init: func(t *testing.T, m *papi.Mock, data testDataForPropertyBootstrap) {
+				expectSearchPropertyByName(m, data.name, papi.SearchItem{
+					ContractID:   data.contractID,
+					GroupID:      "grp_5",
+					PropertyID:   data.propertyID,
+					PropertyName: data.name,
+				})
+			},
+			mockData: testDataForPropertyBootstrap{
+				propertyID: "prp_123",
+				name:       "property_name",
+				groupID:    "grp_1",
+				contractID: "ctr_2",
+				productID:  "prd_3",
+			},
+			error: regexp.MustCompile(`cannot adopt existing property: property "property_name" \(prp_123\) belongs to contract "ctr_2" and group "grp_5"`),
+		},
 	}
 
 	for name, test := range tests {
@@ -401,6 +482,13 @@ func TestBootstrapResourceImport(t *testing.T) {
 	}
 }
 
+func expectSearchPropertyByName(m *papi.Mock, name string, items ...papi.SearchItem) *mock.Call {
+	return m.On("SearchProperties", AnyCTX, papi.SearchRequest{
+		Key:   papi.SearchKeyPropertyName,
+		Value: name,
+	}).Return(&papi.SearchResponse{Versions: papi.SearchItems{Items: items}}, nil).Once()
+}
+
 func checkPropertyBootstrapAttributes(data testDataForPropertyBootstrap) resource.TestCheckFunc {
 	if data.withoutPrefixes {
 		return resource.ComposeAggregateTestCheckFunc(
@@ -0,0 +1,391 @@
+package property
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/str"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/tf"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/apex/log"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePropertyHostname() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePropertyHostnameCreate,
+		ReadContext:   resourcePropertyHostnameRead,
+		UpdateContext: resourcePropertyHostnameUpdate,
+		DeleteContext: resourcePropertyHostnameDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePropertyHostnameImport,
+		},
+		CustomizeDiff: propertyHostnameCustomDiff,
+		Schema: map[string]*schema.Schema{
+			"property_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Identifies the property to which the hostname is added",
+				StateFunc:   addPrefixToState("prp_"),
+			},
+			"contract_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Identifies the contract to which the property is assigned",
+				StateFunc:   addPrefixToState("ctr_"),
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Identifies the group to which the property is assigned",
+				StateFunc:   addPrefixToState("grp_"),
+			},
+			"cname_from": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+				Description:      "The hostname that the end users see, e.g. 'www.example.com'",
+			},
+			"cname_to": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+				Description:      "The edge hostname to which the hostname points, e.g. 'www.example.com.edgesuite.net'",
+			},
+			"cert_provisioning_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: tf.IsNotBlank,
+				Description:      "The certificate provisioning type, either 'CPS_MANAGED' or 'DEFAULT'",
+			},
+			"cname_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"edge_hostname_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cert_status": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     certStatus,
+			},
+			"latest_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Property's current latest version number",
+			},
+		},
+	}
+}
+
+// propertyLocks serializes the modifications of hostnames of the same property, e.g. by many akamai_property_hostname
+// resources in one apply, so that they do not create many property versions or overwrite each other's changes
+var propertyLocks sync.Map
+
+type (
+	// hostnamesManagersKey identifies the property in the provider instance, which is identified by its operation ID
+	hostnamesManagersKey struct {
+		operationID string
+		propertyID  string
+	}
+
+	// hostnamesManagers records which resources manage the hostnames of the property
+	hostnamesManagers struct {
+		property         atomic.Bool
+		propertyHostname atomic.Bool
+	}
+)
+
+// propertyHostnamesManagers records the properties which hostnames are managed by akamai_property and akamai_property_hostname
+// resources of the same provider instance. akamai_property replaces the whole list of hostnames of the property, removing
+// the hostnames added by akamai_property_hostname, so the two resources cannot be used for the same property.
+var propertyHostnamesManagers sync.Map
+
+// checkHostnamesManagers records that the hostnames of the property are managed by akamai_property resource, if byProperty is true,
+// or by akamai_property_hostname resource otherwise, and returns ErrHostnamesManagedByProperty if they are managed by both.
+// The conflict is detected only when both resources are planned or applied by the same provider instance, e.g. in one configuration.
+func checkHostnamesManagers(m interface{}, propertyID string, byProperty bool) error {
+	key := hostnamesManagersKey{operationID: meta.Must(m).OperationID(), propertyID: str.AddPrefix(propertyID, "prp_")}
+	value, _ := propertyHostnamesManagers.LoadOrStore(key, &hostnamesManagers{})
+	managers := value.(*hostnamesManagers)
+	if byProperty {
+		managers.property.Store(true)
+	} else {
+		managers.propertyHostname.Store(true)
+	}
+	if managers.property.Load() && managers.propertyHostname.Load() {
+		return fmt.Errorf("%w: %s, which overwrite each other's changes. Define all hostnames of the property either in akamai_property "+
+			"or in akamai_property_hostname resources", ErrHostnamesManagedByProperty, key.propertyID)
+	}
+	return nil
+}
+
+// propertyHostnameCustomDiff rejects akamai_property_hostname resources of properties managed by akamai_property resources
+func propertyHostnameCustomDiff(_ context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if !diff.NewValueKnown("property_id") {
+		return nil
+	}
+	return checkHostnamesManagers(m, diff.Get("property_id").(string), false)
+}
+
+// lockProperty locks the property with the given ID and returns the function unlocking it
+func lockProperty(propertyID string) func() {
+	lock, _ := propertyLocks.LoadOrStore(propertyID, &sync.Mutex{})
+	mutex := lock.(*sync.Mutex)
+	mutex.Lock()
+	return mutex.Unlock
+}
+
+func resourcePropertyHostnameCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyHostnameCreate"))
+	client := Client(meta.Must(m))
+
+	propertyID := str.AddPrefix(d.Get("property_id").(string), "prp_")
+	cnameFrom := d.Get("cname_from").(string)
+	hostname := papi.Hostname{
+		CnameType:            papi.HostnameCnameTypeEdgeHostname,
+		CnameFrom:            cnameFrom,
+		CnameTo:              d.Get("cname_to").(string),
+		CertProvisioningType: d.Get("cert_provisioning_type").(string),
+	}
+
+	if err := checkHostnamesManagers(m, propertyID, false); err != nil {
+		return diag.Errorf("%s create: %s", ErrPropertyHostname, err)
+	}
+
+	err := modifyPropertyHostnames(ctx, client, d, func(hostnames []papi.Hostname) ([]papi.Hostname, error) {
+		if findHostname(hostnames, cnameFrom) != nil {
+			return nil, fmt.Errorf("%w: %s", ErrHostnameAlreadyExists, cnameFrom)
+		}
+		return append(hostnames, hostname), nil
+	})
+	if err != nil {
+		return diag.Errorf("%s create: %s", ErrPropertyHostname, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", propertyID, cnameFrom))
+	return resourcePropertyHostnameRead(ctx, d, m)
+}
+
+func resourcePropertyHostnameRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyHostnameRead"))
+	logger := log.FromContext(ctx)
+	client := Client(meta.Must(m))
+
+	propertyID := str.AddPrefix(d.Get("property_id").(string), "prp_")
+	contractID := str.AddPrefix(d.Get("contract_id").(string), "ctr_")
+	groupID := str.AddPrefix(d.Get("group_id").(string), "grp_")
+	cnameFrom := d.Get("cname_from").(string)
+
+	if err := checkHostnamesManagers(m, propertyID, false); err != nil {
+		logger.Warn(err.Error())
+	}
+
+	property, err := fetchLatestProperty(ctx, client, propertyID, groupID, contractID)
+	if err != nil {
+		return diag.Errorf("%s read: %s", ErrPropertyHostname, err)
+	}
+
+	hostnames, err := fetchPropertyVersionHostnames(ctx, client, *property, property.LatestVersion)
+	if err != nil {
+		return diag.Errorf("%s read: %s", ErrPropertyHostname, err)
+	}
+
+	hostname := findHostname(hostnames, cnameFrom)
+	if hostname == nil {
+		logger.Warnf("hostname %q not found in the latest version of property %s, removing from state", cnameFrom, propertyID)
+		d.SetId("")
+		return nil
+	}
+
+	flattened := flattenHostnames([]papi.Hostname{*hostname})[0]
+	attrs := map[string]interface{}{
+		"cname_to":               hostname.CnameTo,
+		"cert_provisioning_type": hostname.CertProvisioningType,
+		"cname_type":             flattened["cname_type"],
+		"edge_hostname_id":       flattened["edge_hostname_id"],
+		"cert_status":            flattened["cert_status"],
+		"latest_version":         property.LatestVersion,
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePropertyHostnameUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyHostnameUpdate"))
+	client := Client(meta.Must(m))
+
+	if !d.HasChanges("cname_to", "cert_provisioning_type") {
+		return resourcePropertyHostnameRead(ctx, d, m)
+	}
+
+	if err := checkHostnamesManagers(m, d.Get("property_id").(string), false); err != nil {
+		return diag.Errorf("%s update: %s", ErrPropertyHostname, err)
+	}
+
+	cnameFrom := d.Get("cname_from").(string)
+	err := modifyPropertyHostnames(ctx, client, d, func(hostnames []papi.Hostname) ([]papi.Hostname, error) {
+		hostname := findHostname(hostnames, cnameFrom)
+		if hostname == nil {
+			return append(hostnames, papi.Hostname{
+				CnameType:            papi.HostnameCnameTypeEdgeHostname,
+				CnameFrom:            cnameFrom,
+				CnameTo:              d.Get("cname_to").(string),
+				CertProvisioningType: d.Get("cert_provisioning_type").(string),
+			}), nil
+		}
+		hostname.CnameTo = d.Get("cname_to").(string)
+		hostname.EdgeHostnameID = ""
+		hostname.CertProvisioningType = d.Get("cert_provisioning_type").(string)
+		return hostnames, nil
+	})
+	if err != nil {
+		d.Partial(true)
+		return diag.Errorf("%s update: %s", ErrPropertyHostname, err)
+	}
+
+	return resourcePropertyHostnameRead(ctx, d, m)
+}
+
+func resourcePropertyHostnameDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = log.NewContext(ctx, meta.Must(m).Log("PAPI", "resourcePropertyHostnameDelete"))
+	client := Client(meta.Must(m))
+
+	cnameFrom := d.Get("cname_from").(string)
+	err := modifyPropertyHostnames(ctx, client, d, func(hostnames []papi.Hostname) ([]papi.Hostname, error) {
+		if findHostname(hostnames, cnameFrom) == nil {
+			return nil, nil
+		}
+		remaining := make([]papi.Hostname, 0, len(hostnames)-1)
+		for _, h := range hostnames {
+			if !strings.EqualFold(h.CnameFrom, cnameFrom) {
+				remaining = append(remaining, h)
+			}
+		}
+		return remaining, nil
+	})
+	if err != nil {
+		return diag.Errorf("%s delete: %s", ErrPropertyHostname, err)
+	}
+
+	return nil
+}
+
+func resourcePropertyHostnameImport(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	meta := meta.Must(m)
+	logger := meta.Log("PAPI", "resourcePropertyHostnameImport")
+
+	logger.Debug("Importing property hostname")
+
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("%s import: invalid import id '%s'"+
+			"- colon separated list of contract ID, group ID, property ID and hostname has to be supplied",
+			ErrPropertyHostname, d.Id())
+	}
+
+	contractID, groupID := str.AddPrefix(parts[0], "ctr_"), str.AddPrefix(parts[1], "grp_")
+	propertyID, cnameFrom := str.AddPrefix(parts[2], "prp_"), parts[3]
+	attrs := map[string]interface{}{
+		"contract_id": contractID,
+		"group_id":    groupID,
+		"property_id": propertyID,
+		"cname_from":  cnameFrom,
+	}
+	if err := tf.SetAttrs(d, attrs); err != nil {
+		return nil, err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", propertyID, cnameFrom))
+	return []*schema.ResourceData{d}, nil
+}
+
+// modifyPropertyHostnames applies the modification to the hostnames of the latest version of the property.
+// When the latest version is or was active, a new version is created from it first. A nil result of the modification
+// means there is nothing to change. The property is locked for the whole operation.
+func modifyPropertyHostnames(ctx context.Context, client papi.PAPI, d *schema.ResourceData, modify func([]papi.Hostname) ([]papi.Hostname, error)) error {
+	logger := log.FromContext(ctx)
+
+	propertyID := str.AddPrefix(d.Get("property_id").(string), "prp_")
+	contractID := str.AddPrefix(d.Get("contract_id").(string), "ctr_")
+	groupID := str.AddPrefix(d.Get("group_id").(string), "grp_")
+
+	unlock := lockProperty(propertyID)
+	defer unlock()
+
+	property, err := fetchLatestProperty(ctx, client, propertyID, groupID, contractID)
+	if err != nil {
+		return err
+	}
+
+	hostnames, err := fetchPropertyVersionHostnames(ctx, client, *property, property.LatestVersion)
+	if err != nil {
+		return err
+	}
+
+	modified, err := modify(hostnamesToUpdate(hostnames))
+	if err != nil {
+		return err
+	}
+	if modified == nil {
+		logger.Debug("no changes to property hostnames")
+		return nil
+	}
+
+	version, err := fetchPropertyVersion(ctx, client, propertyID, groupID, contractID, property.LatestVersion)
+	if err != nil {
+		return err
+	}
+	if version.Version.StagingStatus != papi.VersionStatusInactive || version.Version.ProductionStatus != papi.VersionStatusInactive {
+		newVersion, err := createPropertyVersion(ctx, client, *property, property.LatestVersion)
+		if err != nil {
+			return err
+		}
+		property.LatestVersion = newVersion
+	}
+
+	return updatePropertyHostnames(ctx, client, *property, modified)
+}
+
+// hostnamesToUpdate returns the hostnames fetched from the property version without the read-only fields,
+// so that they can be sent back in the update request
+func hostnamesToUpdate(hostnames []papi.Hostname) []papi.Hostname {
+	result := make([]papi.Hostname, 0, len(hostnames))
+	for _, h := range hostnames {
+		hostname := papi.Hostname{
+			CnameType:            h.CnameType,
+			CnameFrom:            h.CnameFrom,
+			CnameTo:              h.CnameTo,
+			CertProvisioningType: h.CertProvisioningType,
+		}
+		if h.CnameTo == "" {
+			hostname.EdgeHostnameID = h.EdgeHostnameID
+		}
+		result = append(result, hostname)
+	}
+	return result
+}
+
+// findHostname returns the hostname with the given cname_from, which is case-insensitive, or nil if not found
+func findHostname(hostnames []papi.Hostname, cnameFrom string) *papi.Hostname {
+	for i := range hostnames {
+		if strings.EqualFold(hostnames[i].CnameFrom, cnameFrom) {
+			return &hostnames[i]
+		}
+	}
+	return nil
+}
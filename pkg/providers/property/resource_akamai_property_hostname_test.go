@@ -0,0 +1,238 @@
+package property
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/session"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// propertyHostnamesState holds the state of the property modified by the mocked PAPI calls
+type propertyHostnamesState struct {
+	mu            sync.Mutex
+	latestVersion int
+	active        bool
+	hostnames     []papi.Hostname
+}
+
+func (s *propertyHostnamesState) getHostnames() []papi.Hostname {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]papi.Hostname{}, s.hostnames...)
+}
+
+func (s *propertyHostnamesState) activate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = true
+}
+
+// mockPropertyHostnames sets up the PAPI calls used by akamai_property_hostname resource, operating on the given state
+func mockPropertyHostnames(client *papi.Mock, state *propertyHostnamesState) {
+	client.OnGetProperty(AnyCTX, papi.GetPropertyRequest{PropertyID: "prp_1", ContractID: "ctr_1", GroupID: "grp_1"},
+		func(context.Context, papi.GetPropertyRequest) (*papi.GetPropertyResponse, error) {
+			state.mu.Lock()
+			defer state.mu.Unlock()
+			property := papi.Property{PropertyID: "prp_1", ContractID: "ctr_1", GroupID: "grp_1", PropertyName: "test", LatestVersion: state.latestVersion}
+			if state.active {
+				property.StagingVersion = ptr.To(state.latestVersion)
+			}
+			return &papi.GetPropertyResponse{Property: &property}, nil
+		}).Maybe()
+
+	client.OnGetPropertyVersionHostnames(AnyCTX, mock.Anything,
+		func(_ context.Context, req papi.GetPropertyVersionHostnamesRequest) (*papi.GetPropertyVersionHostnamesResponse, error) {
+			state.mu.Lock()
+			defer state.mu.Unlock()
+			items := make([]papi.Hostname, 0, len(state.hostnames))
+			for _, h := range state.hostnames {
+				h.CertStatus = papi.CertStatusItem{ValidationCname: papi.ValidationCname{Hostname: "_acme-challenge." + h.CnameFrom, Target: "dcv.akamai.com"}}
+				items = append(items, h)
+			}
+			return &papi.GetPropertyVersionHostnamesResponse{PropertyID: req.PropertyID, PropertyVersion: req.PropertyVersion,
+				Hostnames: papi.HostnameResponseItems{Items: items}}, nil
+		}).Maybe()
+
+	getVersion := client.On("GetPropertyVersion", AnyCTX, mock.Anything).Maybe()
+	getVersion.Run(func(args mock.Arguments) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		status := papi.VersionStatusInactive
+		if state.active {
+			status = papi.VersionStatusActive
+		}
+		getVersion.Return(&papi.GetPropertyVersionsResponse{Version: papi.PropertyVersionGetItem{
+			PropertyVersion:  args.Get(1).(papi.GetPropertyVersionRequest).PropertyVersion,
+			StagingStatus:    status,
+			ProductionStatus: papi.VersionStatusInactive,
+		}}, nil)
+	})
+
+	createVersion := client.On("CreatePropertyVersion", AnyCTX, mock.Anything).Maybe()
+	createVersion.Run(func(mock.Arguments) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		state.latestVersion++
+		state.active = false
+		createVersion.Return(&papi.CreatePropertyVersionResponse{PropertyVersion: state.latestVersion}, nil)
+	})
+
+	client.On("UpdatePropertyVersionHostnames", AnyCTX, mock.Anything).Run(func(args mock.Arguments) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		req := args.Get(1).(papi.UpdatePropertyVersionHostnamesRequest)
+		if req.PropertyVersion != state.latestVersion || state.active {
+			panic("hostnames updated in not editable property version")
+		}
+		state.hostnames = append([]papi.Hostname{}, req.Hostnames...)
+	}).Return(&papi.UpdatePropertyVersionHostnamesResponse{}, nil).Maybe()
+}
+
+func TestResourcePropertyHostname(t *testing.T) {
+	existing := papi.Hostname{
+		CnameType:            papi.HostnameCnameTypeEdgeHostname,
+		CnameFrom:            "existing.example.com",
+		CnameTo:              "existing.example.com.edgesuite.net",
+		CertProvisioningType: "CPS_MANAGED",
+	}
+	www := papi.Hostname{
+		CnameType:            papi.HostnameCnameTypeEdgeHostname,
+		CnameFrom:            "www.example.com",
+		CnameTo:              "www.example.com.edgesuite.net",
+		CertProvisioningType: "CPS_MANAGED",
+	}
+	checkHostnames := func(state *propertyHostnamesState, expected ...papi.Hostname) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			assert.Equal(t, expected, state.getHostnames())
+			return nil
+		}
+	}
+
+	t.Run("create, update in a new version and delete hostname", func(t *testing.T) {
+		client := &papi.Mock{}
+		state := &propertyHostnamesState{latestVersion: 1, hostnames: []papi.Hostname{existing}}
+		mockPropertyHostnames(client, state)
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				CheckDestroy:             checkHostnames(state, existing),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResPropertyHostname/create.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_hostname.www", "id", "prp_1:www.example.com"),
+							resource.TestCheckResourceAttr("akamai_property_hostname.www", "cname_type", "EDGE_HOSTNAME"),
+							resource.TestCheckResourceAttr("akamai_property_hostname.www", "latest_version", "1"),
+							resource.TestCheckResourceAttr("akamai_property_hostname.www", "cert_status.0.hostname", "_acme-challenge.www.example.com"),
+							checkHostnames(state, existing, www),
+						),
+					},
+					{
+						PreConfig: state.activate,
+						Config:    testutils.LoadFixtureString(t, "testdata/TestResPropertyHostname/update.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_hostname.www", "cname_to", "www.example.com.edgekey.net"),
+							resource.TestCheckResourceAttr("akamai_property_hostname.www", "cert_provisioning_type", "DEFAULT"),
+							resource.TestCheckResourceAttr("akamai_property_hostname.www", "latest_version", "2"),
+							checkHostnames(state, existing, papi.Hostname{
+								CnameType:            papi.HostnameCnameTypeEdgeHostname,
+								CnameFrom:            "www.example.com",
+								CnameTo:              "www.example.com.edgekey.net",
+								CertProvisioningType: "DEFAULT",
+							}),
+						),
+					},
+					{
+						ImportState:       true,
+						ImportStateId:     "ctr_1:grp_1:prp_1:www.example.com",
+						ResourceName:      "akamai_property_hostname.www",
+						ImportStateVerify: true,
+					},
+					{
+						ImportState:       true,
+						ImportStateId:     "1:1:1:www.example.com",
+						ResourceName:      "akamai_property_hostname.www",
+						ImportStateVerify: true,
+					},
+				},
+			})
+		})
+		client.AssertNumberOfCalls(t, "CreatePropertyVersion", 1)
+	})
+
+	t.Run("hostname already exists", func(t *testing.T) {
+		client := &papi.Mock{}
+		state := &propertyHostnamesState{latestVersion: 1, hostnames: []papi.Hostname{www}}
+		mockPropertyHostnames(client, state)
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, "testdata/TestResPropertyHostname/create.tf"),
+						ExpectError: regexp.MustCompile("property hostname create: hostname already exists in the property version: www.example.com"),
+					},
+				},
+			})
+		})
+		client.AssertNotCalled(t, "UpdatePropertyVersionHostnames", mock.Anything, mock.Anything)
+	})
+
+	t.Run("two hostnames of the same property in one apply", func(t *testing.T) {
+		client := &papi.Mock{}
+		state := &propertyHostnamesState{latestVersion: 1, active: true, hostnames: []papi.Hostname{existing}}
+		mockPropertyHostnames(client, state)
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				CheckDestroy:             checkHostnames(state, existing),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, "testdata/TestResPropertyHostname/two_hostnames.tf"),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_hostname.www", "id", "prp_1:www.example.com"),
+							resource.TestCheckResourceAttr("akamai_property_hostname.api", "id", "prp_1:api.example.com"),
+							func(*terraform.State) error {
+								hostnames := state.getHostnames()
+								assert.Len(t, hostnames, 3)
+								assert.Equal(t, existing, hostnames[0])
+								return nil
+							},
+						),
+					},
+				},
+			})
+		})
+		// only the first modification creates a new version, the other one is applied to it
+		client.AssertNumberOfCalls(t, "CreatePropertyVersion", 1)
+	})
+}
+
+func TestCheckHostnamesManagers(t *testing.T) {
+	newMeta := func(operationID string) meta.Meta {
+		m, err := meta.New(session.Must(session.New()), hclog.NewNullLogger(), operationID)
+		require.NoError(t, err)
+		return m
+	}
+	m := newMeta("TestCheckHostnamesManagers")
+
+	assert.NoError(t, checkHostnamesManagers(m, "prp_1", false))
+	assert.NoError(t, checkHostnamesManagers(m, "1", false))
+	assert.NoError(t, checkHostnamesManagers(m, "prp_2", true))
+	assert.NoError(t, checkHostnamesManagers(newMeta("TestCheckHostnamesManagers-other"), "prp_1", true))
+
+	assert.ErrorIs(t, checkHostnamesManagers(m, "prp_1", true), ErrHostnamesManagedByProperty)
+	assert.ErrorIs(t, checkHostnamesManagers(m, "2", false), ErrHostnamesManagedByProperty)
+}
@@ -31,6 +31,7 @@ func resourcePropertyInclude() *schema.Resource {
 			StateContext: resourcePropertyIncludeImport,
 		},
 		CustomizeDiff: customdiff.All(
+			validateRulesCustomDiff,
 			propertyIncludeRulesCustomDiff,
 			setIncludeVersionsComputedOnRulesChange,
 		),
@@ -85,6 +86,11 @@ func resourcePropertyInclude() *schema.Resource {
 				DiffSuppressFunc: tf.DiffSuppressAny(suppressDefaultRules, diffSuppressPropertyRules),
 				StateFunc:        rulesStateFunc,
 			},
+			"skip_rules_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Disables the plan time validation of rules against the schema of the rule format bundled with the provider, e.g. when the bundled schema does not match the one of the API",
+			},
 			"rule_errors": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -337,6 +343,11 @@ func resourcePropertyIncludeUpdate(ctx context.Context, rd *schema.ResourceData,
 
 	logger.Debug("Updating property include")
 
+	if !rd.HasChanges("rules", "rule_format") {
+		logger.Debug("No changes of the include rules")
+		return resourcePropertyIncludeRead(ctx, rd, m)
+	}
+
 	includeID := rd.Id()
 
 	contractID, err := tf.GetStringValue("contract_id", rd)
@@ -18,6 +18,7 @@ import (
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/timeouts"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/logger"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/tracing"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -102,6 +103,55 @@ func resourcePropertyIncludeActivation() *schema.Resource {
 				Description: "Provides an audit record when activating on a production network",
 				Elem:        complianceRecordSchema,
 			},
+			"activate_parents": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "When set, the parent properties which reference the include are activated on the same network, " +
+					"once the include version is active there. Unless listed in 'parent_versions', the version of a parent already " +
+					"active on the network is activated, and parents without such version are skipped. On production network, " +
+					"only versions which were active on staging network are activated",
+			},
+			"parent_versions": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The versions of the parent properties to activate when 'activate_parents' is set, keyed by property ID",
+			},
+			"parent_activations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The activations of the parent properties performed along with the include activation",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The parent property ID",
+						},
+						"version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The activated version of the parent property",
+						},
+						"activation_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the parent property activation",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the parent property activation",
+						},
+						"previous_version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version of the parent property active on the network before the activation, zero if none",
+						},
+					},
+				},
+			},
 			"timeouts": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -165,6 +215,13 @@ func resourcePropertyIncludeActivationCreate(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
+	if diags := activateIncludeParents(ctx, d, client); diags.HasError() {
+		// nothing is stored in the state, so that the next apply reuses the include activation
+		// and retries the activations of the parents
+		d.SetId("")
+		return diags
+	}
+
 	return resourcePropertyIncludeActivationRead(ctx, d, m)
 }
 
@@ -242,6 +299,11 @@ func resourcePropertyIncludeActivationUpdate(ctx context.Context, d *schema.Reso
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	if diags := activateIncludeParents(ctx, d, client); diags.HasError() {
+		d.Partial(true)
+		return diags
+	}
 	return resourcePropertyIncludeActivationRead(ctx, d, m)
 }
 
@@ -302,8 +364,9 @@ func resourcePropertyIncludeActivationImport(_ context.Context, d *schema.Resour
 	attrs["include_id"] = rd.includeID
 	attrs["network"] = rd.network
 
-	// it is impossible to fetch auto_acknowledge_rule_warnings from server
+	// it is impossible to fetch auto_acknowledge_rule_warnings and activate_parents from server
 	attrs["auto_acknowledge_rule_warnings"] = false
+	attrs["activate_parents"] = false
 
 	if err := tf.SetAttrs(d, attrs); err != nil {
 		return nil, err
@@ -352,6 +415,139 @@ func resourcePropertyIncludeActivationUpsert(ctx context.Context, d *schema.Reso
 	return nil
 }
 
+// activateIncludeParents activates the parent properties referencing the include, when 'activate_parents' is set.
+// The include version has to be active on the network first.
+func activateIncludeParents(ctx context.Context, d *schema.ResourceData, client papi.PAPI) diag.Diagnostics {
+	logger := logger.Get("activateIncludeParents")
+
+	var activations []*batchActivation
+	if d.Get("activate_parents").(bool) {
+		activationResourceData := propertyIncludeActivationData{}
+		if err := activationResourceData.populateFromResource(d); err != nil {
+			return diag.FromErr(err)
+		}
+
+		isActive, err := isLatestActiveExpectedActivated(ctx, client, activationResourceData)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !isActive {
+			return diag.Errorf("%s: include %s version %d is not active on %s network", ErrIncludeParentsActivation,
+				activationResourceData.includeID, activationResourceData.version, activationResourceData.network)
+		}
+
+		activations, err = findIncludeParentsToActivate(ctx, client, activationResourceData)
+		if err != nil {
+			return diag.Errorf("%s: %s", ErrIncludeParentsActivation, err)
+		}
+
+		logger.Debugf("activating %d parent properties", len(activations))
+		request := batchActivationRequest{
+			activationType:          papi.ActivationTypeActivate,
+			network:                 papi.ActivationNetwork(activationResourceData.network),
+			notify:                  activationResourceData.notifyEmails,
+			note:                    activationResourceData.note,
+			acknowledgeRuleWarnings: activationResourceData.acknowledgement,
+			complianceRecord:        activationResourceData.complianceRecord,
+		}
+		if diags := activateBatch(ctx, client, request, false, activations); diags.HasError() {
+			return diags
+		}
+	}
+
+	if err := d.Set("parent_activations", flattenBatchActivations(activations)); err != nil {
+		return diag.FromErr(fmt.Errorf("%w: %s", tf.ErrValueSet, err.Error()))
+	}
+	return nil
+}
+
+// findIncludeParentsToActivate returns the versions of the parent properties of the include to activate on the network.
+// These are the versions listed in 'parent_versions' or, for the other parents, the versions already active on the network.
+// Parents which active version no longer references the include are skipped. On production network, versions which
+// were never active on staging network are rejected.
+func findIncludeParentsToActivate(ctx context.Context, client papi.PAPI, activationResourceData propertyIncludeActivationData) ([]*batchActivation, error) {
+	logger := logger.Get("findIncludeParentsToActivate")
+
+	parents, err := client.ListIncludeParents(ctx, papi.ListIncludeParentsRequest{
+		ContractID: activationResourceData.contractID,
+		GroupID:    activationResourceData.groupID,
+		IncludeID:  activationResourceData.includeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	isProduction := activationResourceData.network == string(papi.ActivationNetworkProduction)
+	unknownParents := make(map[string]struct{}, len(activationResourceData.parentVersions))
+	for propertyID := range activationResourceData.parentVersions {
+		unknownParents[propertyID] = struct{}{}
+	}
+
+	var activations []*batchActivation
+	for _, parent := range parents.Properties.Items {
+		delete(unknownParents, parent.PropertyID)
+		version, isListed := activationResourceData.parentVersions[parent.PropertyID]
+		if !isListed {
+			property, err := fetchLatestProperty(ctx, client, parent.PropertyID, parent.GroupID, parent.ContractID)
+			if err != nil {
+				return nil, err
+			}
+			activeVersion := property.StagingVersion
+			if isProduction {
+				activeVersion = property.ProductionVersion
+			}
+			if activeVersion == nil {
+				logger.Debugf("parent property %s has no version active on %s network, skipping", parent.PropertyID, activationResourceData.network)
+				continue
+			}
+			version = *activeVersion
+		}
+
+		isReferenced, err := isIncPresentInReferencedIncludes(ctx, client, papi.ListReferencedIncludesRequest{
+			PropertyID:      parent.PropertyID,
+			PropertyVersion: version,
+			ContractID:      parent.ContractID,
+			GroupID:         parent.GroupID,
+		}, activationResourceData.includeID)
+		if err != nil {
+			return nil, err
+		}
+		if !isReferenced {
+			if isListed {
+				return nil, fmt.Errorf("version %d of parent property %s does not reference the include", version, parent.PropertyID)
+			}
+			logger.Debugf("active version %d of parent property %s does not reference the include, skipping", version, parent.PropertyID)
+			continue
+		}
+
+		if isProduction {
+			propertyVersion, err := client.GetPropertyVersion(ctx, papi.GetPropertyVersionRequest{
+				PropertyID:      parent.PropertyID,
+				PropertyVersion: version,
+				ContractID:      parent.ContractID,
+				GroupID:         parent.GroupID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if propertyVersion.Version.StagingStatus == papi.VersionStatusInactive {
+				return nil, fmt.Errorf("version %d of parent property %s was never active on %s network", version, parent.PropertyID, papi.ActivationNetworkStaging)
+			}
+		}
+		activations = append(activations, &batchActivation{propertyID: parent.PropertyID, version: version})
+	}
+
+	if len(unknownParents) > 0 {
+		propertyIDs := make([]string, 0, len(unknownParents))
+		for propertyID := range unknownParents {
+			propertyIDs = append(propertyIDs, propertyID)
+		}
+		sort.Strings(propertyIDs)
+		return nil, fmt.Errorf("properties listed in 'parent_versions' are not parents of the include: %s", strings.Join(propertyIDs, ", "))
+	}
+	return activations, nil
+}
+
 type propertyIncludeActivationData struct {
 	includeID        string
 	contractID       string
@@ -362,6 +558,7 @@ type propertyIncludeActivationData struct {
 	note             string
 	acknowledgement  bool
 	complianceRecord []any
+	parentVersions   map[string]int
 }
 
 func (p *propertyIncludeActivationData) populateFromResource(d *schema.ResourceData) error {
@@ -406,6 +603,14 @@ func (p *propertyIncludeActivationData) populateFromResource(d *schema.ResourceD
 	if err != nil && !errors.Is(err, tf.ErrNotFound) {
 		return err
 	}
+	parentVersions, err := tf.GetMapValue("parent_versions", d)
+	if err != nil && !errors.Is(err, tf.ErrNotFound) {
+		return err
+	}
+	p.parentVersions = make(map[string]int, len(parentVersions))
+	for propertyID, version := range parentVersions {
+		p.parentVersions[str.AddPrefix(propertyID, "prp_")] = version.(int)
+	}
 	return nil
 }
 
@@ -639,6 +844,9 @@ func waitForActivationCondition(ctx context.Context,
 	includeID, activationID string,
 	cond func(papi.ActivationStatus) bool,
 ) (*papi.GetIncludeActivationResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "property.waitForIncludeActivation")
+	defer span.End()
+
 	for {
 		activation, err := client.GetIncludeActivation(ctx, papi.GetIncludeActivationRequest{
 			IncludeID:    includeID,
@@ -5,14 +5,18 @@ import (
 	"math/rand"
 	"regexp"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/ptr"
 	"github.com/akamai/terraform-provider-akamai/v6/pkg/common/testutils"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -873,3 +877,303 @@ func TestReadTimeoutFromEnvOrDefault(t *testing.T) {
 		})
 	}
 }
+
+// includeActivationsState holds the activations of the include created by the mocked PAPI calls
+type includeActivationsState struct {
+	mu          sync.Mutex
+	activations []papi.IncludeActivation
+}
+
+// mockIncludeActivations sets up the PAPI calls used to activate and deactivate the include, operating on the given state
+func mockIncludeActivations(client *papi.Mock, state *includeActivationsState) {
+	addActivation := func(activationType papi.ActivationType, req papi.ActivateOrDeactivateIncludeRequest) string {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		activation := papi.IncludeActivation{
+			ActivationID:   fmt.Sprintf("atv_inc_%d", len(state.activations)+1),
+			Network:        req.Network,
+			ActivationType: activationType,
+			Status:         papi.ActivationStatusActive,
+			SubmitDate:     fmt.Sprintf("2024-01-01T00:00:%02dZ", len(state.activations)+1),
+			UpdateDate:     fmt.Sprintf("2024-01-01T00:00:%02dZ", len(state.activations)+1),
+			Note:           req.Note,
+			NotifyEmails:   req.NotifyEmails,
+			IncludeID:      req.IncludeID,
+			IncludeVersion: req.Version,
+		}
+		state.activations = append(state.activations, activation)
+		return activation.ActivationID
+	}
+
+	listActivations := client.On("ListIncludeActivations", mock.Anything, mock.Anything).Maybe()
+	listActivations.Run(func(mock.Arguments) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		listActivations.Return(&papi.ListIncludeActivationsResponse{
+			ContractID:  contractID,
+			GroupID:     groupID,
+			Activations: papi.IncludeActivationsRes{Items: append([]papi.IncludeActivation(nil), state.activations...)},
+		}, nil)
+	})
+
+	getActivation := client.On("GetIncludeActivation", mock.Anything, mock.Anything).Maybe()
+	getActivation.Run(func(args mock.Arguments) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		req := args.Get(1).(papi.GetIncludeActivationRequest)
+		for _, activation := range state.activations {
+			if activation.ActivationID == req.ActivationID {
+				getActivation.Return(&papi.GetIncludeActivationResponse{
+					ContractID: contractID,
+					GroupID:    groupID,
+					Activation: activation,
+				}, nil)
+				return
+			}
+		}
+		getActivation.Return(nil, fmt.Errorf("%w: %s", papi.ErrNotFound, papi.ErrGetIncludeActivation))
+	})
+
+	activate := client.On("ActivateInclude", mock.Anything, mock.Anything).Maybe()
+	activate.Run(func(args mock.Arguments) {
+		req := args.Get(1).(papi.ActivateIncludeRequest)
+		activationID := addActivation(papi.ActivationTypeActivate, papi.ActivateOrDeactivateIncludeRequest(req))
+		activate.Return(&papi.ActivationIncludeResponse{ActivationID: activationID}, nil)
+	})
+
+	deactivate := client.On("DeactivateInclude", mock.Anything, mock.Anything).Maybe()
+	deactivate.Run(func(args mock.Arguments) {
+		req := args.Get(1).(papi.DeactivateIncludeRequest)
+		activationID := addActivation(papi.ActivationTypeDeactivate, papi.ActivateOrDeactivateIncludeRequest(req))
+		deactivate.Return(&papi.DeactivationIncludeResponse{ActivationID: activationID}, nil)
+	})
+}
+
+func TestResourcePropertyIncludeActivationWithParents(t *testing.T) {
+	activationPollInterval = time.Microsecond
+	getActivationInterval = time.Microsecond
+
+	// includeParent describes the versions of a parent property of the include
+	type includeParent struct {
+		// listed is set for the parents listed in 'parent_versions', which are not fetched
+		listed            bool
+		stagingVersion    *int
+		productionVersion *int
+		// referencing holds the versions which reference the include
+		referencing map[int]bool
+	}
+
+	// mockIncludeParents sets up the parents of the include, 'prp_1' and 'prp_2'. The parents missing in the map are not expected to be fetched
+	mockIncludeParents := func(client *papi.Mock, parents map[string]includeParent) {
+		client.On("ListIncludeParents", mock.Anything, papi.ListIncludeParentsRequest{
+			ContractID: contractID,
+			GroupID:    groupID,
+			IncludeID:  includeID,
+		}).Return(&papi.ListIncludeParentsResponse{Properties: papi.ParentPropertyItems{Items: []papi.ParentProperty{
+			{PropertyID: "prp_1", PropertyName: "parent-1", ContractID: contractID, GroupID: groupID},
+			{PropertyID: "prp_2", PropertyName: "parent-2", ContractID: contractID, GroupID: groupID},
+		}}}, nil)
+
+		for propertyID, parent := range parents {
+			if parent.listed {
+				continue
+			}
+			client.On("GetProperty", mock.Anything, papi.GetPropertyRequest{
+				PropertyID: propertyID,
+				ContractID: contractID,
+				GroupID:    groupID,
+			}).Return(&papi.GetPropertyResponse{Property: &papi.Property{
+				PropertyID:        propertyID,
+				ContractID:        contractID,
+				GroupID:           groupID,
+				LatestVersion:     5,
+				StagingVersion:    parent.stagingVersion,
+				ProductionVersion: parent.productionVersion,
+			}}, nil)
+		}
+		references := client.On("ListReferencedIncludes", mock.Anything, mock.Anything).Maybe()
+		references.Run(func(args mock.Arguments) {
+			req := args.Get(1).(papi.ListReferencedIncludesRequest)
+			include := papi.Include{IncludeID: "inc_other"}
+			if parents[req.PropertyID].referencing[req.PropertyVersion] {
+				include.IncludeID = includeID
+			}
+			references.Return(&papi.ListReferencedIncludesResponse{Includes: papi.IncludeItems{Items: []papi.Include{include}}}, nil)
+		})
+	}
+
+	t.Run("include activated along with its parents", func(t *testing.T) {
+		client := new(papi.Mock)
+		includeState := &includeActivationsState{}
+		propertyState := newPropertyActivationsState()
+		propertyState.addActivation("prp_1", papi.ActivationTypeActivate, 1)
+		mockIncludeActivations(client, includeState)
+		mockPropertyActivations(client, propertyState)
+		mockIncludeParents(client, map[string]includeParent{
+			"prp_1": {listed: true, referencing: map[int]bool{2: true}},
+			"prp_2": {stagingVersion: ptr.To(5), referencing: map[int]bool{4: true}},
+		})
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, fmt.Sprintf("%s/activate_parents.tf", testDir)),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "version", "3"),
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "activate_parents", "true"),
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "parent_activations.#", "1"),
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "parent_activations.0.property_id", "prp_1"),
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "parent_activations.0.version", "2"),
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "parent_activations.0.previous_version", "1"),
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "parent_activations.0.status", "ACTIVE"),
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "parent_activations.0.activation_id", "atv_prp_1_2"),
+						),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+
+		// the parents stay active after the include is deactivated
+		assert.Equal(t, 2, propertyState.activeVersion("prp_1"))
+		assert.Equal(t, 0, propertyState.count("prp_2"))
+		require.Len(t, includeState.activations, 2)
+		assert.Equal(t, papi.ActivationTypeDeactivate, includeState.activations[1].ActivationType)
+	})
+
+	t.Run("failed parent activation is retried by the next apply", func(t *testing.T) {
+		client := new(papi.Mock)
+		includeState := &includeActivationsState{}
+		propertyState := newPropertyActivationsState()
+		propertyState.failing["prp_1:2"] = true
+		mockIncludeActivations(client, includeState)
+		mockPropertyActivations(client, propertyState)
+		mockIncludeParents(client, map[string]includeParent{
+			"prp_1": {listed: true, referencing: map[int]bool{2: true}},
+			"prp_2": {stagingVersion: ptr.To(5), referencing: map[int]bool{4: true}},
+		})
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, fmt.Sprintf("%s/activate_parents.tf", testDir)),
+						ExpectError: regexp.MustCompile(`property prp_1 version 2: activation request failed in downstream system`),
+					},
+					{
+						PreConfig: func() {
+							propertyState.mu.Lock()
+							defer propertyState.mu.Unlock()
+							delete(propertyState.failing, "prp_1:2")
+						},
+						Config: testutils.LoadFixtureString(t, fmt.Sprintf("%s/activate_parents.tf", testDir)),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "parent_activations.#", "1"),
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "parent_activations.0.status", "ACTIVE"),
+							// the include activation of the first apply is reused
+							func(*terraform.State) error {
+								assert.Len(t, includeState.activations, 1)
+								return nil
+							},
+						),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+		assert.Equal(t, 2, propertyState.activeVersion("prp_1"))
+	})
+
+	t.Run("versions of parents active on the network activated by default", func(t *testing.T) {
+		client := new(papi.Mock)
+		includeState := &includeActivationsState{}
+		propertyState := newPropertyActivationsState()
+		propertyState.addActivation("prp_1", papi.ActivationTypeActivate, 1)
+		mockIncludeActivations(client, includeState)
+		mockPropertyActivations(client, propertyState)
+		mockIncludeParents(client, map[string]includeParent{
+			"prp_1": {stagingVersion: ptr.To(1), referencing: map[int]bool{1: true, 2: true}},
+			"prp_2": {productionVersion: ptr.To(3), referencing: map[int]bool{3: true}},
+		})
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config: testutils.LoadFixtureString(t, fmt.Sprintf("%s/activate_parents_active_versions.tf", testDir)),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "parent_activations.#", "1"),
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "parent_activations.0.property_id", "prp_1"),
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "parent_activations.0.version", "1"),
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "parent_activations.0.previous_version", "1"),
+							resource.TestCheckResourceAttr("akamai_property_include_activation.activation", "parent_activations.0.status", "ACTIVE"),
+						),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+
+		// the latest version of 'prp_1' is not activated
+		assert.Equal(t, 1, propertyState.activeVersion("prp_1"))
+		assert.Equal(t, 0, propertyState.count("prp_2"))
+	})
+
+	t.Run("production version never active on staging is rejected", func(t *testing.T) {
+		client := new(papi.Mock)
+		includeState := &includeActivationsState{}
+		mockIncludeActivations(client, includeState)
+		mockIncludeParents(client, map[string]includeParent{
+			"prp_1": {listed: true, referencing: map[int]bool{2: true}},
+		})
+		client.On("GetPropertyVersion", mock.Anything, papi.GetPropertyVersionRequest{
+			PropertyID:      "prp_1",
+			PropertyVersion: 2,
+			ContractID:      contractID,
+			GroupID:         groupID,
+		}).Return(&papi.GetPropertyVersionsResponse{Version: papi.PropertyVersionGetItem{
+			PropertyVersion:  2,
+			StagingStatus:    papi.VersionStatusInactive,
+			ProductionStatus: papi.VersionStatusInactive,
+		}}, nil)
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, fmt.Sprintf("%s/activate_parents_production.tf", testDir)),
+						ExpectError: regexp.MustCompile(`version 2 of parent property prp_1 was never active on STAGING network`),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+
+	t.Run("parent versions not referencing the include are rejected", func(t *testing.T) {
+		client := new(papi.Mock)
+		includeState := &includeActivationsState{}
+		mockIncludeActivations(client, includeState)
+		mockIncludeParents(client, map[string]includeParent{
+			"prp_1": {listed: true, referencing: map[int]bool{1: true}},
+		})
+
+		useClient(client, nil, func() {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testutils.NewProtoV6ProviderFactory(NewSubprovider()),
+				Steps: []resource.TestStep{
+					{
+						Config:      testutils.LoadFixtureString(t, fmt.Sprintf("%s/activate_parents.tf", testDir)),
+						ExpectError: regexp.MustCompile(`version 2 of parent property prp_1 does not reference the include`),
+					},
+				},
+			})
+		})
+		client.AssertExpectations(t)
+	})
+}
@@ -958,6 +958,15 @@ func TestResourcePropertyInclude(t *testing.T) {
 					Config:      testutils.LoadFixtureString(t, "%s/rule_format_blank.tf", workdir),
 					ExpectError: regexp.MustCompile(`provided value cannot be blank`),
 				},
+				{
+					Config:      testutils.LoadFixtureString(t, "%s/rules_not_matching_rule_format.tf", workdir),
+					ExpectError: regexp.MustCompile(`rules do not match rule format v2024-02-12:\s+#/rules/behaviors/0/options/behavior: expected behavior to be one of`),
+				},
+				{
+					Config:             testutils.LoadFixtureString(t, "%s/rules_not_matching_rule_format_skip_validation.tf", workdir),
+					PlanOnly:           true,
+					ExpectNonEmptyPlan: true,
+				},
 			},
 		},
 	}
@@ -1,6 +1,7 @@
 package property
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -689,8 +691,21 @@ func TestResProperty(t *testing.T) {
 				},
 				{
 					Config: testutils.LoadFixtureString(t, "%s/step1.tf", FixturePath),
-					Check: checkAttrs("prp_0", "to.test.domain", "1", "0", "0", "ehn_123",
-						`{"rules":{"behaviors":[{"name":"caching","options":{"behavior":"MAX_AGE","mustRevalidate":false,"ttl":"13d"}}],"name":"default","options":{}}}`),
+					ConfigPlanChecks: resource.ConfigPlanChecks{
+						PreApply: []plancheck.PlanCheck{
+							expectPlannedRulesDiff{address: "akamai_property.test", expected: `~ /default: behavior "caching" option "ttl": "12d" -> "13d"`},
+						},
+					},
+					Check: resource.ComposeAggregateTestCheckFunc(
+						checkAttrs("prp_0", "to.test.domain", "1", "0", "0", "ehn_123",
+							`{"rules":{"behaviors":[{"name":"caching","options":{"behavior":"MAX_AGE","mustRevalidate":false,"ttl":"13d"}}],"name":"default","options":{}}}`),
+						// the planned rules_diff is kept in the state returned by the update
+						resource.TestCheckResourceAttr("akamai_property.test", "rules_diff", `~ /default: behavior "caching" option "ttl": "12d" -> "13d"`),
+					),
+				},
+				{
+					RefreshState: true,
+					Check:        resource.TestCheckResourceAttr("akamai_property.test", "rules_diff", ""),
 				},
 			}
 		},
@@ -915,6 +930,7 @@ func TestResProperty(t *testing.T) {
 		t.Run("Schema Configuration Error: invalid json rules", assertConfigError(t, "invalid json rules", `rules are not valid JSON`))
 		t.Run("Schema Configuration Error: invalid name given", assertConfigError(t, "invalid name given", `a name must only contain letters, numbers, and these characters: . _ -`))
 		t.Run("Schema Configuration Error: name given too long", assertConfigError(t, "name given too long", `a name must be longer than 0 characters and shorter than 86 characters`))
+		t.Run("Schema Configuration Error: rules not matching rule format", assertConfigError(t, "rules not matching rule format", `#/rules/children/0/criteria/0/options/values: expected array, got string`))
 
 		// Test Lifecycle
 
@@ -1947,3 +1963,27 @@ func TestValidatePropertyName(t *testing.T) {
 		})
 	}
 }
+
+// expectPlannedRulesDiff is the plan check asserting the planned value of rules_diff of the property
+type expectPlannedRulesDiff struct {
+	address  string
+	expected string
+}
+
+func (e expectPlannedRulesDiff) CheckPlan(_ context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Address != e.address {
+			continue
+		}
+		after, ok := rc.Change.After.(map[string]any)
+		if !ok {
+			resp.Error = fmt.Errorf("%s - planned values not found", e.address)
+			return
+		}
+		if after["rules_diff"] != e.expected {
+			resp.Error = fmt.Errorf("%s - expected rules_diff %q, got %q", e.address, e.expected, after["rules_diff"])
+		}
+		return
+	}
+	resp.Error = fmt.Errorf("%s - resource not found in plan", e.address)
+}
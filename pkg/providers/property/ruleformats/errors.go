@@ -35,6 +35,10 @@ var (
 	ErrOnlyForDefault = errors.New("cannot be used outside 'default' rule")
 	// ErrNotForDefault is used when some fields cannot be used in "default" rules in data source
 	ErrNotForDefault = errors.New("cannot be used in 'default' rule")
+	// ErrUnsupportedRuleFormat is used when the rule format is not bundled with the provider
+	ErrUnsupportedRuleFormat = errors.New("unsupported rule format")
+	// ErrInvalidRules is used when the rules do not match the rule format
+	ErrInvalidRules = errors.New("rules do not match the rule format")
 )
 
 // Error returns NotFoundError as a string.
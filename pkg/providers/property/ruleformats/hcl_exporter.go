@@ -0,0 +1,240 @@
+package ruleformats
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// rulesBuilderDataSource is the type of the data source generated for every rule of the rule tree
+const rulesBuilderDataSource = "akamai_property_rules_builder"
+
+type (
+	// hclExporter converts the rule tree into akamai_property_rules_builder data sources, in the reverse direction to RulesBuilder
+	hclExporter struct {
+		ruleFormat    RuleFormat
+		behaviors     map[string]string
+		criteria      map[string]string
+		shouldFlatten func(string) bool
+		body          *hclwrite.Body
+		labels        map[string]bool
+	}
+)
+
+var invalidLabelCharsRegexp = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// ExportHCL returns akamai_property_rules_builder data sources which build the given rule tree in the given rule format,
+// e.g. 'v2024-02-12' or 'rules_v2024_02_12'. Every rule of the tree is exported as a separate data source,
+// which references the data sources of its children. The data source of the top-level rule is the first one.
+//
+// The rules have to match the rule format, otherwise ErrInvalidRules is returned with the violations found.
+func ExportHCL(ruleFormat string, rules papi.Rules) ([]byte, error) {
+	rf, ok := schemasRegistry.ruleFormat(ruleFormat)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedRuleFormat, ruleFormat)
+	}
+
+	ruleErrors, err := ValidateRules(ruleFormat, rules)
+	if err != nil {
+		return nil, err
+	}
+	if len(ruleErrors) > 0 {
+		messages := make([]string, 0, len(ruleErrors))
+		for _, e := range ruleErrors {
+			messages = append(messages, e.Error())
+		}
+		return nil, fmt.Errorf("%w:\n%s", ErrInvalidRules, strings.Join(messages, "\n"))
+	}
+
+	file := hclwrite.NewEmptyFile()
+	e := hclExporter{
+		ruleFormat:    rf,
+		behaviors:     schemaKeysByJSONName(rf.behaviorsSchemas, rf.nameMappings),
+		criteria:      schemaKeysByJSONName(rf.criteriaSchemas, rf.nameMappings),
+		shouldFlatten: ShouldFlattenFunc(rf.version),
+		body:          file.Body(),
+		labels:        map[string]bool{},
+	}
+	e.exportRule(rules)
+	return file.Bytes(), nil
+}
+
+// exportRule appends the data sources of the rule and its descendants and returns the label of the rule data source
+func (e *hclExporter) exportRule(rule papi.Rules) string {
+	label := e.label(rule.Name)
+	if len(e.body.Blocks()) > 0 {
+		e.body.AppendNewline()
+	}
+	dataSource := e.body.AppendNewBlock("data", []string{rulesBuilderDataSource, label})
+	ruleBody := dataSource.Body().AppendNewBlock(e.ruleFormat.version, nil).Body()
+
+	ruleBody.SetAttributeValue("name", cty.StringVal(rule.Name))
+	if rule.Options.IsSecure {
+		ruleBody.SetAttributeValue("is_secure", cty.True)
+	}
+	setStringIfNotEmpty(ruleBody, "comments", rule.Comments)
+	setStringIfNotEmpty(ruleBody, "criteria_must_satisfy", string(rule.CriteriaMustSatisfy))
+	setStringIfNotEmpty(ruleBody, "uuid", rule.UUID)
+	setStringIfNotEmpty(ruleBody, "template_uuid", rule.TemplateUuid)
+	setStringIfNotEmpty(ruleBody, "template_link", rule.TemplateLink)
+	if rule.CriteriaLocked {
+		ruleBody.SetAttributeValue("criteria_locked", cty.True)
+	}
+	setStringIfNotEmpty(ruleBody, "advanced_override", rule.AdvancedOverride)
+	if rule.CustomOverride != nil {
+		customOverride := ruleBody.AppendNewBlock("custom_override", nil).Body()
+		customOverride.SetAttributeValue("name", cty.StringVal(rule.CustomOverride.Name))
+		customOverride.SetAttributeValue("override_id", cty.StringVal(rule.CustomOverride.OverrideID))
+	}
+
+	for _, variable := range rule.Variables {
+		variableBody := ruleBody.AppendNewBlock("variable", nil).Body()
+		variableBody.SetAttributeValue("name", cty.StringVal(variable.Name))
+		variableBody.SetAttributeValue("value", cty.StringVal(stringOrEmpty(variable.Value)))
+		variableBody.SetAttributeValue("description", cty.StringVal(stringOrEmpty(variable.Description)))
+		variableBody.SetAttributeValue("hidden", cty.BoolVal(variable.Hidden))
+		variableBody.SetAttributeValue("sensitive", cty.BoolVal(variable.Sensitive))
+	}
+
+	for _, criterion := range rule.Criteria {
+		e.exportBehavior(ruleBody.AppendNewBlock("criterion", nil).Body(), criterion, e.criteria, e.ruleFormat.criteriaSchemas)
+	}
+	for _, behavior := range rule.Behaviors {
+		e.exportBehavior(ruleBody.AppendNewBlock("behavior", nil).Body(), behavior, e.behaviors, e.ruleFormat.behaviorsSchemas)
+	}
+
+	if len(rule.Children) > 0 {
+		children := make([]hclwrite.Tokens, 0, len(rule.Children))
+		for _, child := range rule.Children {
+			childLabel := e.exportRule(child)
+			children = append(children, hclwrite.TokensForTraversal(hcl.Traversal{
+				hcl.TraverseRoot{Name: "data"},
+				hcl.TraverseAttr{Name: rulesBuilderDataSource},
+				hcl.TraverseAttr{Name: childLabel},
+				hcl.TraverseAttr{Name: "json"},
+			}))
+		}
+		ruleBody.SetAttributeRaw("children", hclwrite.TokensForTuple(children))
+	}
+
+	return label
+}
+
+func (e *hclExporter) exportBehavior(body *hclwrite.Body, behavior papi.RuleBehavior, keys map[string]string, schemas map[string]*schema.Schema) {
+	key := keys[behavior.Name]
+	behaviorBody := body.AppendNewBlock(key, nil).Body()
+	if behavior.Locked {
+		behaviorBody.SetAttributeValue("locked", cty.True)
+	}
+	setStringIfNotEmpty(behaviorBody, "uuid", behavior.UUID)
+	setStringIfNotEmpty(behaviorBody, "template_uuid", behavior.TemplateUuid)
+
+	if options, ok := schemas[key].Elem.(*schema.Resource); ok {
+		e.exportOptions(behaviorBody, behavior.Name, behavior.Options, options.Schema)
+	}
+}
+
+// exportOptions sets the options of a behavior, criterion or a nested object, identified by the dot-separated names,
+// e.g. 'cpCode.value', as attributes and blocks of the body
+func (e *hclExporter) exportOptions(body *hclwrite.Body, name string, options map[string]any, schemas map[string]*schema.Schema) {
+	keys := schemaKeysByJSONName(schemas, e.ruleFormat.nameMappings)
+	for _, option := range sortedKeys(options) {
+		value := options[option]
+		if value == nil {
+			continue
+		}
+		key := keys[option]
+		optionName := name + "." + option
+		s := schemas[key]
+
+		elem, isObject := s.Elem.(*schema.Resource)
+		switch {
+		case s.Type == schema.TypeList && isObject && e.shouldFlatten(optionName):
+			e.exportOptions(body.AppendNewBlock(key, nil).Body(), optionName, value.(map[string]any), elem.Schema)
+		case s.Type == schema.TypeList && isObject:
+			items := value.([]any)
+			if len(items) == 0 {
+				body.AppendNewBlock(key, nil)
+			}
+			for _, item := range items {
+				e.exportOptions(body.AppendNewBlock(key, nil).Body(), optionName, item.(map[string]any), elem.Schema)
+			}
+		default:
+			body.SetAttributeValue(key, optionValue(value, s))
+		}
+	}
+}
+
+func optionValue(value any, s *schema.Schema) cty.Value {
+	switch v := value.(type) {
+	case bool:
+		return cty.BoolVal(v)
+	case float64:
+		// the API expects some of the enum values in a different type, see TypeMappings
+		if s.Type == schema.TypeString {
+			return cty.StringVal(fmt.Sprintf("%v", v))
+		}
+		return cty.NumberFloatVal(v)
+	case string:
+		return cty.StringVal(v)
+	case []any:
+		elem, _ := s.Elem.(*schema.Schema)
+		if len(v) == 0 {
+			return cty.ListValEmpty(primitiveType(elem))
+		}
+		items := make([]cty.Value, 0, len(v))
+		for _, item := range v {
+			items = append(items, optionValue(item, elem))
+		}
+		return cty.ListVal(items)
+	default:
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+}
+
+func primitiveType(s *schema.Schema) cty.Type {
+	if s == nil {
+		return cty.String
+	}
+	switch s.Type {
+	case schema.TypeBool:
+		return cty.Bool
+	case schema.TypeInt, schema.TypeFloat:
+		return cty.Number
+	default:
+		return cty.String
+	}
+}
+
+// label returns the unique data source label for the rule, derived from its name
+func (e *hclExporter) label(ruleName string) string {
+	label := strings.Trim(invalidLabelCharsRegexp.ReplaceAllString(strings.ToLower(ruleName), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "rule_" + label
+	}
+	unique := label
+	for n := 2; e.labels[unique]; n++ {
+		unique = fmt.Sprintf("%s_%d", label, n)
+	}
+	e.labels[unique] = true
+	return unique
+}
+
+func setStringIfNotEmpty(body *hclwrite.Body, name, value string) {
+	if value != "" {
+		body.SetAttributeValue(name, cty.StringVal(value))
+	}
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
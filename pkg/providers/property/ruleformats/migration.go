@@ -0,0 +1,307 @@
+package ruleformats
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	// MigrationResult contains the rule tree migrated to the target rule format,
+	// the changes applied to it and the violations of the target rule format which could not be migrated automatically.
+	MigrationResult struct {
+		Rules   papi.Rules
+		Changes []RuleChange
+		Issues  []RuleError
+	}
+
+	// RuleChange describes a change applied to the rule tree during the migration.
+	RuleChange struct {
+		// Path is the JSON path to the changed value in the source rule tree, e.g. '#/rules/behaviors/1/options/ttl'
+		Path    string
+		Message string
+	}
+
+	// rulesMigrator migrates behaviors and criteria of the rule tree between two rule formats
+	rulesMigrator struct {
+		source, target RuleFormat
+		renames        ruleFormatRenames
+		changes        []RuleChange
+	}
+
+	// ruleFormatRenames lists the behaviors, criteria and options renamed by the API between two rule formats.
+	// They map the dot-separated names in the source rule format, e.g. 'caching' or 'caching.ttl', to the new last segment
+	// of the name in the target rule format, e.g. 'cache' or 'maxAge'.
+	ruleFormatRenames struct {
+		behaviors, criteria map[string]string
+	}
+
+	// ruleFormatsPair identifies the migration from the source to the target rule format by their versions in the schema key format,
+	// e.g. 'rules_v2023_05_30' to 'rules_v2024_02_12'
+	ruleFormatsPair struct {
+		source, target string
+	}
+)
+
+// renamesByRuleFormats lists the renames made by the API, by the source and the target rule format of the migration.
+// Each direction of the migration is listed separately. Names changed only by the name mappings of the rule formats
+// map to the same key of terraform schema, so they are renamed without being listed here.
+// None of the behaviors, criteria and options was renamed between the rule formats bundled so far: those missing from
+// 'rules_v2023_05_30', i.e. frontEndOptimization, inputValidation and shutr, were removed without a replacement.
+var renamesByRuleFormats = map[ruleFormatsPair]ruleFormatRenames{}
+
+// MigrateRules migrates the rule tree from the source to the target rule format, e.g. from 'v2023-05-30' to 'v2024-02-12'.
+// Options removed from the target rule format are removed and values which type changed are converted, where possible.
+// Behaviors and criteria removed from the target rule format and values not matching the target rule format are left unchanged
+// and reported as issues, as they cannot be migrated automatically.
+//
+// Behaviors, criteria and options are matched between the rule formats by the keys of their terraform schemas, which are derived
+// from their names. Behaviors, criteria and options renamed by the API have different keys, so they are matched by the names
+// listed in renamesByRuleFormats instead. Those not listed there are reported as issues of the target rule format.
+func MigrateRules(sourceFormat, targetFormat string, rules papi.Rules) (*MigrationResult, error) {
+	source, ok := schemasRegistry.ruleFormat(sourceFormat)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedRuleFormat, sourceFormat)
+	}
+	target, ok := schemasRegistry.ruleFormat(targetFormat)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedRuleFormat, targetFormat)
+	}
+
+	migrated, err := copyRules(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	m := rulesMigrator{source: source, target: target, renames: renamesByRuleFormats[ruleFormatsPair{source.version, target.version}]}
+	m.migrateRule("#/rules", &migrated)
+
+	issues, err := ValidateRules(targetFormat, migrated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MigrationResult{
+		Rules:   migrated,
+		Changes: m.changes,
+		Issues:  issues,
+	}, nil
+}
+
+// names returns the renames of the behaviors or criteria and their options
+func (r ruleFormatRenames) names(kind string) map[string]string {
+	if kind == "criterion" {
+		return r.criteria
+	}
+	return r.behaviors
+}
+
+// targetKey returns the key of terraform schema in the target rule format of the behavior, criterion or option,
+// given its key and its dot-separated name in the source rule format, e.g. 'caching.ttl'
+func (m *rulesMigrator) targetKey(kind, key, name string, targetSchemas map[string]*schema.Schema) string {
+	newName, ok := m.renames.names(kind)[name]
+	if !ok {
+		return key
+	}
+	return schemaKeysByJSONName(targetSchemas, m.target.nameMappings)[newName]
+}
+
+func copyRules(rules papi.Rules) (papi.Rules, error) {
+	encoded, err := json.Marshal(rules)
+	if err != nil {
+		return papi.Rules{}, fmt.Errorf("copying rules: %w", err)
+	}
+	var copied papi.Rules
+	if err := json.Unmarshal(encoded, &copied); err != nil {
+		return papi.Rules{}, fmt.Errorf("copying rules: %w", err)
+	}
+	return copied, nil
+}
+
+func (m *rulesMigrator) addChange(path, format string, args ...any) {
+	m.changes = append(m.changes, RuleChange{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (m *rulesMigrator) migrateRule(path string, rule *papi.Rules) {
+	for i := range rule.Behaviors {
+		m.migrateBehavior(fmt.Sprintf("%s/behaviors/%d", path, i), "behavior", &rule.Behaviors[i],
+			m.source.behaviorsSchemas, m.target.behaviorsSchemas)
+	}
+	for i := range rule.Criteria {
+		m.migrateBehavior(fmt.Sprintf("%s/criteria/%d", path, i), "criterion", &rule.Criteria[i],
+			m.source.criteriaSchemas, m.target.criteriaSchemas)
+	}
+	for i := range rule.Children {
+		m.migrateRule(fmt.Sprintf("%s/children/%d", path, i), &rule.Children[i])
+	}
+}
+
+// migrateBehavior migrates the behavior or criterion known in the source rule format,
+// which is matched with the target rule format by the key of its terraform schema or by its name renamed by the API.
+func (m *rulesMigrator) migrateBehavior(path, kind string, behavior *papi.RuleBehavior, sourceSchemas, targetSchemas map[string]*schema.Schema) {
+	key, ok := schemaKeysByJSONName(sourceSchemas, m.source.nameMappings)[behavior.Name]
+	if !ok {
+		return
+	}
+	targetKey := m.targetKey(kind, key, behavior.Name, targetSchemas)
+	targetSchema, ok := targetSchemas[targetKey]
+	if !ok {
+		// reported by the validation of the target rule format
+		return
+	}
+
+	name := jsonName(targetKey, m.target.nameMappings)
+	if name != behavior.Name {
+		m.addChange(path+"/name", "%s %q renamed to %q", kind, behavior.Name, name)
+	}
+
+	sourceOptions, sourceOK := sourceSchemas[key].Elem.(*schema.Resource)
+	targetOptions, targetOK := targetSchema.Elem.(*schema.Resource)
+	if sourceOK && targetOK {
+		behavior.Options = m.migrateOptions(path+"/options", kind, behavior.Name, name, behavior.Options, sourceOptions.Schema, targetOptions.Schema)
+	}
+	behavior.Name = name
+}
+
+// migrateOptions returns the options of a behavior, criterion or a nested object migrated to the target rule format.
+// The options are identified by the dot-separated names in the source and in the target rule format, e.g. 'cpCode.value'.
+func (m *rulesMigrator) migrateOptions(path, kind, sourceName, targetName string, options map[string]any, sourceSchemas, targetSchemas map[string]*schema.Schema) map[string]any {
+	sourceKeys := schemaKeysByJSONName(sourceSchemas, m.source.nameMappings)
+	migrated := make(map[string]any, len(options))
+	for _, option := range sortedKeys(options) {
+		value := options[option]
+		optionPath := path + "/" + escapeJSONPointer(option)
+		key, ok := sourceKeys[option]
+		if !ok {
+			// reported by the validation of the target rule format
+			migrated[option] = value
+			continue
+		}
+		targetKey := m.targetKey(kind, key, sourceName+"."+option, targetSchemas)
+		targetSchema, ok := targetSchemas[targetKey]
+		if !ok {
+			m.addChange(optionPath, "option %q removed, as it is not supported by %q in the target rule format", option, targetName)
+			continue
+		}
+
+		newOption := jsonName(targetKey, m.target.nameMappings)
+		if newOption != option {
+			m.addChange(optionPath, "option %q renamed to %q", option, newOption)
+		}
+		migrated[newOption] = m.migrateValue(optionPath, kind, sourceName+"."+option, targetName+"."+newOption, value, sourceSchemas[key], targetSchema)
+	}
+	return migrated
+}
+
+func (m *rulesMigrator) migrateValue(path, kind, sourceName, targetName string, value any, sourceSchema, targetSchema *schema.Schema) any {
+	sourceElem, sourceOK := sourceSchema.Elem.(*schema.Resource)
+	targetElem, targetOK := targetSchema.Elem.(*schema.Resource)
+	if sourceOK && targetOK {
+		switch v := value.(type) {
+		case map[string]any:
+			return m.migrateOptions(path, kind, sourceName, targetName, v, sourceElem.Schema, targetElem.Schema)
+		case []any:
+			items := make([]any, 0, len(v))
+			for i, item := range v {
+				if object, ok := item.(map[string]any); ok {
+					item = m.migrateOptions(fmt.Sprintf("%s/%d", path, i), kind, sourceName, targetName, object, sourceElem.Schema, targetElem.Schema)
+				}
+				items = append(items, item)
+			}
+			return items
+		}
+		return value
+	}
+
+	if value == nil {
+		return value
+	}
+	if sourceSchema.Type != targetSchema.Type {
+		return m.convertType(path, value, targetSchema)
+	}
+	if targetSchema.Type != schema.TypeString {
+		return value
+	}
+	// the API expects some of the enum values in a different type, see TypeMappings
+	str, isString := value.(string)
+	if !isString {
+		str = fmt.Sprintf("%v", value)
+	}
+	mappedValue, mapped := m.target.typeMappings[targetName+"."+str]
+	switch {
+	case mapped && isString:
+		m.addChange(path, "value %q converted to %v", str, mappedValue)
+		return mappedValue
+	case !mapped && !isString:
+		if _, sourceMapped := m.source.typeMappings[sourceName+"."+str]; sourceMapped {
+			m.addChange(path, "value %v converted to %q", value, str)
+			return str
+		}
+	}
+	return value
+}
+
+// convertType converts the value of an option which type changed in the target rule format, e.g. from a string to a number,
+// or from a single value to a list. The value is left unchanged if it cannot be converted.
+func (m *rulesMigrator) convertType(path string, value any, targetSchema *schema.Schema) any {
+	if _, isList := value.([]any); !isList && targetSchema.Type == schema.TypeList {
+		if elem, ok := targetSchema.Elem.(*schema.Schema); ok {
+			if converted, ok := convertPrimitive(value, elem.Type); ok {
+				m.addChange(path, "value %v converted to a list", value)
+				return []any{converted}
+			}
+		}
+		return value
+	}
+
+	converted, ok := convertPrimitive(value, targetSchema.Type)
+	if !ok {
+		return value
+	}
+	if converted != value {
+		m.addChange(path, "value %v converted to %s", value, strings.ToLower(strings.TrimPrefix(targetSchema.Type.String(), "Type")))
+	}
+	return converted
+}
+
+// convertPrimitive returns the JSON value converted to the given type, if possible
+func convertPrimitive(value any, valueType schema.ValueType) (any, bool) {
+	switch valueType {
+	case schema.TypeString:
+		switch v := value.(type) {
+		case string:
+			return v, true
+		case float64, bool:
+			return fmt.Sprintf("%v", v), true
+		}
+	case schema.TypeInt:
+		switch v := value.(type) {
+		case float64:
+			return v, v == float64(int64(v))
+		case string:
+			i, err := strconv.ParseInt(v, 10, 64)
+			return float64(i), err == nil
+		}
+	case schema.TypeFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, true
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			return f, err == nil
+		}
+	case schema.TypeBool:
+		switch v := value.(type) {
+		case bool:
+			return v, true
+		case string:
+			b, err := strconv.ParseBool(v)
+			return b, err == nil
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,234 @@
+package ruleformats
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateRules(t *testing.T) {
+	tests := map[string]struct {
+		sourceFormat    string
+		targetFormat    string
+		rules           string
+		expectedRules   string
+		expectedChanges []RuleChange
+		expectedIssues  []RuleError
+		expectedError   error
+	}{
+		"same rule format": {
+			sourceFormat:  "v2024-02-12",
+			targetFormat:  "rules_v2024_02_12",
+			rules:         `{"name": "default", "behaviors": [{"name": "caching", "options": {"behavior": "MAX_AGE", "mustRevalidate": false, "ttl": "1d"}}]}`,
+			expectedRules: `{"name": "default", "behaviors": [{"name": "caching", "options": {"behavior": "MAX_AGE", "mustRevalidate": false, "ttl": "1d"}}]}`,
+		},
+		"option converted to list and removed behavior": {
+			sourceFormat: "v2023-01-05",
+			targetFormat: "v2023-05-30",
+			rules: `{
+				"name": "default",
+				"behaviors": [
+					{"name": "datastream", "options": {"enabled": true, "logStreamName": 1234}},
+					{"name": "shutr", "options": {}}
+				]
+			}`,
+			expectedRules: `{
+				"name": "default",
+				"behaviors": [
+					{"name": "datastream", "options": {"enabled": true, "logStreamName": ["1234"]}},
+					{"name": "shutr", "options": {}}
+				]
+			}`,
+			expectedChanges: []RuleChange{
+				{Path: "#/rules/behaviors/0/options/logStreamName", Message: "value 1234 converted to a list"},
+			},
+			expectedIssues: []RuleError{
+				{Path: "#/rules/behaviors/1/name", Message: `unknown behavior "shutr"`},
+			},
+		},
+		"options converted to numbers in child rule": {
+			sourceFormat: "v2023-05-30",
+			targetFormat: "v2024-02-12",
+			rules: `{
+				"name": "default",
+				"children": [
+					{
+						"name": "Variables",
+						"behaviors": [
+							{"name": "setVariable", "options": {"variableName": "PMUSER_PATH", "startIndex": "2", "endIndex": "last"}}
+						]
+					}
+				]
+			}`,
+			expectedRules: `{
+				"name": "default",
+				"children": [
+					{
+						"name": "Variables",
+						"behaviors": [
+							{"name": "setVariable", "options": {"variableName": "PMUSER_PATH", "startIndex": 2, "endIndex": "last"}}
+						]
+					}
+				]
+			}`,
+			expectedChanges: []RuleChange{
+				{Path: "#/rules/children/0/behaviors/0/options/startIndex", Message: "value 2 converted to int"},
+			},
+			expectedIssues: []RuleError{
+				{Path: "#/rules/children/0/behaviors/0/options/endIndex", Message: "expected integer, got string"},
+			},
+		},
+		"unsupported source rule format": {
+			sourceFormat:  "latest",
+			targetFormat:  "v2024-02-12",
+			rules:         `{"name": "default"}`,
+			expectedError: ErrUnsupportedRuleFormat,
+		},
+		"unsupported target rule format": {
+			sourceFormat:  "v2024-02-12",
+			targetFormat:  "v2099-01-01",
+			rules:         `{"name": "default"}`,
+			expectedError: ErrUnsupportedRuleFormat,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var rules papi.Rules
+			require.NoError(t, json.Unmarshal([]byte(test.rules), &rules))
+
+			result, err := MigrateRules(test.sourceFormat, test.targetFormat, rules)
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			migrated, err := json.Marshal(result.Rules)
+			require.NoError(t, err)
+			var expected papi.Rules
+			require.NoError(t, json.Unmarshal([]byte(test.expectedRules), &expected))
+			expectedJSON, err := json.Marshal(expected)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expectedJSON), string(migrated))
+			assert.Equal(t, test.expectedChanges, result.Changes)
+			assert.Equal(t, test.expectedIssues, result.Issues)
+
+			original, err := json.Marshal(rules)
+			require.NoError(t, err)
+			var source papi.Rules
+			require.NoError(t, json.Unmarshal([]byte(test.rules), &source))
+			sourceJSON, err := json.Marshal(source)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(sourceJSON), string(original), "source rules must not be modified")
+		})
+	}
+}
+
+func TestMigrateRulesRenames(t *testing.T) {
+	cacheOptions := func(ttl string) *schema.Resource {
+		return &schema.Resource{Schema: map[string]*schema.Schema{
+			ttl:    {Type: schema.TypeString, Optional: true},
+			"keep": {Type: schema.TypeBool, Optional: true},
+		}}
+	}
+	pathOptions := func(values string) *schema.Resource {
+		return &schema.Resource{Schema: map[string]*schema.Schema{
+			values: {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		}}
+	}
+	older := RuleFormat{
+		version:          "rules_v2090_01_01",
+		behaviorsSchemas: map[string]*schema.Schema{"old_cache": {Type: schema.TypeList, Optional: true, MaxItems: 1, Elem: cacheOptions("ttl")}},
+		criteriaSchemas:  map[string]*schema.Schema{"path": {Type: schema.TypeList, Optional: true, MaxItems: 1, Elem: pathOptions("values")}},
+	}
+	newer := RuleFormat{
+		version:          "rules_v2090_02_01",
+		behaviorsSchemas: map[string]*schema.Schema{"cache": {Type: schema.TypeList, Optional: true, MaxItems: 1, Elem: cacheOptions("max_age")}},
+		criteriaSchemas:  map[string]*schema.Schema{"path": {Type: schema.TypeList, Optional: true, MaxItems: 1, Elem: pathOptions("paths")}},
+	}
+
+	tests := map[string]struct {
+		source, target  RuleFormat
+		renames         ruleFormatRenames
+		rules           string
+		expectedRules   string
+		expectedChanges []RuleChange
+	}{
+		"renames applied": {
+			source: older,
+			target: newer,
+			renames: ruleFormatRenames{
+				behaviors: map[string]string{"oldCache": "cache", "oldCache.ttl": "maxAge"},
+				criteria:  map[string]string{"path.values": "paths"},
+			},
+			rules:         `{"name": "default", "behaviors": [{"name": "oldCache", "options": {"ttl": "1d", "keep": true}}], "criteria": [{"name": "path", "options": {"values": ["/a"]}}]}`,
+			expectedRules: `{"name": "default", "behaviors": [{"name": "cache", "options": {"maxAge": "1d", "keep": true}}], "criteria": [{"name": "path", "options": {"paths": ["/a"]}}]}`,
+			expectedChanges: []RuleChange{
+				{Path: "#/rules/behaviors/0/name", Message: `behavior "oldCache" renamed to "cache"`},
+				{Path: "#/rules/behaviors/0/options/ttl", Message: `option "ttl" renamed to "maxAge"`},
+				{Path: "#/rules/criteria/0/options/values", Message: `option "values" renamed to "paths"`},
+			},
+		},
+		"renames applied when migrating back": {
+			source: newer,
+			target: older,
+			renames: ruleFormatRenames{
+				behaviors: map[string]string{"cache": "oldCache", "cache.maxAge": "ttl"},
+			},
+			rules:         `{"name": "default", "behaviors": [{"name": "cache", "options": {"maxAge": "1d", "keep": true}}]}`,
+			expectedRules: `{"name": "default", "behaviors": [{"name": "oldCache", "options": {"ttl": "1d", "keep": true}}]}`,
+			expectedChanges: []RuleChange{
+				{Path: "#/rules/behaviors/0/name", Message: `behavior "cache" renamed to "oldCache"`},
+				{Path: "#/rules/behaviors/0/options/maxAge", Message: `option "maxAge" renamed to "ttl"`},
+			},
+		},
+		"renamed option not listed": {
+			source: older,
+			target: newer,
+			renames: ruleFormatRenames{
+				behaviors: map[string]string{"oldCache": "cache"},
+			},
+			rules:         `{"name": "default", "behaviors": [{"name": "oldCache", "options": {"ttl": "1d", "keep": true}}]}`,
+			expectedRules: `{"name": "default", "behaviors": [{"name": "cache", "options": {"keep": true}}]}`,
+			expectedChanges: []RuleChange{
+				{Path: "#/rules/behaviors/0/name", Message: `behavior "oldCache" renamed to "cache"`},
+				{Path: "#/rules/behaviors/0/options/ttl", Message: `option "ttl" removed, as it is not supported by "cache" in the target rule format`},
+			},
+		},
+		"renamed behavior not listed": {
+			source:        older,
+			target:        newer,
+			rules:         `{"name": "default", "behaviors": [{"name": "oldCache", "options": {"ttl": "1d"}}]}`,
+			expectedRules: `{"name": "default", "behaviors": [{"name": "oldCache", "options": {"ttl": "1d"}}]}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var rules papi.Rules
+			require.NoError(t, json.Unmarshal([]byte(test.rules), &rules))
+
+			m := rulesMigrator{source: test.source, target: test.target, renames: test.renames}
+			m.migrateRule("#/rules", &rules)
+
+			var expected papi.Rules
+			require.NoError(t, json.Unmarshal([]byte(test.expectedRules), &expected))
+			assert.Equal(t, expected, rules)
+			assert.Equal(t, test.expectedChanges, m.changes)
+		})
+	}
+}
+
+func TestRenamesByRuleFormats(t *testing.T) {
+	for pair := range renamesByRuleFormats {
+		_, ok := schemasRegistry.ruleFormat(pair.source)
+		assert.True(t, ok, "unknown source rule format %q", pair.source)
+		_, ok = schemasRegistry.ruleFormat(pair.target)
+		assert.True(t, ok, "unknown target rule format %q", pair.target)
+	}
+}
@@ -1,6 +1,8 @@
 package ruleformats
 
 import (
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -99,6 +101,21 @@ func (r *registry) shouldFlattenFunc(ruleFormat string) func(string) bool {
 	panic("no flaten func for given rule format: " + ruleFormat)
 }
 
+// ruleFormat returns the rule format of given version, either in the schema key format, e.g. 'rules_v2024_02_12',
+// or in the format used by the API, e.g. 'v2024-02-12'
+func (r *registry) ruleFormat(version string) (RuleFormat, bool) {
+	key := version
+	if !strings.HasPrefix(key, "rules_") {
+		key = "rules_" + strings.ReplaceAll(version, "-", "_")
+	}
+	for _, rf := range r.rules {
+		if rf.version == key {
+			return rf, true
+		}
+	}
+	return RuleFormat{}, false
+}
+
 func (r *registry) versions() []string {
 	versions := make([]string, 0, len(r.rules))
 	for _, ruleFormat := range r.rules {
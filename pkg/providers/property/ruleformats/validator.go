@@ -0,0 +1,257 @@
+package ruleformats
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/iancoleman/strcase"
+)
+
+type (
+	// RuleError describes a violation of the rule format schema found in the rule tree.
+	RuleError struct {
+		// Path is the JSON path to the invalid value, e.g. '#/rules/children/0/behaviors/1/options/ttl'
+		Path    string
+		Message string
+	}
+
+	// rulesValidator validates behaviors and criteria of the rule tree against the schemas of a rule format
+	rulesValidator struct {
+		ruleFormat    RuleFormat
+		behaviors     map[string]string
+		criteria      map[string]string
+		shouldFlatten func(string) bool
+		errors        []RuleError
+	}
+)
+
+// variableRegexp matches the values referencing the rule variables, e.g. '{{user.PMUSER_ORIGIN}}'
+var variableRegexp = regexp.MustCompile(`^{{.+}}$`)
+
+// Error returns RuleError as a string.
+func (e RuleError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidateRules validates the behaviors and criteria of the rule tree against the schema of the given rule format,
+// e.g. 'v2024-02-12' or 'rules_v2024_02_12'. It reports unknown behaviors, criteria and options,
+// options of wrong type and values not matching the allowed values or patterns.
+//
+// If the rule format is not bundled with the provider, ErrUnsupportedRuleFormat is returned.
+func ValidateRules(ruleFormat string, rules papi.Rules) ([]RuleError, error) {
+	rf, ok := schemasRegistry.ruleFormat(ruleFormat)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedRuleFormat, ruleFormat)
+	}
+
+	v := rulesValidator{
+		ruleFormat:    rf,
+		behaviors:     schemaKeysByJSONName(rf.behaviorsSchemas, rf.nameMappings),
+		criteria:      schemaKeysByJSONName(rf.criteriaSchemas, rf.nameMappings),
+		shouldFlatten: ShouldFlattenFunc(rf.version),
+	}
+	v.validateRule("#/rules", rules)
+	return v.errors, nil
+}
+
+// schemaKeysByJSONName maps the names used in rules JSON to the keys of terraform schemas,
+// in the same way as the names are converted by RulesBuilder
+func schemaKeysByJSONName(schemas map[string]*schema.Schema, nameMappings map[string]string) map[string]string {
+	keys := make(map[string]string, len(schemas))
+	for key := range schemas {
+		keys[jsonName(key, nameMappings)] = key
+	}
+	return keys
+}
+
+// jsonName returns the name used in rules JSON for the key of terraform schema, in the same way as RulesBuilder
+func jsonName(key string, nameMappings map[string]string) string {
+	name := strcase.ToLowerCamel(key)
+	if mapped, ok := nameMappings[name]; ok {
+		return mapped
+	}
+	return name
+}
+
+func (v *rulesValidator) addError(path, format string, args ...any) {
+	v.errors = append(v.errors, RuleError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *rulesValidator) validateRule(path string, rule papi.Rules) {
+	if rule.CriteriaMustSatisfy != "" &&
+		rule.CriteriaMustSatisfy != papi.RuleCriteriaMustSatisfyAll && rule.CriteriaMustSatisfy != papi.RuleCriteriaMustSatisfyAny {
+		v.addError(path+"/criteriaMustSatisfy", "expected one of [all any], got %q", rule.CriteriaMustSatisfy)
+	}
+	for i, behavior := range rule.Behaviors {
+		v.validateBehavior(fmt.Sprintf("%s/behaviors/%d", path, i), "behavior", behavior, v.behaviors, v.ruleFormat.behaviorsSchemas)
+	}
+	for i, criterion := range rule.Criteria {
+		v.validateBehavior(fmt.Sprintf("%s/criteria/%d", path, i), "criterion", criterion, v.criteria, v.ruleFormat.criteriaSchemas)
+	}
+	for i, child := range rule.Children {
+		v.validateRule(fmt.Sprintf("%s/children/%d", path, i), child)
+	}
+}
+
+func (v *rulesValidator) validateBehavior(path, kind string, behavior papi.RuleBehavior, keys map[string]string, schemas map[string]*schema.Schema) {
+	key, ok := keys[behavior.Name]
+	if !ok {
+		v.addError(path+"/name", "unknown %s %q", kind, behavior.Name)
+		return
+	}
+	options, ok := schemas[key].Elem.(*schema.Resource)
+	if !ok {
+		return
+	}
+	v.validateOptions(path+"/options", behavior.Name, behavior.Options, options.Schema)
+}
+
+// validateOptions validates the options of a behavior, criterion or a nested object,
+// identified by the dot-separated names, e.g. 'cpCode.value'
+func (v *rulesValidator) validateOptions(path, name string, options map[string]any, schemas map[string]*schema.Schema) {
+	keys := schemaKeysByJSONName(schemas, v.ruleFormat.nameMappings)
+	for _, option := range sortedKeys(options) {
+		value := options[option]
+		optionPath := path + "/" + escapeJSONPointer(option)
+		key, ok := keys[option]
+		if !ok {
+			v.addError(optionPath, "unknown option %q of %q", option, name)
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		v.validateValue(optionPath, name+"."+option, value, schemas[key])
+	}
+}
+
+//nolint:gocyclo
+func (v *rulesValidator) validateValue(path, name string, value any, s *schema.Schema) {
+	// a variable can be referenced in place of a value of any scalar type
+	if str, ok := value.(string); ok && variableRegexp.MatchString(str) && s.Type != schema.TypeList {
+		return
+	}
+	switch s.Type {
+	case schema.TypeBool:
+		if _, ok := value.(bool); !ok {
+			v.addError(path, "expected boolean, got %s", jsonType(value))
+		}
+	case schema.TypeInt:
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			v.addError(path, "expected integer, got %s", jsonType(value))
+			return
+		}
+		v.validateWithSchema(path, name, int(number), s)
+	case schema.TypeFloat:
+		number, ok := value.(float64)
+		if !ok {
+			v.addError(path, "expected number, got %s", jsonType(value))
+			return
+		}
+		v.validateWithSchema(path, name, number, s)
+	case schema.TypeString:
+		str, ok := value.(string)
+		if !ok {
+			// the API expects some of the enum values in a different type, see TypeMappings
+			mappedValue := fmt.Sprintf("%v", value)
+			if _, mapped := v.ruleFormat.typeMappings[name+"."+mappedValue]; !mapped {
+				v.addError(path, "expected string, got %s", jsonType(value))
+				return
+			}
+			str = mappedValue
+		}
+		v.validateWithSchema(path, name, str, s)
+	case schema.TypeList:
+		v.validateList(path, name, value, s)
+	}
+}
+
+func (v *rulesValidator) validateList(path, name string, value any, s *schema.Schema) {
+	if v.shouldFlatten(name) {
+		object, ok := value.(map[string]any)
+		if !ok {
+			v.addError(path, "expected object, got %s", jsonType(value))
+			return
+		}
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			v.validateOptions(path, name, object, elem.Schema)
+		}
+		return
+	}
+
+	items, ok := value.([]any)
+	if !ok {
+		v.addError(path, "expected array, got %s", jsonType(value))
+		return
+	}
+	for i, item := range items {
+		itemPath := fmt.Sprintf("%s/%d", path, i)
+		switch elem := s.Elem.(type) {
+		case *schema.Resource:
+			object, ok := item.(map[string]any)
+			if !ok {
+				v.addError(itemPath, "expected object, got %s", jsonType(item))
+				continue
+			}
+			v.validateOptions(itemPath, name, object, elem.Schema)
+		case *schema.Schema:
+			if item == nil {
+				v.addError(itemPath, "expected %s, got null", strings.ToLower(strings.TrimPrefix(elem.Type.String(), "Type")))
+				continue
+			}
+			v.validateValue(itemPath, name, item, elem)
+		}
+	}
+}
+
+// validateWithSchema runs the validation of terraform schema against the value.
+// Values referencing rule variables are not validated, as they are resolved by the API.
+func (v *rulesValidator) validateWithSchema(path, name string, value any, s *schema.Schema) {
+	if s.ValidateDiagFunc == nil {
+		return
+	}
+	option := name[strings.LastIndex(name, ".")+1:]
+	for _, d := range s.ValidateDiagFunc(value, cty.GetAttrPath(option)) {
+		v.addError(path, "%s", d.Summary)
+	}
+}
+
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapeJSONPointer escapes the reference token as defined in RFC 6901
+func escapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
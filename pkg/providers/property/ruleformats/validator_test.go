@@ -0,0 +1,100 @@
+package ruleformats
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRules(t *testing.T) {
+	tests := map[string]struct {
+		ruleFormat    string
+		rules         string
+		expected      []RuleError
+		expectedError error
+	}{
+		"valid rules": {
+			ruleFormat: "v2024-02-12",
+			rules: `{
+				"name": "default",
+				"behaviors": [
+					{"name": "caching", "options": {"behavior": "MAX_AGE", "mustRevalidate": false, "ttl": "{{user.PMUSER_TTL}}"}},
+					{"name": "cpCode", "options": {"value": {"id": 12345, "cpCodeLimits": null}}},
+					{"name": "adScalerCircuitBreaker", "options": {"returnErrorResponseCodeBased": 502}}
+				],
+				"children": [
+					{
+						"name": "Images",
+						"criteriaMustSatisfy": "any",
+						"criteria": [{"name": "path", "options": {"matchOperator": "MATCHES_ONE_OF", "values": ["/img/*"]}}]
+					}
+				]
+			}`,
+		},
+		"variables in place of integer and boolean values": {
+			ruleFormat: "v2024-02-12",
+			rules: `{
+				"name": "default",
+				"behaviors": [
+					{"name": "caching", "options": {"behavior": "MAX_AGE", "mustRevalidate": "{{user.PMUSER_REVALIDATE}}", "ttl": "1d"}},
+					{"name": "adScalerCircuitBreaker", "options": {"returnErrorResponseCodeBased": "{{user.PMUSER_CODE}}"}}
+				]
+			}`,
+		},
+		"schema key format": {
+			ruleFormat: "rules_v2023_01_05",
+			rules:      `{"name": "default", "behaviors": [{"name": "caching", "options": {"behavior": "NO_STORE"}}]}`,
+		},
+		"invalid rules": {
+			ruleFormat: "v2024-02-12",
+			rules: `{
+				"name": "default",
+				"behaviors": [
+					{"name": "caching", "options": {"behavior": "FOREVER", "mustRevalidate": "no", "ttl": "1 day", "unknownOption": 1}},
+					{"name": "cpCode", "options": {"value": [{"id": "abc"}]}},
+					{"name": "teleport", "options": {}}
+				],
+				"children": [
+					{
+						"name": "Images",
+						"criteriaMustSatisfy": "some",
+						"criteria": [{"name": "path", "options": {"matchOperator": "MATCHES_ONE_OF", "values": [1]}}]
+					}
+				]
+			}`,
+			expected: []RuleError{
+				{Path: "#/rules/behaviors/0/options/behavior", Message: "expected behavior to be one of [MAX_AGE NO_STORE BYPASS_CACHE CACHE_CONTROL_AND_EXPIRES CACHE_CONTROL EXPIRES], got FOREVER"},
+				{Path: "#/rules/behaviors/0/options/mustRevalidate", Message: "expected boolean, got string"},
+				{Path: "#/rules/behaviors/0/options/ttl", Message: `value ttl: "1 day" does not match the pattern "^[0-9]+[DdHhMmSs]$|{{.+}}"`},
+				{Path: "#/rules/behaviors/0/options/unknownOption", Message: `unknown option "unknownOption" of "caching"`},
+				{Path: "#/rules/behaviors/1/options/value", Message: "expected object, got array"},
+				{Path: "#/rules/behaviors/2/name", Message: `unknown behavior "teleport"`},
+				{Path: "#/rules/children/0/criteriaMustSatisfy", Message: `expected one of [all any], got "some"`},
+				{Path: "#/rules/children/0/criteria/0/options/values/0", Message: "expected string, got number"},
+			},
+		},
+		"unsupported rule format": {
+			ruleFormat:    "latest",
+			rules:         `{"name": "default"}`,
+			expectedError: ErrUnsupportedRuleFormat,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var rules papi.Rules
+			require.NoError(t, json.Unmarshal([]byte(test.rules), &rules))
+
+			errs, err := ValidateRules(test.ruleFormat, rules)
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package property
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+)
+
+type (
+	// ruleNode is a rule of the rule tree with its path built from the names of the rule and its ancestors,
+	// e.g. '/default/Performance/Compression'
+	ruleNode struct {
+		path   string
+		parent string
+		rule   papi.Rules
+	}
+
+	// ruleChange is a single change between two rule trees of the rule at the path
+	ruleChange struct {
+		kind        string
+		path        string
+		description string
+	}
+
+	// rulesDiff collects the changes between two rule trees
+	rulesDiff struct {
+		changes []ruleChange
+	}
+)
+
+const (
+	changeAdded    = "added"
+	changeRemoved  = "removed"
+	changeModified = "modified"
+)
+
+var changePrefixes = map[string]string{
+	changeAdded:    "+",
+	changeRemoved:  "-",
+	changeModified: "~",
+}
+
+// diffRules returns the structural changes between the old and the new rule tree:
+// rules added, removed or moved by path, changed behaviors, criteria, their options and variables.
+// Every change is a line prefixed with '+' for additions, '-' for removals and '~' for modifications.
+func diffRules(oldRules, newRules papi.Rules) []string {
+	changes := compareRuleTrees(oldRules, newRules)
+	if len(changes) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		lines = append(lines, fmt.Sprintf("%s %s: %s", changePrefixes[change.kind], change.path, change.description))
+	}
+	return lines
+}
+
+// compareRuleTrees returns the changes between the old and the new rule tree in the order of the new tree,
+// followed by the removed rules and the reordered children
+func compareRuleTrees(oldRules, newRules papi.Rules) []ruleChange {
+	oldNodes, newNodes := flattenRules(oldRules), flattenRules(newRules)
+	oldByPath := make(map[string]ruleNode, len(oldNodes))
+	for _, node := range oldNodes {
+		oldByPath[node.path] = node
+	}
+	newPaths := make(map[string]bool, len(newNodes))
+	for _, node := range newNodes {
+		newPaths[node.path] = true
+	}
+
+	var diff rulesDiff
+	// matched maps the paths of the new rules to the paths of the old ones
+	matched := make(map[string]string, len(newNodes))
+	oldMatched := make(map[string]bool, len(oldNodes))
+	for _, node := range newNodes {
+		oldPath, moved := translatePath(node, matched)
+		if oldNode, ok := oldByPath[oldPath]; ok && !oldMatched[oldPath] {
+			matched[node.path] = oldPath
+			oldMatched[oldPath] = true
+			diff.compareRules(node.path, oldNode.rule, node.rule)
+			continue
+		}
+		if !moved {
+			if oldNode, ok := findMovedRule(node, oldNodes, newPaths, oldMatched); ok {
+				matched[node.path] = oldNode.path
+				oldMatched[oldNode.path] = true
+				diff.add(changeModified, node.path, "rule moved from %s", oldNode.path)
+				diff.compareRules(node.path, oldNode.rule, node.rule)
+				continue
+			}
+		}
+		if _, parentMatched := matched[node.parent]; parentMatched || node.parent == "" {
+			diff.add(changeAdded, node.path, "rule added")
+		}
+	}
+
+	for _, node := range oldNodes {
+		if !oldMatched[node.path] && (node.parent == "" || oldMatched[node.parent]) {
+			diff.add(changeRemoved, node.path, "rule removed")
+		}
+	}
+
+	diff.compareChildrenOrder(newNodes, matched, oldNodes)
+	return diff.changes
+}
+
+// flattenRules returns the rules of the tree in pre-order.
+// Siblings with the same name are distinguished by their occurrence, e.g. '/default/Images[2]'.
+func flattenRules(rules papi.Rules) []ruleNode {
+	var nodes []ruleNode
+	var walk func(parent string, rule papi.Rules, name string)
+	walk = func(parent string, rule papi.Rules, name string) {
+		node := ruleNode{path: parent + "/" + name, parent: parent, rule: rule}
+		nodes = append(nodes, node)
+		for _, child := range childNames(rule.Children) {
+			walk(node.path, child.rule, child.name)
+		}
+	}
+	walk("", rules, rules.Name)
+	return nodes
+}
+
+type namedRule struct {
+	name string
+	rule papi.Rules
+}
+
+func childNames(children []papi.Rules) []namedRule {
+	occurrences := make(map[string]int, len(children))
+	named := make([]namedRule, 0, len(children))
+	for _, child := range children {
+		occurrences[child.Name]++
+		name := child.Name
+		if n := occurrences[child.Name]; n > 1 {
+			name = fmt.Sprintf("%s[%d]", child.Name, n)
+		}
+		named = append(named, namedRule{name: name, rule: child})
+	}
+	return named
+}
+
+// translatePath returns the path the rule had in the old tree, if any of its ancestors was moved
+func translatePath(node ruleNode, matched map[string]string) (string, bool) {
+	for ancestor := node.parent; ancestor != ""; ancestor = ancestor[:strings.LastIndex(ancestor, "/")] {
+		if oldAncestor, ok := matched[ancestor]; ok && oldAncestor != ancestor {
+			return oldAncestor + strings.TrimPrefix(node.path, ancestor), true
+		}
+	}
+	return node.path, false
+}
+
+// findMovedRule returns the removed rule of the same name as the added one.
+// The rule with the same content is preferred.
+func findMovedRule(node ruleNode, oldNodes []ruleNode, newPaths, oldMatched map[string]bool) (ruleNode, bool) {
+	var candidate *ruleNode
+	for i, oldNode := range oldNodes {
+		if oldNode.parent == "" || oldMatched[oldNode.path] || newPaths[oldNode.path] || oldNode.rule.Name != node.rule.Name {
+			continue
+		}
+		if ruleContentEqual(oldNode.rule, node.rule) {
+			return oldNode, true
+		}
+		if candidate == nil {
+			candidate = &oldNodes[i]
+		}
+	}
+	if candidate == nil {
+		return ruleNode{}, false
+	}
+	return *candidate, true
+}
+
+func ruleContentEqual(oldRule, newRule papi.Rules) bool {
+	var diff rulesDiff
+	diff.compareRules("", oldRule, newRule)
+	return len(diff.changes) == 0
+}
+
+func (d *rulesDiff) add(kind, path, format string, args ...any) {
+	d.changes = append(d.changes, ruleChange{kind: kind, path: path, description: fmt.Sprintf(format, args...)})
+}
+
+// compareRules compares the content of the rule without its children
+func (d *rulesDiff) compareRules(path string, oldRule, newRule papi.Rules) {
+	if oldRule.Comments != newRule.Comments {
+		d.add(changeModified, path, "comments changed")
+	}
+	if oldRule.CriteriaMustSatisfy != newRule.CriteriaMustSatisfy {
+		d.add(changeModified, path, "criteriaMustSatisfy %s -> %s", formatRuleValue(oldRule.CriteriaMustSatisfy), formatRuleValue(newRule.CriteriaMustSatisfy))
+	}
+	if oldRule.Options.IsSecure != newRule.Options.IsSecure {
+		d.add(changeModified, path, "is_secure %t -> %t", oldRule.Options.IsSecure, newRule.Options.IsSecure)
+	}
+	if oldRule.AdvancedOverride != newRule.AdvancedOverride {
+		d.add(changeModified, path, "advancedOverride changed")
+	}
+	if !reflect.DeepEqual(oldRule.CustomOverride, newRule.CustomOverride) {
+		d.add(changeModified, path, "customOverride %s -> %s", formatRuleValue(oldRule.CustomOverride), formatRuleValue(newRule.CustomOverride))
+	}
+	d.compareBehaviors(path, "behavior", oldRule.Behaviors, newRule.Behaviors)
+	d.compareBehaviors(path, "criterion", oldRule.Criteria, newRule.Criteria)
+	d.compareVariables(path, oldRule.Variables, newRule.Variables)
+}
+
+// compareBehaviors compares the behaviors or criteria of the rule matched by their names.
+// Repeated behaviors are matched by their occurrence.
+func (d *rulesDiff) compareBehaviors(path, kind string, oldBehaviors, newBehaviors []papi.RuleBehavior) {
+	oldByName := behaviorsByName(oldBehaviors)
+	newByName := behaviorsByName(newBehaviors)
+
+	for _, name := range behaviorNames(newBehaviors) {
+		newBehavior := newByName[name]
+		oldBehavior, ok := oldByName[name]
+		if !ok {
+			d.add(changeAdded, path, "%s %q added", kind, name)
+			continue
+		}
+		if oldBehavior.Locked != newBehavior.Locked {
+			d.add(changeModified, path, "%s %q locked %t -> %t", kind, name, oldBehavior.Locked, newBehavior.Locked)
+		}
+		d.compareOptions(path, kind, name, oldBehavior.Options, newBehavior.Options)
+	}
+	for _, name := range behaviorNames(oldBehaviors) {
+		if _, ok := newByName[name]; !ok {
+			d.add(changeRemoved, path, "%s %q removed", kind, name)
+		}
+	}
+}
+
+func (d *rulesDiff) compareOptions(path, kind, name string, oldOptions, newOptions papi.RuleOptionsMap) {
+	keys := make(map[string]struct{}, len(oldOptions)+len(newOptions))
+	for key := range oldOptions {
+		keys[key] = struct{}{}
+	}
+	for key := range newOptions {
+		keys[key] = struct{}{}
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, key := range sortedKeys {
+		oldValue, oldOK := oldOptions[key]
+		newValue, newOK := newOptions[key]
+		switch {
+		case !oldOK:
+			d.add(changeAdded, path, "%s %q option %q = %s", kind, name, key, formatRuleValue(newValue))
+		case !newOK:
+			d.add(changeRemoved, path, "%s %q option %q = %s", kind, name, key, formatRuleValue(oldValue))
+		case formatRuleValue(oldValue) != formatRuleValue(newValue):
+			d.add(changeModified, path, "%s %q option %q: %s -> %s", kind, name, key, formatRuleValue(oldValue), formatRuleValue(newValue))
+		}
+	}
+}
+
+func (d *rulesDiff) compareVariables(path string, oldVariables, newVariables []papi.RuleVariable) {
+	oldByName := make(map[string]papi.RuleVariable, len(oldVariables))
+	for _, v := range oldVariables {
+		oldByName[v.Name] = v
+	}
+	newByName := make(map[string]papi.RuleVariable, len(newVariables))
+	for _, v := range newVariables {
+		newByName[v.Name] = v
+		oldVariable, ok := oldByName[v.Name]
+		if !ok {
+			d.add(changeAdded, path, "variable %q added", v.Name)
+			continue
+		}
+		if !reflect.DeepEqual(oldVariable, v) {
+			d.add(changeModified, path, "variable %q changed", v.Name)
+		}
+	}
+	for _, v := range oldVariables {
+		if _, ok := newByName[v.Name]; !ok {
+			d.add(changeRemoved, path, "variable %q removed", v.Name)
+		}
+	}
+}
+
+// compareChildrenOrder reports the rules which children kept in both trees are in different order
+func (d *rulesDiff) compareChildrenOrder(newNodes []ruleNode, matched map[string]string, oldNodes []ruleNode) {
+	oldOrder := make(map[string][]string)
+	for _, node := range oldNodes {
+		oldOrder[node.parent] = append(oldOrder[node.parent], node.path)
+	}
+	oldIndex := make(map[string]int, len(oldNodes))
+	for _, children := range oldOrder {
+		for i, path := range children {
+			oldIndex[path] = i
+		}
+	}
+
+	newOrder := make(map[string][]string)
+	var parents []string
+	for _, node := range newNodes {
+		oldParent, ok := matched[node.parent]
+		oldPath, matchedNode := matched[node.path]
+		if !ok || !matchedNode || oldPath[:strings.LastIndex(oldPath, "/")] != oldParent {
+			continue
+		}
+		if _, seen := newOrder[node.parent]; !seen {
+			parents = append(parents, node.parent)
+		}
+		newOrder[node.parent] = append(newOrder[node.parent], oldPath)
+	}
+	for _, parent := range parents {
+		children := newOrder[parent]
+		if !sort.SliceIsSorted(children, func(i, j int) bool {
+			return oldIndex[children[i]] < oldIndex[children[j]]
+		}) {
+			d.add(changeModified, parent, "children reordered")
+		}
+	}
+}
+
+func behaviorsByName(behaviors []papi.RuleBehavior) map[string]papi.RuleBehavior {
+	byName := make(map[string]papi.RuleBehavior, len(behaviors))
+	names := behaviorNames(behaviors)
+	for i, behavior := range behaviors {
+		byName[names[i]] = behavior
+	}
+	return byName
+}
+
+func behaviorNames(behaviors []papi.RuleBehavior) []string {
+	occurrences := make(map[string]int, len(behaviors))
+	names := make([]string, 0, len(behaviors))
+	for _, behavior := range behaviors {
+		occurrences[behavior.Name]++
+		name := behavior.Name
+		if n := occurrences[behavior.Name]; n > 1 {
+			name = fmt.Sprintf("%s[%d]", behavior.Name, n)
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func formatRuleValue(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// removeNilRuleTreeOptions removes the options with null values added by PAPI from behaviors and criteria of the whole rule tree,
+// so that they are not reported as changes
+func removeNilRuleTreeOptions(rules *papi.Rules) {
+	for _, b := range rules.Behaviors {
+		removeNils(b.Options)
+	}
+	for _, c := range rules.Criteria {
+		removeNils(c.Options)
+	}
+	for i := range rules.Children {
+		removeNilRuleTreeOptions(&rules.Children[i])
+	}
+}
@@ -0,0 +1,125 @@
+package property
+
+import (
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffRules(t *testing.T) {
+	origin := func(hostname string) papi.RuleBehavior {
+		return papi.RuleBehavior{Name: "origin", Options: papi.RuleOptionsMap{"hostname": hostname, "httpPort": 80}}
+	}
+	images := papi.Rules{
+		Name:      "Images",
+		Criteria:  []papi.RuleBehavior{{Name: "fileExtension", Options: papi.RuleOptionsMap{"values": []any{"jpg"}}}},
+		Behaviors: []papi.RuleBehavior{{Name: "caching", Options: papi.RuleOptionsMap{"ttl": "1d"}}},
+	}
+	compression := papi.Rules{Name: "Compression", Behaviors: []papi.RuleBehavior{{Name: "gzipResponse", Options: papi.RuleOptionsMap{"behavior": "ALWAYS"}}}}
+	base := papi.Rules{
+		Name:      "default",
+		Behaviors: []papi.RuleBehavior{origin("origin.example.com")},
+		Children: []papi.Rules{
+			{Name: "Offload", Children: []papi.Rules{images}},
+			{Name: "Performance", Children: []papi.Rules{compression}},
+		},
+	}
+
+	tests := map[string]struct {
+		newRules func() papi.Rules
+		expected []string
+	}{
+		"no changes": {
+			newRules: func() papi.Rules { return base },
+		},
+		"behavior option changed": {
+			newRules: func() papi.Rules {
+				rules := base
+				rules.Behaviors = []papi.RuleBehavior{{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "new.example.com", "httpsPort": 443}}}
+				return rules
+			},
+			expected: []string{
+				`~ /default: behavior "origin" option "hostname": "origin.example.com" -> "new.example.com"`,
+				`- /default: behavior "origin" option "httpPort" = 80`,
+				`+ /default: behavior "origin" option "httpsPort" = 443`,
+			},
+		},
+		"behaviors and criteria added and removed": {
+			newRules: func() papi.Rules {
+				changedImages := images
+				changedImages.Criteria = []papi.RuleBehavior{{Name: "path", Options: papi.RuleOptionsMap{"values": []any{"/img/*"}}}}
+				changedImages.CriteriaMustSatisfy = papi.RuleCriteriaMustSatisfyAny
+				changedImages.Behaviors = append(changedImages.Behaviors, papi.RuleBehavior{Name: "caching", Options: papi.RuleOptionsMap{"ttl": "2d"}})
+				rules := base
+				rules.Children = []papi.Rules{
+					{Name: "Offload", Children: []papi.Rules{changedImages}},
+					base.Children[1],
+				}
+				return rules
+			},
+			expected: []string{
+				`~ /default/Offload/Images: criteriaMustSatisfy "" -> "any"`,
+				`+ /default/Offload/Images: behavior "caching[2]" added`,
+				`+ /default/Offload/Images: criterion "path" added`,
+				`- /default/Offload/Images: criterion "fileExtension" removed`,
+			},
+		},
+		"rules added and removed": {
+			newRules: func() papi.Rules {
+				rules := base
+				rules.Children = []papi.Rules{
+					base.Children[0],
+					{Name: "Security", Children: []papi.Rules{{Name: "WAF"}}},
+				}
+				return rules
+			},
+			expected: []string{
+				`+ /default/Security: rule added`,
+				`- /default/Performance: rule removed`,
+			},
+		},
+		"rule moved with its children": {
+			newRules: func() papi.Rules {
+				movedImages := images
+				movedImages.Children = nil
+				rules := base
+				rules.Children = []papi.Rules{
+					{Name: "Offload"},
+					{Name: "Performance", Children: []papi.Rules{compression, movedImages}},
+				}
+				return rules
+			},
+			expected: []string{
+				`~ /default/Performance/Images: rule moved from /default/Offload/Images`,
+			},
+		},
+		"children reordered": {
+			newRules: func() papi.Rules {
+				rules := base
+				rules.Children = []papi.Rules{base.Children[1], base.Children[0]}
+				return rules
+			},
+			expected: []string{
+				`~ /default: children reordered`,
+			},
+		},
+		"variables changed": {
+			newRules: func() papi.Rules {
+				value := "1"
+				rules := base
+				rules.Variables = []papi.RuleVariable{{Name: "PMUSER_TEST", Value: &value}}
+				return rules
+			},
+			expected: []string{
+				`+ /default: variable "PMUSER_TEST" added`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, diffRules(base, test.newRules()))
+		})
+	}
+}
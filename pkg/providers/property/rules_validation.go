@@ -0,0 +1,62 @@
+package property
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v8/pkg/papi"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/meta"
+	"github.com/akamai/terraform-provider-akamai/v6/pkg/providers/property/ruleformats"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateRulesCustomDiff validates the rules JSON of akamai_property and akamai_property_include resources
+// against the schema of their frozen rule format bundled with the provider, so that invalid behaviors and options
+// are reported at plan time instead of when the rules are updated.
+// Rules using the 'latest' rule format or a rule format which is not bundled with the provider are not validated,
+// nor are the rules of resources with skip_rules_validation set.
+func validateRulesCustomDiff(_ context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if diff.Get("skip_rules_validation").(bool) {
+		return nil
+	}
+	if diff.Id() != "" && !diff.HasChanges("rules", "rule_format", "skip_rules_validation") {
+		return nil
+	}
+	if !diff.NewValueKnown("rules") || !diff.NewValueKnown("rule_format") {
+		return nil
+	}
+	rulesJSON, ruleFormat := diff.Get("rules").(string), diff.Get("rule_format").(string)
+	if rulesJSON == "" || ruleFormat == "" || ruleFormat == "latest" {
+		return nil
+	}
+
+	var rulesUpdate papi.RulesUpdate
+	if err := json.Unmarshal([]byte(rulesJSON), &rulesUpdate); err != nil {
+		return fmt.Errorf("cannot parse rules JSON from config: %s", err)
+	}
+
+	ruleErrors, err := ruleformats.ValidateRules(ruleFormat, rulesUpdate.Rules)
+	if errors.Is(err, ruleformats.ErrUnsupportedRuleFormat) {
+		meta.Must(m).Log("PAPI", "validateRulesCustomDiff").Debugf("rules are not validated: %s", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(ruleErrors) > 0 {
+		return fmt.Errorf("rules do not match rule format %s:\n%s\nSet skip_rules_validation to disable this validation",
+			ruleFormat, formatRuleErrors(ruleErrors))
+	}
+	return nil
+}
+
+func formatRuleErrors(ruleErrors []ruleformats.RuleError) string {
+	lines := make([]string, 0, len(ruleErrors))
+	for _, e := range ruleErrors {
+		lines = append(lines, e.Error())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,42 @@
+data "akamai_property_rules_builder" "default" {
+  rules_v2024_02_12 {
+    name = "default"
+    variable {
+      name        = "PMUSER_ORIGIN"
+      value       = "origin.example.com"
+      description = ""
+      hidden      = false
+      sensitive   = false
+    }
+    behavior {
+      cp_code {
+        value {
+          id   = 12345
+          name = "my cp code"
+        }
+      }
+    }
+    children = [data.akamai_property_rules_builder.static_content.json]
+  }
+}
+
+data "akamai_property_rules_builder" "static_content" {
+  rules_v2024_02_12 {
+    name                  = "Static Content"
+    criteria_must_satisfy = "any"
+    criterion {
+      file_extension {
+        match_case_sensitive = false
+        match_operator       = "IS_ONE_OF"
+        values               = ["jpg", "png"]
+      }
+    }
+    behavior {
+      caching {
+        behavior        = "MAX_AGE"
+        must_revalidate = false
+        ttl             = "1d"
+      }
+    }
+  }
+}
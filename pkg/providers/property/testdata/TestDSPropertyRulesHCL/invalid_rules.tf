@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_hcl" "test" {
+  rule_format = "v2024-02-12"
+  rules       = jsonencode({ rules = { name = "default", behaviors = [{ name = "teleport", options = {} }] } })
+}
@@ -0,0 +1,33 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_hcl" "test" {
+  rule_format = "v2024-02-12"
+  rules = jsonencode({
+    rules = {
+      name = "default"
+      behaviors = [
+        {
+          name    = "cpCode"
+          options = { value = { id = 12345, name = "my cp code" } }
+        }
+      ]
+      variables = [
+        { name = "PMUSER_ORIGIN", value = "origin.example.com", description = "", hidden = false, sensitive = false }
+      ]
+      children = [
+        {
+          name                = "Static Content"
+          criteriaMustSatisfy = "any"
+          criteria = [
+            { name = "fileExtension", options = { matchOperator = "IS_ONE_OF", values = ["jpg", "png"], matchCaseSensitive = false } }
+          ]
+          behaviors = [
+            { name = "caching", options = { behavior = "MAX_AGE", mustRevalidate = false, ttl = "1d" } }
+          ]
+        }
+      ]
+    }
+  })
+}
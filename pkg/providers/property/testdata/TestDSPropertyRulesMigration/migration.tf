@@ -0,0 +1,30 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_migration" "test" {
+  source_rule_format = "v2023-01-05"
+  target_rule_format = "v2024-02-12"
+  rules = jsonencode({
+    rules = {
+      name = "default"
+      behaviors = [
+        {
+          name    = "datastream"
+          options = { enabled = true, logStreamName = 1234 }
+        }
+      ]
+      children = [
+        {
+          name      = "Variables"
+          comments  = "Extracts the path"
+          behaviors = [{ name = "setVariable", options = { variableName = "PMUSER_PATH", startIndex = "2", endIndex = "last" } }]
+        },
+        {
+          name      = "SureRoute"
+          behaviors = [{ name = "shutr", options = {} }]
+        }
+      ]
+    }
+  })
+}
@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_migration" "test" {
+  source_rule_format = "v2015-08-17"
+  target_rule_format = "v2024-02-12"
+  rules              = jsonencode({ rules = { name = "default" } })
+}
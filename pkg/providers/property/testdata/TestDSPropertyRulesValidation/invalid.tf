@@ -0,0 +1,24 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_validation" "test" {
+  rule_format = "v2024-02-12"
+  rules = jsonencode({
+    rules = {
+      name = "default"
+      behaviors = [
+        {
+          name    = "caching"
+          options = { behavior = "MAX_AGE", mustRevalidate = "no", ttl = "1 day" }
+        }
+      ]
+      children = [
+        {
+          name      = "Teleport"
+          behaviors = [{ name = "teleport", options = {} }]
+        }
+      ]
+    }
+  })
+}
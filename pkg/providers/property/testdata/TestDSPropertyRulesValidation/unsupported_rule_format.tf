@@ -0,0 +1,8 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_validation" "test" {
+  rule_format = "v2015-08-17"
+  rules       = jsonencode({ rules = { name = "default" } })
+}
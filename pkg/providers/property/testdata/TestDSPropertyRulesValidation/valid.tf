@@ -0,0 +1,18 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_validation" "test" {
+  rule_format = "v2024-02-12"
+  rules = jsonencode({
+    rules = {
+      name = "default"
+      behaviors = [
+        {
+          name    = "caching"
+          options = { behavior = "MAX_AGE", mustRevalidate = false, ttl = "1d" }
+        }
+      ]
+    }
+  })
+}
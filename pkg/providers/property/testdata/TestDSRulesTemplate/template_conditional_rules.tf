@@ -0,0 +1,25 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_template" "test" {
+  template_file = "testdata/TestDSRulesTemplate/conditional-rules/main.json"
+  variables {
+    name  = "propertyName"
+    value = "example.com"
+    type  = "string"
+  }
+  variables {
+    name  = "compression"
+    value = "false"
+    type  = "bool"
+  }
+  variables {
+    name = "hostnames"
+    value = jsonencode([
+      { hostname = "www.example.com", origin = "origin-www.example.com", port = 80 },
+      { hostname = "api.example.com", origin = "origin-api.example.com", port = 8080 },
+    ])
+    type = "jsonBlock"
+  }
+}
@@ -0,0 +1,22 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_rules_template" "test" {
+  template_file = "testdata/TestDSRulesTemplate/conditional-rules/main.json"
+  variables {
+    name  = "propertyName"
+    value = "example.com"
+    type  = "string"
+  }
+  variables {
+    name  = "compression"
+    value = "true"
+    type  = "bool"
+  }
+  variables {
+    name  = "hostnames"
+    value = "[]"
+    type  = "jsonBlock"
+  }
+}
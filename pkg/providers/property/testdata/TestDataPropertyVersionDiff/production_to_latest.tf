@@ -0,0 +1,11 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_version_diff" "diff" {
+  group_id     = "grp_test"
+  contract_id  = "ctr_test"
+  property_id  = "prp_test"
+  from_version = "production"
+  to_version   = "latest"
+}
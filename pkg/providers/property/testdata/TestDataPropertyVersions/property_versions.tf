@@ -0,0 +1,9 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+data "akamai_property_versions" "versions" {
+  group_id    = "test"
+  contract_id = "ctr_test"
+  property_id = "test"
+}
@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_property_activation" "test" {
+  property_id                    = "test"
+  contact                        = ["user@example.com"]
+  version                        = 2
+  auto_acknowledge_rule_warnings = true
+  note                           = "property activation note"
+  rollback_on_failure            = true
+}
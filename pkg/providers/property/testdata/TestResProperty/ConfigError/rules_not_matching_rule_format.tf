@@ -0,0 +1,23 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_property" "test" {
+  name        = "test_property"
+  group_id    = "grp_0"
+  contract_id = "ctr_0"
+  product_id  = "prd_0"
+  rule_format = "v2024-02-12"
+
+  rules = jsonencode({
+    rules = {
+      name = "default"
+      children = [
+        {
+          name     = "Images"
+          criteria = [{ name = "fileExtension", options = { matchOperator = "IS_ONE_OF", values = "jpg" } }]
+        }
+      ]
+    }
+  })
+}
@@ -0,0 +1,17 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_property_activations" "test" {
+  contact        = ["user@example.com"]
+  all_or_nothing = true
+
+  property {
+    property_id = "prp_1"
+    version     = 2
+  }
+  property {
+    property_id = "prp_2"
+    version     = 1
+  }
+}
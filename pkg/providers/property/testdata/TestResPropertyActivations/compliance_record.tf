@@ -0,0 +1,19 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_property_activations" "test" {
+  contact = ["user@example.com"]
+  note    = "release"
+
+  compliance_record {
+    noncompliance_reason_emergency {
+      ticket_id = "JIRA-1"
+    }
+  }
+
+  property {
+    property_id = "prp_1"
+    version     = 2
+  }
+}
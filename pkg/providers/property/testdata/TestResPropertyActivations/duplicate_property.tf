@@ -0,0 +1,16 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_property_activations" "test" {
+  contact = ["user@example.com"]
+
+  property {
+    property_id = "prp_1"
+    version     = 2
+  }
+  property {
+    property_id = "1"
+    version     = 3
+  }
+}
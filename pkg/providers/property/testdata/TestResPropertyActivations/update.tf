@@ -0,0 +1,17 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_property_activations" "test" {
+  contact = ["user@example.com"]
+  note    = "release"
+
+  property {
+    property_id = "prp_1"
+    version     = 3
+  }
+  property {
+    property_id = "2"
+    version     = 1
+  }
+}
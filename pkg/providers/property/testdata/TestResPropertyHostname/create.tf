@@ -0,0 +1,12 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_property_hostname" "www" {
+  property_id            = "prp_1"
+  contract_id            = "ctr_1"
+  group_id               = "grp_1"
+  cname_from             = "www.example.com"
+  cname_to               = "www.example.com.edgesuite.net"
+  cert_provisioning_type = "CPS_MANAGED"
+}
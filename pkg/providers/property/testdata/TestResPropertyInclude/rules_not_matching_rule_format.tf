@@ -0,0 +1,23 @@
+provider "akamai" {
+  edgerc = "../../common/testutils/edgerc"
+}
+
+resource "akamai_property_include" "test" {
+  contract_id = "ctr_123"
+  group_id    = "grp_123"
+  name        = "test_include"
+  product_id  = "prd_test"
+  type        = "MICROSERVICES"
+  rule_format = "v2024-02-12"
+  rules = jsonencode({
+    rules = {
+      name = "default"
+      behaviors = [
+        {
+          name    = "caching"
+          options = { behavior = "FOREVER" }
+        }
+      ]
+    }
+  })
+}
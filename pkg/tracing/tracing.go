@@ -0,0 +1,148 @@
+// Package tracing contains the OpenTelemetry tracing of provider operations
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// ExporterOTLP exports the spans to an OTLP/HTTP endpoint
+	ExporterOTLP = "otlp"
+	// ExporterFile writes the spans as JSON to a local file
+	ExporterFile = "file"
+
+	// OperationIDKey is the span attribute with the OperationID of the provider operation
+	OperationIDKey = attribute.Key("akamai.operation_id")
+
+	tracerName  = "github.com/akamai/terraform-provider-akamai"
+	serviceName = "terraform-provider-akamai"
+)
+
+var (
+	// ErrUnsupportedExporter is returned when the configured tracing exporter is not supported
+	ErrUnsupportedExporter = errors.New("unsupported tracing exporter")
+	// ErrMissingFile is returned when the file exporter is configured without the file
+	ErrMissingFile = errors.New("tracing file is required")
+)
+
+// Config is the configuration of exporting the spans
+type Config struct {
+	// Exporter is either ExporterOTLP or ExporterFile, empty value disables tracing
+	Exporter string
+	// Endpoint is the URL of the OTLP/HTTP endpoint, defaults to the OTEL_EXPORTER_OTLP_ENDPOINT
+	// environment variable or http://localhost:4318
+	Endpoint string
+	// File is the path of the file the spans are written to by the file exporter
+	File string
+}
+
+type operationIDContextKey struct{}
+
+var (
+	mu             sync.Mutex
+	tracerProvider *sdktrace.TracerProvider
+	file           *os.File
+)
+
+// Configure sets up the exporting of spans.
+// Only the first configuration takes effect, as both muxed providers configure the same process.
+func Configure(ctx context.Context, cfg Config) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if tracerProvider != nil {
+		return nil
+	}
+
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case "":
+		return nil
+	case ExporterOTLP:
+		var opts []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		}
+		otlpExporter, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return fmt.Errorf("cannot create OTLP exporter: %w", err)
+		}
+		exporter = otlpExporter
+	case ExporterFile:
+		if cfg.File == "" {
+			return ErrMissingFile
+		}
+		f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return fmt.Errorf("cannot open tracing file: %w", err)
+		}
+		fileExporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return fmt.Errorf("cannot create file exporter: %w", err)
+		}
+		exporter, file = fileExporter, f
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedExporter, cfg.Exporter)
+	}
+
+	tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	return nil
+}
+
+// Shutdown exports the remaining spans and stops the tracing
+func Shutdown(ctx context.Context) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if tracerProvider == nil {
+		return nil
+	}
+	err := tracerProvider.Shutdown(ctx)
+	if file != nil {
+		err = errors.Join(err, file.Close())
+	}
+	tracerProvider, file = nil, nil
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	return err
+}
+
+// ContextWithOperationID returns a context carrying the OperationID, which is added to the spans started with it
+func ContextWithOperationID(ctx context.Context, operationID string) context.Context {
+	return context.WithValue(ctx, operationIDContextKey{}, operationID)
+}
+
+// StartSpan starts the span as a child of the span from the context.
+// The span carries the OperationID from the context, if present.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if operationID, ok := ctx.Value(operationIDContextKey{}).(string); ok && operationID != "" {
+		attrs = append(attrs, OperationIDKey.String(operationID))
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// RecordError marks the span as failed with the given error, nil error is ignored
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
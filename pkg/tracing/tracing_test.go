@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collector is a stand-in of the OpenTelemetry collector receiving the spans over OTLP/HTTP
+type collector struct {
+	mu    sync.Mutex
+	spans map[string]map[string]string
+}
+
+func newCollector(t *testing.T) (*collector, *httptest.Server) {
+	c := &collector{spans: make(map[string]map[string]string)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var req coltracepb.ExportTraceServiceRequest
+		require.NoError(t, proto.Unmarshal(body, &req))
+
+		c.mu.Lock()
+		for _, resourceSpans := range req.ResourceSpans {
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				for _, span := range scopeSpans.Spans {
+					attrs := make(map[string]string)
+					for _, attr := range span.Attributes {
+						attrs[attr.Key] = attr.Value.GetStringValue()
+					}
+					c.spans[span.Name] = attrs
+				}
+			}
+		}
+		c.mu.Unlock()
+
+		resp, err := proto.Marshal(&coltracepb.ExportTraceServiceResponse{})
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		_, err = w.Write(resp)
+		require.NoError(t, err)
+	}))
+	return c, srv
+}
+
+func TestConfigure(t *testing.T) {
+	t.Run("OTLP exporter", func(t *testing.T) {
+		c, srv := newCollector(t)
+		defer srv.Close()
+
+		require.NoError(t, Configure(context.Background(), Config{Exporter: ExporterOTLP, Endpoint: srv.URL}))
+		// only the first configuration takes effect
+		require.NoError(t, Configure(context.Background(), Config{Exporter: "unsupported"}))
+
+		ctx := ContextWithOperationID(context.Background(), "opID")
+		ctx, parent := StartSpan(ctx, "akamai_property.Create")
+		_, child := StartSpan(ctx, "property.pollActivation")
+		child.End()
+		parent.End()
+		require.NoError(t, Shutdown(context.Background()))
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		require.Len(t, c.spans, 2)
+		assert.Equal(t, "opID", c.spans["akamai_property.Create"][string(OperationIDKey)])
+		assert.Equal(t, "opID", c.spans["property.pollActivation"][string(OperationIDKey)])
+	})
+
+	t.Run("file exporter", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "spans.json")
+		require.NoError(t, Configure(context.Background(), Config{Exporter: ExporterFile, File: file}))
+
+		_, span := StartSpan(context.Background(), "GET papi")
+		span.End()
+		require.NoError(t, Shutdown(context.Background()))
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"Name":"GET papi"`)
+	})
+
+	t.Run("tracing is disabled by default", func(t *testing.T) {
+		require.NoError(t, Configure(context.Background(), Config{}))
+		_, span := StartSpan(context.Background(), "GET papi")
+		assert.False(t, span.IsRecording())
+		span.End()
+		require.NoError(t, Shutdown(context.Background()))
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		assert.ErrorIs(t, Configure(context.Background(), Config{Exporter: "zipkin"}), ErrUnsupportedExporter)
+		assert.ErrorIs(t, Configure(context.Background(), Config{Exporter: ExporterFile}), ErrMissingFile)
+	})
+}